@@ -0,0 +1,168 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    mkdir -p $BATS_TMPDIR/backups-$$/bac1 $BATS_TMPDIR/backups-$$/origin
+    mkdir -p $BATS_TMPDIR/restored-$$
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int primary key,
+    c0 int
+);
+INSERT INTO test VALUES (1,1),(2,2);
+SQL
+    dolt commit -am "created table test"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+    rm -rf $BATS_TMPDIR/backups-$$
+    rm -rf $BATS_TMPDIR/restored-$$
+}
+
+@test "backup: add, list and remove a backup" {
+    run dolt backup add bac1 file://../backups-$$/bac1
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt backup
+    [ "$status" -eq 0 ]
+    [ "$output" = "bac1" ]
+
+    run dolt backup -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "bac1 file://" ]] || false
+    [[ "$output" =~ "backups-$$/bac1" ]] || false
+
+    run dolt backup add bac1 file://../backups-$$/bac1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    run dolt backup add bac.1 file://../backups-$$/bac1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid backup name" ]] || false
+
+    run dolt remote
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt backup rm bac1
+    [ "$status" -eq 0 ]
+    run dolt backup
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt backup remove bac1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown backup" ]] || false
+}
+
+@test "backup: sync and restore a backup" {
+    dolt remote add origin file://../backups-$$/origin
+    dolt branch feature
+    dolt tag v1 -m "first tag"
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+
+    dolt backup add bac1 file://../backups-$$/bac1
+    run dolt backup sync bac1
+    [ "$status" -eq 0 ]
+
+    cd $BATS_TMPDIR/restored-$$
+    run dolt backup restore file://../backups-$$/bac1 repo
+    [ "$status" -eq 0 ]
+    cd repo
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "created table test" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* master" ]] || false
+    [[ "$output" =~ "feature" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin" ]] || false
+
+    run dolt backup
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 5 ]
+    [ "${lines[4]}" = "4" ]
+
+    run dolt diff --cached
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "+  | 3" ]] || false
+    [[ ! "$output" =~ "+  | 4" ]] || false
+
+    run dolt diff
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "+  | 4" ]] || false
+    [[ ! "$output" =~ "+  | 3" ]] || false
+}
+
+@test "backup: sync an existing backup" {
+    dolt backup add bac1 file://../backups-$$/bac1
+    dolt backup sync bac1
+
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt commit -am "added a row"
+    run dolt backup sync bac1
+    [ "$status" -eq 0 ]
+
+    cd $BATS_TMPDIR/restored-$$
+    dolt backup restore file://../backups-$$/bac1 repo
+    cd repo
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added a row" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "backup: sync does not write to the repository" {
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt add test
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    manifest=$(cat .dolt/noms/manifest)
+
+    dolt backup add bac1 file://../backups-$$/bac1
+    run dolt backup sync bac1
+    [ "$status" -eq 0 ]
+
+    [ "$(cat .dolt/noms/manifest)" = "$manifest" ]
+}
+
+@test "backup: restore fails for a database which is not a backup" {
+    dolt remote add origin file://../backups-$$/bac1
+    dolt push origin master
+
+    cd $BATS_TMPDIR/restored-$$
+    run dolt backup restore file://../backups-$$/bac1 repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "does not contain a dolt backup" ]] || false
+    [ ! -d repo ]
+    cd $BATS_TMPDIR/dolt-repo-$$
+}
+
+@test "backup: sync requires a known backup" {
+    run dolt backup sync bac1
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown backup" ]] || false
+}
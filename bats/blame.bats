@@ -136,3 +136,34 @@ SQL
     [ "$status" -eq 1 ]
     [[ "$output" =~ "no table named blame_test found" ]] || false
 }
+
+@test "blame: dolt_blame_ system table annotates each row" {
+    run dolt sql -q "select pk, email, message from dolt_blame_blame_test order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,bats-1@email.fake,create blame_test table" ]] || false
+    [[ "$output" =~ "2,bats-3@email.fake,replace richard with harry" ]] || false
+    [[ "$output" =~ "3,bats-4@email.fake,add more people to blame_test" ]] || false
+    [[ "$output" =~ "4,bats-4@email.fake,add more people to blame_test" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_blame_blame_test b join dolt_log l on b.commit_hash = l.commit_hash" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+}
+
+@test "blame: dolt_blame_ system table can be joined against the table" {
+    dolt sql -q "delete from blame_test where pk = 3"
+    dolt commit -am "remove alan"
+
+    run dolt sql -q "select t.name, b.message from blame_test t join dolt_blame_blame_test b on t.pk = b.pk order by t.pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Tom,create blame_test table" ]] || false
+    [[ "$output" =~ "Harry,replace richard with harry" ]] || false
+    [[ "$output" =~ "Betty,add more people to blame_test" ]] || false
+    [[ ! "$output" =~ "Alan" ]] || false
+}
+
+@test "blame: dolt_blame_ system table requires an existing table" {
+    run dolt sql -q "select * from dolt_blame_not_a_table"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table not found" ]] || false
+}
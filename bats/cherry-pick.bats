@@ -0,0 +1,92 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int primary key,
+    c0 int
+);
+INSERT INTO test VALUES (1,1),(2,2);
+SQL
+    dolt commit -am "created table test"
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt commit -am "added row 3"
+    dolt sql -q "UPDATE test SET c0 = 22 WHERE pk = 2;"
+    dolt commit -am "updated row 2"
+    dolt checkout master
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "cherry-pick: applies a single commit" {
+    run dolt cherry-pick other
+    [ $status -eq 0 ]
+    [[ "$output" =~ "updated row 2" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1,1" ]] || false
+    [[ "$output" =~ "2,22" ]] || false
+    [[ ! "$output" =~ "3,3" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "cherry-pick: refuses to run with uncommitted changes" {
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    run dolt cherry-pick other
+    [ $status -eq 1 ]
+    [[ "$output" =~ "your local changes would be overwritten by cherry-pick" ]] || false
+}
+
+@test "cherry-pick: refuses merge commits" {
+    dolt checkout -b merged
+    dolt sql -q "INSERT INTO test VALUES (5,5);"
+    dolt commit -am "added row 5"
+    dolt merge other
+    dolt commit -m "merged other"
+    dolt checkout master
+    run dolt cherry-pick merged
+    [ $status -eq 1 ]
+    [[ "$output" =~ "merge commit" ]] || false
+}
+
+@test "cherry-pick: conflicts are reported in dolt_conflicts" {
+    dolt sql -q "UPDATE test SET c0 = 222 WHERE pk = 2;"
+    dolt commit -am "updated row 2 on master"
+    run dolt cherry-pick other
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT our_c0, their_c0 FROM dolt_conflicts_test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "222,22" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    dolt commit -m "resolved cherry-pick"
+    run dolt sql -q "SELECT c0 FROM test WHERE pk = 2" -r csv
+    [[ "$output" =~ "22" ]] || false
+}
+
+@test "cherry-pick: DOLT_CHERRY_PICK applies a single commit" {
+    run dolt sql -q "SELECT DOLT_CHERRY_PICK('other~1');"
+    [ $status -eq 0 ]
+
+    run dolt log -n 1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "added row 3" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
@@ -290,6 +290,38 @@ SQL
     [[ ! "$output" =~ "CONFLICT" ]] || false
 }
 
+@test "conflict-detection: two branches add same column and rows. merge. no conflict" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  PRIMARY KEY (pk)
+);
+SQL
+    dolt add test
+    dolt commit -m "table created"
+    dolt branch add-column
+    dolt sql -q "alter table test add c0 bigint"
+    dolt sql -q "insert into test values (1, 1, 1)"
+    dolt add test
+    dolt commit -m "added column c0"
+    dolt checkout add-column
+    dolt sql -q "alter table test add c0 bigint"
+    dolt sql -q "insert into test values (2, 2, 2)"
+    dolt add test
+    dolt commit -m "added same column c0"
+    dolt checkout master
+    run dolt merge add-column
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "select * from test order by pk" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "pk,c1,c0" ]] || false
+    [[ "$output" =~ "1,1,1" ]] || false
+    [[ "$output" =~ "2,2,2" ]] || false
+}
+
 @test "conflict-detection: two branches add different column. merge. no conflict" {
     skip https://github.com/dolthub/dolt/issues/773
     dolt sql <<SQL
@@ -160,3 +160,101 @@ if rows[2] != "9,8,7,6,5,4".split(","):
     run dolt table import -u person_info export-csv.csv
     [ "$status" -eq 0 ]
 }
+
+@test "export-tables: export a table to parquet and import it" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v1 TINYINT UNSIGNED,
+  v2 DECIMAL(10,2),
+  v3 VARCHAR(20),
+  v4 DATE,
+  v5 DATETIME,
+  v6 DOUBLE,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES
+    (1,255,'-12.34','abc','2020-04-08','2020-04-08 11:11:11',1.5),
+    (2,NULL,NULL,NULL,NULL,NULL,NULL);
+SQL
+    run dolt table export test test.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f test.parquet ]
+
+    run dolt table import -c --pk=pk imported test.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt schema show imported
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\`pk\` bigint NOT NULL" ]] || false
+    [[ "$output" =~ "\`v1\` tinyint unsigned" ]] || false
+    [[ "$output" =~ "\`v2\` decimal(10,2)" ]] || false
+    [[ "$output" =~ "\`v3\` longtext" ]] || false
+    [[ "$output" =~ "\`v4\` date" ]] || false
+    [[ "$output" =~ "\`v5\` datetime" ]] || false
+    [[ "$output" =~ "\`v6\` double" ]] || false
+
+    run dolt sql -q "select * from imported order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "pk,v1,v2,v3,v4,v5,v6" ]
+    [ "${lines[1]}" = "1,255,-12.34,abc,2020-04-08 00:00:00 +0000 UTC,2020-04-08 11:11:11 +0000 UTC,1.5" ]
+    [ "${lines[2]}" = "2,,,,,," ]
+
+    run dolt table import -u imported test.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2" ]] || false
+}
+
+@test "export-tables: export a table to jsonl and import it" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v1 VARCHAR(20),
+  v2 DOUBLE,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES
+    (1,'abc',1.5),
+    (2,'line
+break',NULL);
+SQL
+    run dolt table export test test.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    run cat test.jsonl
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = '{"pk":1,"v1":"abc","v2":1.5}' ]
+    [ "${lines[1]}" = '{"pk":2,"v1":"line\nbreak"}' ]
+
+    dolt schema export test test.sql
+    sed -i.bak 's/`test`/`imported`/' test.sql
+    run dolt table import -c -s test.sql imported test.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Import completed successfully." ]] || false
+    run dolt sql -q "select count(*) from imported where v1 = 'line\nbreak'" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+
+    run dolt table import -c imported2 test.jsonl
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Please specify schema file for .jsonl tables." ]] || false
+}
+
+@test "export-tables: dolt table export jsonl to stdout and import from stdin" {
+    dolt sql -q "INSERT INTO test_int VALUES (0,1,2,3,4,5),(9,8,7,6,5,4)"
+    run dolt table export test_int --file-type jsonl
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = '{"c1":1,"c2":2,"c3":3,"c4":4,"c5":5,"pk":0}' ]
+    [ "${lines[1]}" = '{"c1":8,"c2":7,"c3":6,"c4":5,"c5":4,"pk":9}' ]
+
+    dolt table export test_int --file-type jsonl | dolt table import -r test_int --file-type ndjson
+    dolt sql -q "DELETE FROM test_int"
+    echo '{"pk":3,"c1":30}
+{"pk":4,"c2":40}' | dolt table import -u test_int --file-type jsonl
+    run dolt sql -q "select pk, c1, c2 from test_int order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "3,30," ]
+    [ "${lines[2]}" = "4,,40" ]
+}
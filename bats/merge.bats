@@ -358,3 +358,189 @@ SQL
     [[ "$output" =~ "pkpk" ]] || false
     [[ "$output" =~ "c1c1" ]] || false
 }
+
+@test "merge: merge policies resolve conflicting column changes" {
+    dolt sql <<SQL
+ALTER TABLE test1 ADD COLUMN updated datetime;
+INSERT INTO test1 VALUES (0, 10, 0, '2021-01-01 00:00:00'), (1, 10, 0, '2021-01-01 00:00:00');
+INSERT INTO dolt_merge_policies VALUES ('test1', 'c1', 'sum', NULL), ('test1', '*', 'latest', 'updated');
+SQL
+    dolt add .
+    dolt commit -m "added merge policies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test1 SET c1 = c1 + 5, c2 = 1, updated = '2021-01-03 00:00:00' WHERE pk = 0"
+    dolt commit -am "changed other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test1 SET c1 = c1 + 3, c2 = 2, updated = '2021-01-02 00:00:00' WHERE pk = 0"
+    dolt commit -am "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT pk, c1, c2 FROM test1 WHERE pk = 0" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0,18,1" ]] || false
+}
+
+@test "merge: merge policies leave unresolved changes as conflicts" {
+    dolt sql <<SQL
+INSERT INTO test1 VALUES (0, 0, 0);
+INSERT INTO dolt_merge_policies VALUES ('test1', 'c1', 'prefer_non_null', NULL);
+SQL
+    dolt add .
+    dolt commit -m "added merge policies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test1 SET c1 = NULL, c2 = 1 WHERE pk = 0"
+    dolt commit -am "changed other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test1 SET c1 = 1 WHERE pk = 0"
+    dolt commit -am "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+    run dolt sql -q "SELECT pk, c1, c2 FROM test1" -r csv
+    [[ "$output" =~ "0,1,1" ]] || false
+    dolt commit -m "merged"
+
+    dolt checkout other
+    dolt sql -q "UPDATE test1 SET c1 = 2 WHERE pk = 0"
+    dolt commit -am "changed other again"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test1 SET c1 = 3 WHERE pk = 0"
+    dolt commit -am "changed master again"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+}
+
+@test "merge: unknown merge policy is an error" {
+    dolt sql <<SQL
+INSERT INTO test1 VALUES (0, 0, 0);
+INSERT INTO dolt_merge_policies VALUES ('test1', 'c1', 'newest', NULL);
+SQL
+    dolt add .
+    dolt commit -m "added merge policies"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test1 SET c1 = 1 WHERE pk = 0"
+    dolt commit -am "changed other"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test1 SET c1 = 2 WHERE pk = 0"
+    dolt commit -am "changed master"
+
+    run dolt merge other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown merge policy 'newest'" ]] || false
+}
+
+@test "merge: merge a table whose primary key changed on our branch" {
+    dolt sql -q "INSERT INTO test1 VALUES (1,10,100),(2,20,200),(3,30,300)"
+    dolt commit -am "added rows"
+
+    dolt checkout -b other
+    dolt sql -q "UPDATE test1 SET c2 = 201 WHERE pk = 2"
+    dolt sql -q "INSERT INTO test1 VALUES (4,40,400)"
+    dolt commit -am "changed rows on other"
+
+    dolt checkout master
+    dolt sql <<SQL
+CREATE TABLE tmp (pk int NOT NULL, c1 int NOT NULL, c2 int, PRIMARY KEY (pk));
+INSERT INTO tmp SELECT * FROM test1;
+DROP TABLE test1;
+CREATE TABLE test1 (pk int NOT NULL, c1 int NOT NULL, c2 int, PRIMARY KEY (c1));
+INSERT INTO test1 SELECT * FROM tmp;
+DROP TABLE tmp;
+SQL
+    dolt add .
+    dolt commit -m "changed primary key to c1"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt schema show test1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'PRIMARY KEY (`c1`)' ]] || false
+
+    run dolt sql -q "SELECT pk, c1, c2 FROM test1 ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,20,201" ]] || false
+    [[ "$output" =~ "4,40,400" ]] || false
+    [ "${#lines[@]}" -eq 5 ]
+}
+
+@test "merge: merge a table whose primary key changed on their branch" {
+    dolt sql -q "INSERT INTO test1 VALUES (1,10,100),(2,20,200),(3,30,300)"
+    dolt commit -am "added rows"
+
+    dolt checkout -b other
+    dolt sql <<SQL
+CREATE TABLE tmp (pk int NOT NULL, c1 int NOT NULL, c2 int, PRIMARY KEY (pk));
+INSERT INTO tmp SELECT * FROM test1;
+DROP TABLE test1;
+CREATE TABLE test1 (pk int NOT NULL, c1 int NOT NULL, c2 int, PRIMARY KEY (c1));
+INSERT INTO test1 SELECT * FROM tmp;
+DROP TABLE tmp;
+SQL
+    dolt add .
+    dolt commit -m "changed primary key to c1"
+
+    dolt checkout master
+    dolt sql -q "UPDATE test1 SET c2 = 201 WHERE pk = 2"
+    dolt sql -q "INSERT INTO test1 VALUES (4,40,400)"
+    dolt commit -am "changed rows on master"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt schema show test1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 'PRIMARY KEY (`c1`)' ]] || false
+
+    run dolt sql -q "SELECT pk, c1, c2 FROM test1 WHERE c1 = 20" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,20,201" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "merge: rows which map to the same new primary key conflict" {
+    dolt sql -q "INSERT INTO test1 VALUES (1,10,100),(2,20,200)"
+    dolt commit -am "added rows"
+
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test1 VALUES (3,10,300)"
+    dolt commit -am "added a row with a duplicate c1"
+
+    dolt checkout master
+    dolt sql <<SQL
+CREATE TABLE tmp (pk int NOT NULL, c1 int NOT NULL, c2 int, PRIMARY KEY (pk));
+INSERT INTO tmp SELECT * FROM test1;
+DROP TABLE test1;
+CREATE TABLE test1 (pk int NOT NULL, c1 int NOT NULL, c2 int, PRIMARY KEY (c1));
+INSERT INTO test1 SELECT * FROM tmp;
+DROP TABLE tmp;
+SQL
+    dolt add .
+    dolt commit -m "changed primary key to c1"
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in test1" ]] || false
+
+    run dolt sql -q "SELECT our_pk, their_pk, their_c2 FROM dolt_conflicts_test1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,3,300" ]] || false
+}
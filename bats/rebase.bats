@@ -0,0 +1,158 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int primary key,
+    c0 int
+);
+INSERT INTO test VALUES (1,1),(2,2);
+SQL
+    dolt commit -am "created table test"
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt commit -am "added row 3"
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    dolt commit -am "added row 4"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (5,5);"
+    dolt commit -am "added row 5"
+    dolt checkout feature
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "rebase: replays commits onto upstream" {
+    run dolt rebase master
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/feature" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "5" ]] || false
+
+    run dolt log
+    [ $status -eq 0 ]
+    [[ "$output" =~ "added row 4" ]] || false
+    [[ "$output" =~ "added row 3" ]] || false
+    [[ "$output" =~ "added row 5" ]] || false
+
+    # master is now an ancestor of feature
+    run dolt merge master
+    [ $status -eq 0 ]
+    [[ "$output" =~ "up to date" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "rebase: does nothing when up to date" {
+    dolt checkout master
+    dolt merge feature
+    dolt commit -m "merged feature"
+
+    run dolt rebase feature
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Current branch master is up to date" ]] || false
+}
+
+@test "rebase: fast-forwards when there is nothing to replay" {
+    dolt checkout -b behind HEAD~2
+
+    run dolt rebase feature
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "added row 4" ]] || false
+}
+
+@test "rebase: refuses to run with uncommitted changes" {
+    dolt sql -q "INSERT INTO test VALUES (6,6);"
+    run dolt rebase master
+    [ $status -eq 1 ]
+    [[ "$output" =~ "your local changes would be overwritten by rebase" ]] || false
+}
+
+@test "rebase: stops on conflicts and continues once they are resolved" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3,33);"
+    dolt commit -am "added conflicting row 3"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "could not apply" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "You are currently rebasing" ]] || false
+
+    run dolt rebase master
+    [ $status -eq 1 ]
+
+    run dolt rebase --continue
+    [ $status -eq 1 ]
+    [[ "$output" =~ "resolve all conflicts" ]] || false
+
+    dolt conflicts resolve --theirs test
+    dolt add test
+    run dolt rebase --continue
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Successfully rebased" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3,3" ]] || false
+    [[ "$output" =~ "4,4" ]] || false
+    [[ "$output" =~ "5,5" ]] || false
+
+    run dolt log -n 2
+    [[ "$output" =~ "added row 4" ]] || false
+    [[ "$output" =~ "added row 3" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "You are currently rebasing" ]] || false
+}
+
+@test "rebase: abort restores the original branch" {
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3,33);"
+    dolt commit -am "added conflicting row 3"
+    dolt checkout feature
+
+    run dolt rebase master
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt rebase --abort
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3,3" ]] || false
+    [[ "$output" =~ "4,4" ]] || false
+    [[ ! "$output" =~ "5,5" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "added row 4" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt rebase --abort
+    [ $status -eq 1 ]
+}
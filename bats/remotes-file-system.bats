@@ -175,3 +175,142 @@ SQL
     [ ! -d test-repo ]
     cd ..
 }
+
+@test "remotes-file-system: shallow clone with --depth" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt add test
+    dolt commit -m "created table"
+    for i in 1 2 3; do
+        dolt sql -q "INSERT INTO test VALUES ($i, $i)"
+        dolt commit -am "inserted row $i"
+    done
+    dolt branch other HEAD~2
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt push origin other
+
+    cd dolt-repo-clones
+    run dolt clone --depth 2 file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    cd test-repo
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted row 3" ]] || false
+    [[ "$output" =~ "inserted row 2" ]] || false
+    [[ ! "$output" =~ "inserted row 1" ]] || false
+    [[ ! "$output" =~ "created table" ]] || false
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* master" ]] || false
+    [[ "$output" =~ "remotes/origin/master" ]] || false
+    [[ ! "$output" =~ "other" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_log" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt diff HEAD~1
+    [ "$status" -eq 0 ]
+
+    run dolt log HEAD~2
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "shallow clone" ]] || false
+
+    run dolt diff HEAD~2
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "shallow clone" ]] || false
+
+    # new commits can be made and pushed on top of a shallow clone
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt commit -am "inserted row 4"
+    dolt push origin master
+
+    cd ../..
+    dolt pull
+    run dolt log
+    [[ "$output" =~ "inserted row 4" ]] || false
+    [[ "$output" =~ "created table" ]] || false
+}
+
+@test "remotes-file-system: merge in a shallow clone stops at the boundary" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created table"
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "other commit"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (2)"
+    dolt commit -am "master commit 1"
+    dolt sql -q "INSERT INTO test VALUES (3)"
+    dolt commit -am "master commit 2"
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt push origin other
+
+    cd dolt-repo-clones
+    run dolt clone --depth 1 file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    cd test-repo
+    dolt fetch
+    dolt branch other origin/other
+
+    run dolt merge other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "shallow clone" ]] || false
+
+    run dolt sql -q "SELECT DOLT_MERGE('other')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "shallow clone" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "remotes-file-system: shallow clone of a single branch" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created table"
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "feature commit"
+    dolt checkout master
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt push origin feature
+
+    cd dolt-repo-clones
+    run dolt clone --depth 1 -b feature file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    cd test-repo
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* feature" ]] || false
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+    [[ ! "$output" =~ "master" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature commit" ]] || false
+    [[ ! "$output" =~ "created table" ]] || false
+
+    cd ..
+    run dolt clone --depth 0 file://../remotedir bad-depth
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "depth must be a positive number" ]] || false
+    cd test-repo
+}
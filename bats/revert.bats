@@ -0,0 +1,121 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int primary key,
+    c0 int
+);
+CREATE TABLE keyless (
+    c0 int,
+    c1 int
+);
+INSERT INTO test VALUES (1,1),(2,2);
+INSERT INTO keyless VALUES (1,1),(1,1);
+SQL
+    dolt commit -am "created tables"
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt sql -q "INSERT INTO keyless VALUES (3,3);"
+    dolt commit -am "added rows"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "revert: reverts HEAD" {
+    run dolt revert HEAD
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM keyless" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt log -n 1
+    [ $status -eq 0 ]
+    [[ "$output" =~ 'Revert "added rows"' ]] || false
+    [[ "$output" =~ "This reverts commit" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "revert: reverts a table creation" {
+    dolt sql -q "CREATE TABLE other (pk int primary key);"
+    dolt add .
+    dolt commit -m "created other"
+
+    run dolt revert HEAD
+    [ $status -eq 0 ]
+
+    run dolt ls
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "other" ]] || false
+}
+
+@test "revert: refuses to run with uncommitted changes" {
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    run dolt revert HEAD
+    [ $status -eq 1 ]
+    [[ "$output" =~ "your local changes would be overwritten by revert" ]] || false
+}
+
+@test "revert: stops on conflicts" {
+    dolt sql -q "UPDATE test SET c0 = 33 WHERE pk = 3;"
+    dolt commit -am "updated row 3"
+
+    run dolt revert HEAD~1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM dolt_conflicts_test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "updated row 3" ]] || false
+}
+
+@test "revert: reverts multiple commits" {
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    dolt commit -am "added row 4"
+
+    run dolt revert HEAD HEAD~1
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SELECT pk FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "${lines[2]}" = "2" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ 'Revert "added row 4" and "added rows"' ]] || false
+}
+
+@test "revert: refuses to revert multiple commits when one conflicts" {
+    dolt sql -q "UPDATE test SET c0 = 33 WHERE pk = 3;"
+    dolt commit -am "updated row 3"
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    dolt commit -am "added row 4"
+
+    run dolt revert HEAD HEAD~2
+    [ $status -eq 1 ]
+    [[ "$output" =~ "conflicts" ]] || false
+    [[ "$output" =~ "Nothing was reverted" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "added row 4" ]] || false
+}
@@ -0,0 +1,154 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE t (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  c2 BIGINT,
+  PRIMARY KEY (pk)
+);
+CREATE TABLE other_table (
+  pk BIGINT NOT NULL,
+  v BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO t VALUES (1,1,1),(2,2,2);
+INSERT INTO other_table VALUES (1,1);
+SQL
+    dolt add .
+    dolt commit -m "create tables"
+    dolt branch other
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "schema-conflicts: merge records schema conflicts and merges the other tables" {
+    dolt sql -q "ALTER TABLE t ADD c3 BIGINT"
+    dolt sql -q "INSERT INTO other_table VALUES (2,2)"
+    dolt add .
+    dolt commit -m "add c3 as bigint"
+    dolt checkout other
+    dolt sql -q "ALTER TABLE t ADD c3 VARCHAR(10)"
+    dolt sql -q "INSERT INTO other_table VALUES (3,3)"
+    dolt add .
+    dolt commit -m "add c3 as varchar"
+    dolt checkout master
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema): Merge conflict in t" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM other_table" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "schema changed: t" ]] || false
+
+    run dolt sql -q "SELECT table_name, object_type, our_name, their_name, our_definition, their_definition FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ 't,column,c3,c3,`c3` BIGINT,`c3` VARCHAR(10)' ]] || false
+
+    run dolt sql -q "SELECT unmerged_tables FROM dolt_merge_status" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t" ]] || false
+
+    run dolt conflicts cat t
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "two columns with the name 'c3'" ]] || false
+
+    run dolt add t
+    [ "$status" -eq 1 ]
+
+    run dolt commit -am "merge"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unresolved conflicts" ]] || false
+}
+
+@test "schema-conflicts: resolve schema conflicts with theirs" {
+    dolt sql -q "ALTER TABLE t ADD c3 BIGINT"
+    dolt sql -q "INSERT INTO t VALUES (3,3,3,3)"
+    dolt add .
+    dolt commit -m "add c3 as bigint"
+    dolt checkout other
+    dolt sql -q "ALTER TABLE t ADD c3 VARCHAR(10)"
+    dolt sql -q "UPDATE t SET c3 = 'x' WHERE pk = 1"
+    dolt add .
+    dolt commit -m "add c3 as varchar"
+    dolt checkout master
+    dolt merge other
+
+    run dolt conflicts resolve --schema --theirs t c4
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no schema conflict for a column or index named 'c4'" ]] || false
+
+    run dolt conflicts resolve --theirs t
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "schema conflicts which must be resolved first" ]] || false
+
+    run dolt conflicts resolve --schema --theirs t c3
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT count(*) FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt schema show t
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`c3` varchar(10)' ]] || false
+
+    run dolt sql -q "SELECT pk, c3 FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,x" ]] || false
+    [[ "$output" =~ "3," ]] || false
+
+    dolt add t
+    dolt commit -m "merge"
+}
+
+@test "schema-conflicts: resolving column conflicts with ours can surface index conflicts" {
+    dolt sql -q "ALTER TABLE t ADD c3 BIGINT DEFAULT 1"
+    dolt sql -q "CREATE INDEX idx ON t (c1)"
+    dolt add .
+    dolt commit -m "ours"
+    dolt checkout other
+    dolt sql -q "ALTER TABLE t ADD c3 BIGINT DEFAULT 2"
+    dolt sql -q "CREATE INDEX idx ON t (c2)"
+    dolt sql -q "INSERT INTO t VALUES (3,3,3,3)"
+    dolt add .
+    dolt commit -m "theirs"
+    dolt checkout master
+    dolt merge other
+
+    run dolt sql -q "SELECT object_type, our_name FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "column,c3" ]] || false
+    [[ ! "$output" =~ "index" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_schema_conflicts WHERE our_name = 'c3'"
+
+    run dolt sql -q "SELECT object_type, our_name, description FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "index,idx,two indexes with the name 'idx'" ]] || false
+
+    dolt conflicts resolve --schema --theirs t
+
+    run dolt schema show t
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "DEFAULT 1" ]] || false
+    [[ "$output" =~ 'KEY `idx` (`c2`)' ]] || false
+
+    run dolt sql -q "SELECT pk FROM t WHERE c2 = 3" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "schema changed" ]] || false
+}
@@ -216,4 +216,77 @@ teardown() {
   run dolt sql -r csv -q "SELECT * FROM dolt_conflicts"
   [ "$status" -eq 0 ]
   [[ "$output" =~ "$EXPECTED" ]] || false
-}
\ No newline at end of file
+}
+@test "sql-conflicts: update our values and resolve conflict" {
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0)"
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (1,0,0)"
+  dolt add .
+  dolt commit -m "initial values"
+  dolt branch feature_branch master
+  dolt SQL -q "UPDATE one_pk SET c1=1,c2=1 WHERE pk1=0"
+  dolt SQL -q "DELETE FROM one_pk WHERE pk1=1"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "UPDATE one_pk SET c1=2,c2=2"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  run dolt sql -q "UPDATE dolt_conflicts_one_pk SET their_c1 = 5 WHERE our_pk1 = 0"
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "cannot update column 'their_c1'" ]] || false
+
+  run dolt sql -q "UPDATE dolt_conflicts_one_pk SET our_pk1 = 5 WHERE our_pk1 = 0"
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "cannot update column 'our_pk1'" ]] || false
+
+  dolt sql -q "UPDATE dolt_conflicts_one_pk SET our_c1 = their_c1 + 10 WHERE our_pk1 = 0"
+  dolt sql -q "UPDATE dolt_conflicts_one_pk SET our_c1 = 7, our_c2 = their_c2 WHERE base_pk1 = 1"
+
+  EXPECTED=$( echo -e "base_pk1,our_pk1,our_c1,our_c2,their_c1\n0,0,12,1,2\n1,1,7,2,2" )
+  run dolt sql -r csv -q "SELECT base_pk1,our_pk1,our_c1,our_c2,their_c1 FROM dolt_conflicts_one_pk ORDER BY base_pk1"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "$EXPECTED" ]] || false
+
+  # edits are not written to the table until the conflicts are resolved
+  run dolt sql -r csv -q "SELECT * FROM one_pk ORDER BY pk1"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,1,1" ]] || false
+  [[ ! "$output" =~ "1,7,2" ]] || false
+
+  dolt sql -q "DELETE FROM dolt_conflicts_one_pk"
+
+  run dolt sql -r csv -q "SELECT * FROM one_pk ORDER BY pk1"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,12,1" ]] || false
+  [[ "$output" =~ "1,7,2" ]] || false
+
+  run dolt sql -r csv -q "SELECT * FROM dolt_conflicts"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "one_pk,0" ]] || false
+}
+
+@test "sql-conflicts: resolving ours uses updated our values" {
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0)"
+  dolt add .
+  dolt commit -m "initial values"
+  dolt branch feature_branch master
+  dolt SQL -q "UPDATE one_pk SET c1=1,c2=1 WHERE pk1=0"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "UPDATE one_pk SET c1=2,c2=2 WHERE pk1=0"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  dolt sql -q "UPDATE dolt_conflicts_one_pk SET our_c2 = 3"
+  dolt conflicts resolve --ours one_pk
+
+  run dolt sql -r csv -q "SELECT * FROM one_pk"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,1,3" ]] || false
+}
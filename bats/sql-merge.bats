@@ -468,3 +468,70 @@ get_head_commit() {
 get_working_hash() {
   dolt sql -q "select @@dolt_repo_$$_working" | sed -n 4p | sed -e 's/|//' -e 's/|//'  -e 's/ //'
 }
+
+@test "sql-merge: dolt_merge_status reports a conflicted merge" {
+    run dolt sql -q "SELECT * FROM dolt_merge_status" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "false,,,," ]] || false
+
+    dolt add test
+    dolt commit -m "add test"
+    dolt branch feature-branch
+
+    dolt sql -q "CREATE TABLE other (pk int primary key, c1 int)"
+    dolt sql -q "INSERT INTO other VALUES (1, 1)"
+    dolt add other
+    dolt commit -m "add other on master"
+    dolt checkout feature-branch
+    dolt sql -q "CREATE TABLE other (pk int primary key, c1 int)"
+    dolt sql -q "INSERT INTO other VALUES (1, 2)"
+    dolt add other
+    dolt commit -m "add other on feature-branch"
+    dolt checkout master
+
+    run dolt sql -q "SELECT DOLT_MERGE('feature-branch')"
+    [ $status -eq 1 ]
+    [[ "$output" =~ "merge has conflicts" ]] || false
+
+    FEATURE_HASH=$(dolt sql -q "SELECT hashof('feature-branch')" -r csv | tail -n 1)
+    run dolt sql -q "SELECT is_merging, source, source_commit, target, unmerged_tables FROM dolt_merge_status" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "true,feature-branch,$FEATURE_HASH,master,other" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "You are merging feature-branch ($FEATURE_HASH)" ]] || false
+
+    run dolt sql -q "SELECT DOLT_MERGE('--abort')"
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SELECT is_merging FROM dolt_merge_status" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "false" ]
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "You are merging" ]] || false
+}
+
+@test "sql-merge: dolt_merge_status reads merge state from dolt merge" {
+    dolt add test
+    dolt commit -m "add test"
+    dolt checkout -b feature-branch
+    dolt sql -q "INSERT INTO test VALUES (10)"
+    dolt commit -am "add 10 on feature-branch"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (20)"
+    dolt commit -am "add 20 on master"
+    dolt merge feature-branch
+
+    run dolt sql -q "SELECT is_merging, source, target, unmerged_tables FROM dolt_merge_status" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "true,feature-branch,master," ]] || false
+
+    dolt commit -m "merge feature-branch"
+
+    run dolt sql -q "SELECT is_merging FROM dolt_merge_status" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "false" ]
+}
@@ -251,7 +251,7 @@ teardown() {
     # Merge the test_branch into master. This should a fast forward merge.
     multi_query 0 "
     SET @@repo1_head = merge('test_branch');
-    INSERT INTO dolt_branches (name, hash) VALUES('master', @@repo1_head);"
+    REPLACE INTO dolt_branches (name, hash) VALUES('master', @@repo1_head);"
 
     # Validate tables and data on master
     server_query 0 "SET @@repo1_head=hashof('master');SHOW tables" ";Table\none_pk"
@@ -265,17 +265,17 @@ teardown() {
     SET @@repo1_head=hashof('master');
     UPDATE one_pk SET c1=10 WHERE pk=2;
     SET @@repo1_head=commit('-m', 'Change c 1 to 10');
-    INSERT INTO dolt_branches (name,hash) VALUES ('master', @@repo1_head);
+    REPLACE INTO dolt_branches (name,hash) VALUES ('master', @@repo1_head);
 
     SET @@repo1_head=hashof('test_branch');
     INSERT INTO one_pk (pk,c1,c2) VALUES (4,4,4);
     SET @@repo1_head=commit('-m', 'add 4');
-    INSERT INTO dolt_branches (name,hash) VALUES ('test_branch', @@repo1_head);"
+    REPLACE INTO dolt_branches (name,hash) VALUES ('test_branch', @@repo1_head);"
 
     multi_query 0 "
     SET @@repo1_head=hashof('master');
     SET @@repo1_head=merge('test_branch');
-    INSERT INTO dolt_branches (name, hash) VALUES('master', @@repo1_head);"
+    REPLACE INTO dolt_branches (name, hash) VALUES('master', @@repo1_head);"
 
     # Validate tables and data on master
     server_query 0 "SET @@repo1_head=hashof('master');SHOW tables" ";Table\none_pk"
@@ -459,6 +459,21 @@ SQL
 
 }
 
+@test "sql-server: reload attaches databases added to the multi-db-dir" {
+    skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
+
+    start_multi_db_server repo1
+    server_query 1 "SHOW DATABASES" "Database\ninformation_schema\nrepo1\nrepo2"
+
+    make_repo repo3
+    let PORT="$$ % (65536-1024) + 1024"
+    run dolt sql-server reload --port=$PORT
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Reloading server on port $PORT" ]] || false
+
+    server_query 1 "SHOW DATABASES" "Database\ninformation_schema\nrepo1\nrepo2\nrepo3"
+}
+
 @test "sql-server: DOLT_ADD, DOLT_COMMIT, DOLT_CHECKOUT, DOLT_MERGE work together in server mode" {
       skiponwindows "Has dependencies that are missing on the Jenkins Windows installation."
 
@@ -213,6 +213,40 @@ SQL
     [[ "$output" =~ '2,"""Hello"""' ]] || false
 }
 
+@test "sql: jsonl output" {
+    dolt sql <<SQL
+CREATE TABLE test (
+    a int primary key,
+    b float,
+    c varchar(20)
+);
+INSERT INTO test VALUES (1, 1.5, 'one'), (2, NULL, 'two'), (3, 3.5, NULL);
+SQL
+
+    run dolt sql -r jsonl -q "select * from test order by a"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[0]}" == '{"a":1,"b":1.5,"c":"one"}' ]
+    [ "${lines[1]}" == '{"a":2,"c":"two"}' ]
+    [ "${lines[2]}" == '{"a":3,"b":3.5}' ]
+
+    run dolt sql -r jsonl -q "select * from test where a > 5"
+    [ $status -eq 0 ]
+    [ "$output" == "" ]
+}
+
+@test "sql: jsonl output escapes strings" {
+    dolt sql -q "CREATE TABLE test (a int primary key, c varchar(20))"
+    dolt sql -q "INSERT INTO test VALUES (1, 'back\\\\slash'), (2, 'tab\there'), (3, '<a&b>')"
+
+    run dolt sql -r jsonl -q "select * from test order by a"
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [ "${lines[0]}" == '{"a":1,"c":"back\\slash"}' ]
+    [ "${lines[1]}" == '{"a":2,"c":"tab\there"}' ]
+    [ "${lines[2]}" == '{"a":3,"c":"<a&b>"}' ]
+}
+
 @test "sql: ambiguous column name" {
     run dolt sql -q "select pk,pk1,pk2 from one_pk,two_pk where c1=0"
     [ "$status" -eq 1 ]
@@ -0,0 +1,177 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test (
+    pk int primary key,
+    c0 int
+);
+INSERT INTO test VALUES (1,1),(2,2);
+SQL
+    dolt commit -am "created table test"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "stash: stashes and pops working set changes" {
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+
+    run dolt stash
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Saved working directory and index state WIP on master" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt stash list
+    [ $status -eq 0 ]
+    [[ "$output" =~ "stash@{0}: WIP on master" ]] || false
+
+    run dolt stash pop
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{0}" ]] || false
+
+    run dolt sql -q "SELECT COUNT(*) FROM test" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt stash list
+    [ $status -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "stash: nothing to stash" {
+    run dolt stash
+    [ $status -eq 0 ]
+    [[ "$output" =~ "No local changes to save" ]] || false
+
+    run dolt stash list
+    [ $status -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt stash pop
+    [ $status -eq 1 ]
+    [[ "$output" =~ "No stash entries found" ]] || false
+}
+
+@test "stash: stashes staged changes and new tables" {
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt add test
+    dolt sql -q "CREATE TABLE other (pk int primary key);"
+
+    dolt stash
+    run dolt ls
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "other" ]] || false
+
+    dolt stash pop
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Changes to be committed" ]] || false
+    [[ "$output" =~ "modified:       test" ]] || false
+    [[ "$output" =~ "Untracked files" ]] || false
+    [[ "$output" =~ "new table:      other" ]] || false
+}
+
+@test "stash: lets you switch branches and pop onto another branch" {
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    dolt commit -am "added row 4"
+    dolt checkout master
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+
+    run dolt checkout other
+    [ $status -eq 1 ]
+
+    dolt stash
+    dolt checkout other
+
+    run dolt stash pop
+    [ $status -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3,3" ]] || false
+    [[ "$output" =~ "4,4" ]] || false
+}
+
+@test "stash: keeps the stash when popping results in conflicts" {
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt stash
+    dolt sql -q "INSERT INTO test VALUES (3,33);"
+    dolt commit -am "added conflicting row 3"
+
+    run dolt stash pop
+    [ $status -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "Nothing was changed and the stash entry is kept" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt stash list
+    [[ "$output" =~ "stash@{0}" ]] || false
+}
+
+@test "stash: keeps the stash when popping staged changes results in conflicts" {
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt add test
+    dolt sql -q "UPDATE test SET c0 = 33 WHERE pk = 3;"
+    dolt stash
+    dolt sql -q "INSERT INTO test VALUES (3,33);"
+    dolt commit -am "added row 3"
+
+    run dolt stash pop
+    [ $status -eq 1 ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "Nothing was changed and the stash entry is kept" ]] || false
+
+    run dolt status
+    [ $status -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt stash list
+    [[ "$output" =~ "stash@{0}" ]] || false
+}
+
+@test "stash: list and drop multiple entries" {
+    dolt sql -q "INSERT INTO test VALUES (3,3);"
+    dolt stash
+    dolt sql -q "INSERT INTO test VALUES (4,4);"
+    dolt commit -am "added row 4"
+    dolt sql -q "INSERT INTO test VALUES (5,5);"
+    dolt stash
+
+    run dolt stash list
+    [ $status -eq 0 ]
+    [[ "${lines[0]}" =~ "stash@{0}: WIP on master" ]] || false
+    [[ "${lines[0]}" =~ "added row 4" ]] || false
+    [[ "${lines[1]}" =~ "stash@{1}: WIP on master" ]] || false
+    [[ "${lines[1]}" =~ "created table test" ]] || false
+
+    run dolt stash drop stash@{1}
+    [ $status -eq 0 ]
+    [[ "$output" =~ "Dropped stash@{1}" ]] || false
+
+    run dolt stash list
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "${lines[0]}" =~ "added row 4" ]] || false
+
+    run dolt stash drop 3
+    [ $status -eq 1 ]
+    [[ "$output" =~ "stash@{3} does not exist" ]] || false
+
+    dolt stash pop 0
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "5,5" ]] || false
+    [[ ! "$output" =~ "3,3" ]] || false
+}
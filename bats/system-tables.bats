@@ -125,6 +125,120 @@ teardown() {
     [[ "$output" =~ "create-table-branch" ]] || false
 }
 
+@test "system-tables: create, move, rename and delete branches with dolt_branches" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+    head=$(dolt sql -q "select hashof('master')" -r csv | tail -n 1)
+    parent=$(dolt sql -q "select hashof('master~1')" -r csv | tail -n 1)
+
+    run dolt sql -q "insert into dolt_branches (name, hash) values ('feature', '$parent')"
+    [ $status -eq 0 ]
+    run dolt sql -q "select hash from dolt_branches where name = 'feature'" -r csv
+    [ "${lines[1]}" = "$parent" ]
+
+    run dolt sql -q "update dolt_branches set hash = '$head' where name = 'feature'"
+    [ $status -eq 0 ]
+    run dolt sql -q "select hash from dolt_branches where name = 'feature'" -r csv
+    [ "${lines[1]}" = "$head" ]
+
+    run dolt sql -q "update dolt_branches set name = 'renamed' where name = 'feature'"
+    [ $status -eq 0 ]
+    run dolt branch
+    [ $status -eq 0 ]
+    [[ "$output" =~ "renamed" ]] || false
+    [[ ! "$output" =~ "feature" ]] || false
+
+    run dolt sql -q "update dolt_branches set name = 'master' where name = 'renamed'"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    run dolt sql -q "delete from dolt_branches where name = 'renamed'"
+    [ $status -eq 0 ]
+    run dolt branch
+    [[ ! "$output" =~ "renamed" ]] || false
+}
+
+@test "system-tables: inserting an existing branch into dolt_branches fails" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+    head=$(dolt sql -q "select hashof('master')" -r csv | tail -n 1)
+    parent=$(dolt sql -q "select hashof('master~1')" -r csv | tail -n 1)
+
+    run dolt sql -q "insert into dolt_branches (name, hash) values ('master', '$parent')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+    run dolt sql -q "select hash from dolt_branches where name = 'master'" -r csv
+    [ "${lines[1]}" = "$head" ]
+
+    run dolt sql -q "insert into dolt_branches (name, hash) values ('feature', '$parent'), ('feature', '$head')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+    run dolt branch
+    [[ ! "$output" =~ "feature" ]] || false
+
+    run dolt sql -q "replace into dolt_branches (name, hash) values ('master', '$parent')"
+    [ $status -eq 0 ]
+    run dolt sql -q "select hash from dolt_branches where name = 'master'" -r csv
+    [ "${lines[1]}" = "$parent" ]
+}
+
+@test "system-tables: dolt_branches renames the checked out branch" {
+    dolt checkout -b feature
+    run dolt sql -q "update dolt_branches set name = 'renamed' where name = 'feature'"
+    [ $status -eq 0 ]
+    run dolt branch
+    [ $status -eq 0 ]
+    [[ "$output" =~ "* renamed" ]] || false
+    [[ ! "$output" =~ "feature" ]] || false
+}
+
+@test "system-tables: renaming a branch in dolt_branches keeps its upstream" {
+    mkdir ../remote-$$
+    dolt remote add origin file://../remote-$$
+    dolt checkout -b feature
+    dolt push --set-upstream origin feature
+    dolt checkout master
+
+    dolt sql -q "update dolt_branches set name = 'renamed' where name = 'feature'"
+    dolt checkout renamed
+    run dolt push
+    [ $status -ne 0 ]
+    [[ "$output" =~ "upstream branch of your current branch does not match" ]] || false
+    [[ ! "$output" =~ "no upstream branch" ]] || false
+    rm -rf ../remote-$$
+}
+
+@test "system-tables: deleting from dolt_branches checks the branch can be deleted" {
+    dolt checkout -b unmerged
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt checkout master
+    dolt branch merged
+
+    run dolt sql -q "delete from dolt_branches where name = 'unmerged'"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "not fully merged" ]] || false
+
+    run dolt sql -q "delete from dolt_branches where name = 'master'"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "checked out branch" ]] || false
+
+    run dolt sql -q "delete from dolt_branches where name in ('merged', 'unmerged')"
+    [ $status -ne 0 ]
+    run dolt branch
+    [[ "$output" =~ "  merged" ]] || false
+    [[ "$output" =~ "unmerged" ]] || false
+
+    run dolt sql -q "delete from dolt_branches where name = 'merged'"
+    [ $status -eq 0 ]
+    run dolt branch
+    [[ ! "$output" =~ "  merged" ]] || false
+    [[ "$output" =~ "unmerged" ]] || false
+}
+
 @test "system-tables: query dolt_diff_ system table" {
     dolt sql -q "CREATE TABLE test (pk INT, c1 INT, PRIMARY KEY(pk))"
     dolt add test
@@ -218,6 +332,52 @@ teardown() {
     [ ! "$status" -eq 0 ]
 }
 
+@test "system-tables: dolt_diff diffs a table between any two revisions" {
+    dolt sql -q "CREATE TABLE test (pk INT, c1 INT, PRIMARY KEY(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt sql -q "INSERT INTO test (pk, c1) VALUES (1,1),(2,2)"
+    dolt add test
+    dolt commit -m "add rows 1-2"
+    dolt tag v1
+    dolt sql -q "ALTER TABLE test ADD COLUMN c2 INT"
+    dolt sql -q "INSERT INTO test (pk, c1, c2) VALUES (3,3,3)"
+    dolt add test
+    dolt commit -m "add column c2 and row 3"
+    dolt sql -q "UPDATE test SET c1=10 WHERE pk=1"
+    dolt add test
+    dolt sql -q "DELETE FROM test WHERE pk=2"
+
+    EXPECTED=$(echo -e "to_pk,to_c1,to_c2,diff_type\n1,1,,added\n2,2,,added\n3,3,3,added")
+    run dolt sql -r csv -q "SELECT JSON_EXTRACT(to_row, '$.pk') AS to_pk, JSON_EXTRACT(to_row, '$.c1') AS to_c1, JSON_EXTRACT(to_row, '$.c2') AS to_c2, diff_type FROM dolt_diff WHERE table_name='test' AND from_commit='HEAD~2' AND to_commit='HEAD'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$EXPECTED" ]] || false
+
+    run dolt sql -r csv -q "SELECT to_row, from_row, to_commit, from_commit, diff_type FROM dolt_diff WHERE table_name='test' AND from_commit='v1' AND to_commit='master'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"{""c1"":3,""c2"":3,""pk"":3}",,master,v1,added' ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+
+    # rows are rendered in the schema of their own revision, which has no c2 at v1
+    run dolt sql -r csv -q "SELECT to_row, from_row, diff_type FROM dolt_diff WHERE table_name='test' AND from_commit='v1' AND to_commit='STAGED' AND JSON_EXTRACT(from_row, '$.pk') = 1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"{""c1"":10,""c2"":null,""pk"":1}","{""c1"":1,""pk"":1}",modified' ]] || false
+
+    EXPECTED=$(echo -e "from_pk,from_c1,diff_type\n2,2,removed")
+    run dolt sql -r csv -q "SELECT JSON_EXTRACT(from_row, '$.pk') AS from_pk, JSON_EXTRACT(from_row, '$.c1') AS from_c1, diff_type FROM dolt_diff WHERE table_name='test' AND from_commit='STAGED' AND to_commit='WORKING'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$EXPECTED" ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt sql -q "SELECT * FROM dolt_diff WHERE from_commit='HEAD' AND to_commit='WORKING'"
+    [ ! "$status" -eq 0 ]
+    [[ "$output" =~ "single 'table_name'" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_diff WHERE table_name='test' AND to_commit='WORKING'"
+    [ ! "$status" -eq 0 ]
+    [[ "$output" =~ "single 'from_commit'" ]] || false
+}
+
 @test "system-tables: query dolt_diff_ system table without committing table" {
     dolt sql -q "create table test (pk int not null primary key);"
     dolt sql -q "insert into test values (0), (1);"
@@ -347,3 +507,119 @@ teardown() {
     [[ "$output" =~ "0,commit B" ]] || false
     [[ "$output" =~ "1,commit C" ]] || false
 }
+
+@test "system-tables: query and modify dolt_tags system table" {
+    dolt sql -q "create table test (pk int, c1 int, primary key(pk))"
+    dolt add test
+    dolt commit -m "Added test table"
+    dolt tag v1 HEAD~1 -m "first tag"
+
+    run dolt sql -q "select tag_name, message from dolt_tags" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1,first tag" ]] || false
+
+    run dolt sql -q "insert into dolt_tags (tag_name, tag_hash, message) values ('v2', 'HEAD', 'second tag')"
+    [ $status -eq 0 ]
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v2" ]] || false
+    [[ "$output" =~ "second tag" ]] || false
+    head=$(dolt sql -q "select hashof('HEAD')" -r csv | tail -n 1)
+    run dolt sql -q "select tag_hash from dolt_tags where tag_name = 'v2'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "$head" ]
+
+    run dolt sql -q "insert into dolt_tags (tag_name, tag_hash) values ('v2', 'master')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+
+    run dolt sql -q "insert into dolt_tags (tag_name, tag_hash) values ('v3', 'not-a-branch')"
+    [ $status -ne 0 ]
+
+    run dolt sql -q "delete from dolt_tags where tag_name = 'v1'"
+    [ $status -eq 0 ]
+    run dolt tag
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "v2" ]] || false
+}
+
+@test "system-tables: query and modify dolt_remotes system table" {
+    dolt remote add origin http://localhost:50051/test-org/test-repo
+
+    run dolt sql -q "select name, url, fetch_specs from dolt_remotes" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "origin,http://localhost:50051/test-org/test-repo" ]] || false
+    [[ "$output" =~ "refs/heads/*:refs/remotes/origin/*" ]] || false
+
+    run dolt sql -q "insert into dolt_remotes (name, url) values ('other', 'file:///tmp/other')"
+    [ $status -eq 0 ]
+    run dolt remote -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "other file:///tmp/other" ]] || false
+
+    run dolt sql -q "insert into dolt_remotes (name, url) values ('other', 'file:///tmp/another')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+
+    run dolt sql -q "insert into dolt_remotes (name, url) values ('bad.name', 'file:///tmp/other')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "invalid remote name" ]] || false
+
+    run dolt sql -q "delete from dolt_remotes where name = 'origin'"
+    [ $status -eq 0 ]
+    run dolt remote
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "origin" ]] || false
+    [[ "$output" =~ "other" ]] || false
+}
+
+@test "system-tables: query dolt_diff_summary" {
+    dolt sql -q "create table test (pk int primary key, c1 int)"
+    dolt sql -q "insert into test values (1,1),(2,2),(3,3)"
+    dolt add test
+    dolt commit -m "added test"
+    dolt sql -q "update test set c1 = 10 where pk = 1"
+    dolt sql -q "delete from test where pk = 2"
+    dolt sql -q "insert into test values (4,4)"
+
+    run dolt sql -q "select table_name, rows_unmodified, rows_added, rows_deleted, rows_modified, old_row_count, new_row_count from dolt_diff_summary where from_commit = 'HEAD' and to_commit = 'WORKING'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "test,1,1,1,1,3,3" ]] || false
+
+    run dolt sql -q "select table_name, rows_added from dolt_diff_summary where from_commit = 'HEAD~1' and to_commit = 'HEAD'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "test,3" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_diff_summary where from_commit = 'HEAD' and to_commit = 'STAGED'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt sql -q "select * from dolt_diff_summary where to_commit = 'WORKING'"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "from_commit" ]] || false
+}
+
+@test "system-tables: query dolt_schema_diff" {
+    dolt sql -q "create table test (pk int primary key, c1 int, index c1_idx (c1))"
+    dolt add test
+    dolt commit -m "added test"
+    dolt sql -q "alter table test add column c2 varchar(20)"
+    dolt sql -q "alter table test drop index c1_idx"
+    dolt sql -q "create table other (pk int primary key)"
+
+    run dolt sql -q "select table_name, object_type, object_name, diff_type from dolt_schema_diff where from_commit = 'HEAD' and to_commit = 'WORKING'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "other,table,other,added" ]] || false
+    [[ "$output" =~ "other,column,pk,added" ]] || false
+    [[ "$output" =~ "test,column,c2,added" ]] || false
+    [[ "$output" =~ "test,index,c1_idx,removed" ]] || false
+
+    run dolt sql -q "select to_definition from dolt_schema_diff where from_commit = 'HEAD' and to_commit = 'WORKING' and object_name = 'c2'" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ '`c2` VARCHAR(20)' ]] || false
+
+    run dolt sql -q "select count(*) from dolt_schema_diff where from_commit = 'HEAD~1' and to_commit = 'HEAD' and table_name = 'test'" -r csv
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "4" ]
+}
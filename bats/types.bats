@@ -618,6 +618,103 @@ SQL
     [[ "$output" =~ "\`v\` int unsigned" ]] || false
 }
 
+@test "types: JSON" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v JSON,
+  PRIMARY KEY (pk)
+);
+SQL
+    run dolt schema show
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "\`v\` json" ]] || false
+    dolt sql -q "INSERT INTO test VALUES (1, '{\"a\": [1, 2.5], \"b\": \"c\"}');"
+    run dolt sql -q "SELECT * FROM test"
+    [ "$status" -eq "0" ]
+    [[ "${lines[3]}" =~ ' {"a":[1,2.5],"b":"c"} ' ]] || false
+    run dolt sql -q "SELECT JSON_EXTRACT(v, '$.a[1]') FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "2.5" ]] || false
+    dolt sql -q "UPDATE test SET v='[true, null]' WHERE pk=1;"
+    run dolt sql -q "SELECT * FROM test" -r json
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ '"v":[true,null]' ]] || false
+    echo '{"rows": [{"pk": 2, "v": {"x": [1]}}]}' > test.json
+    dolt table import -u test test.json
+    echo -e 'pk,v\n3,"{""y"": 2}"\n' > test.csv
+    dolt table import -u test test.csv
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[2]}" = '2,"{""x"":[1]}"' ]] || false
+    [[ "${lines[3]}" = '3,"{""y"":2}"' ]] || false
+    echo -e 'pk,v\n4,"{not json"\n' > bad.csv
+    run dolt table import -u test bad.csv
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "invalid JSON text" ]] || false
+    run dolt sql -q "INSERT INTO test VALUES (5, 'not json');"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "invalid JSON text" ]] || false
+}
+
+@test "types: JSON keeps number text and doesn't escape HTML" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v JSON,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, '{"a": [1.0, 1.50, 2], "b": "<a&b>"}');
+SQL
+    run dolt sql -q "SELECT v FROM test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = '"{""a"":[1.0,1.50,2],""b"":""<a&b>""}"' ]] || false
+    run dolt sql -q "SELECT * FROM test" -r json
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ '"v":{"a":[1.0,1.50,2],"b":"<a&b>"}' ]] || false
+}
+
+@test "types: JSON columns need a client with JSON support" {
+    dolt sql -q "CREATE TABLE test (pk BIGINT NOT NULL, v JSON, PRIMARY KEY (pk));"
+    dolt sql -q "INSERT INTO test VALUES (1, '[1]');"
+    dolt commit -am "added a JSON column"
+    run dolt --feature-version 0 sql -q "SELECT * FROM test"
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "out of date" ]] || false
+}
+
+@test "types: JSON merges and conflicts" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  v JSON,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1, '{"a": 1}'), (2, '{"b": 2}');
+SQL
+    dolt commit -am "created table"
+    dolt checkout -b other
+    dolt sql -q "UPDATE test SET v='{\"a\": 10}' WHERE pk=1;"
+    dolt sql -q "UPDATE test SET v='{\"b\": 20}' WHERE pk=2;"
+    dolt commit -am "other changes"
+    dolt checkout master
+    # the same document formatted differently is not a change
+    dolt sql -q "UPDATE test SET v='{ \"b\" :  2 }' WHERE pk=2;"
+    run dolt status
+    [[ "$output" =~ "nothing to commit" ]] || false
+    dolt sql -q "UPDATE test SET v='{\"a\": 11}' WHERE pk=1;"
+    dolt commit -am "master changes"
+
+    run dolt merge other
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "CONFLICT" ]] || false
+    run dolt sql -q "SELECT base_v, our_v, their_v FROM dolt_conflicts_test" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = '"{""a"":1}","{""a"":11}","{""a"":10}"' ]] || false
+    run dolt sql -q "SELECT v FROM test WHERE pk=2" -r csv
+    [[ "${lines[1]}" = '"{""b"":20}"' ]] || false
+}
+
 @test "types: LONG" {
     dolt sql <<SQL
 CREATE TABLE test (
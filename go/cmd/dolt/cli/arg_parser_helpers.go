@@ -84,6 +84,7 @@ const (
 	NoFFParam        = "no-ff"
 	SquashParam      = "squash"
 	AbortParam       = "abort"
+	ContinueParam    = "continue"
 )
 
 var mergeAbortDetails = `Abort the current conflict resolution process, and try to reconstruct the pre-merge state.
@@ -131,3 +132,9 @@ func CreateCheckoutArgParser() *argparser.ArgParser {
 	ap.SupportsString(CheckoutCoBranch, "", "branch", "Create a new branch named {{.LessThan}}new_branch{{.GreaterThan}} and start it at {{.LessThan}}start_point{{.GreaterThan}}.")
 	return ap
 }
+
+func CreateCherryPickArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit whose changes should be applied to the current branch."})
+	return ap
+}
@@ -0,0 +1,321 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var backupDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Manage a set of server backups",
+	LongDesc: `With no arguments, shows a list of existing backups. Several subcommands are available to perform operations on backups.
+
+A backup is a copy of the entire repository, including every branch, tag and workspace, the remote-tracking branches, and the working set. Unlike {{.EmphasisLeft}}dolt push{{.EmphasisRight}}, a backup copies the table files of the database as they are, and backups are configured separately from remotes, so they don't affect the remotes of the repository.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Adds a backup named {{.LessThan}}name{{.GreaterThan}} for the database at {{.LessThan}}url{{.GreaterThan}}. The {{.LessThan}}url{{.GreaterThan}} parameter supports the same url schemes and parameters as {{.EmphasisLeft}}dolt remote add{{.EmphasisRight}}, such as file, aws and gs. The command {{.EmphasisLeft}}dolt backup sync {{.LessThan}}name{{.GreaterThan}}{{.EmphasisRight}} can then be used to copy the repository to the backup.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
+Remove the backup named {{.LessThan}}name{{.GreaterThan}}. The data of the backup is not deleted.
+
+{{.EmphasisLeft}}sync{{.EmphasisRight}}
+Copy the repository to the backup named {{.LessThan}}name{{.GreaterThan}}. Table files which were copied by an earlier sync are not copied again.
+
+{{.EmphasisLeft}}restore{{.EmphasisRight}}
+Restore the backup at {{.LessThan}}url{{.GreaterThan}} to a new repository in the directory {{.LessThan}}name{{.GreaterThan}}. The restored repository has the same branches, tags, remotes and working set as the repository the backup was made from. This command does not need to be run from within a repository.`,
+
+	Synopsis: []string{
+		"[-v | --verbose]",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"remove {{.LessThan}}name{{.GreaterThan}}",
+		"sync {{.LessThan}}name{{.GreaterThan}}",
+		"restore [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}name{{.GreaterThan}}",
+	},
+}
+
+const (
+	addBackupId         = "add"
+	removeBackupId      = "remove"
+	removeBackupShortId = "rm"
+	syncBackupId        = "sync"
+	restoreBackupId     = "restore"
+)
+
+type BackupCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BackupCmd) Name() string {
+	return "backup"
+}
+
+// Description returns a description of the command
+func (cmd BackupCmd) Description() string {
+	return "Manage a set of server backups."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory. Every subcommand other than restore checks for a
+// repository itself.
+func (cmd BackupCmd) RequiresRepo() bool {
+	return false
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd BackupCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, backupDocs, ap))
+}
+
+func (cmd BackupCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"region", "cloud provider region associated with this backup."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"creds-type", "credential type.  Valid options are role, env, and file.  See the help section for additional details."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"profile", "AWS profile to use."})
+	ap.SupportsFlag(verboseFlag, "v", "When printing the list of backups adds additional details.")
+	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
+	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
+	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
+	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	return ap
+}
+
+// Exec executes the command
+func (cmd BackupCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, backupDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 || apr.Arg(0) != restoreBackupId {
+		if !cli.CheckEnvIsValid(dEnv) {
+			return 2
+		}
+	}
+
+	var verr errhand.VerboseError
+
+	switch {
+	case apr.NArg() == 0:
+		verr = printBackups(dEnv, apr)
+	case apr.Arg(0) == addBackupId:
+		verr = addBackup(dEnv, apr)
+	case apr.Arg(0) == removeBackupId:
+		verr = removeBackup(dEnv, apr)
+	case apr.Arg(0) == removeBackupShortId:
+		verr = removeBackup(dEnv, apr)
+	case apr.Arg(0) == syncBackupId:
+		verr = syncBackup(ctx, dEnv, apr)
+	case apr.Arg(0) == restoreBackupId:
+		verr = restoreBackup(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func printBackups(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	backups, err := dEnv.GetBackups()
+
+	if err != nil {
+		return errhand.BuildDError("Unable to get backups from the local directory").AddCause(err).Build()
+	}
+
+	for _, b := range backups {
+		if apr.Contains(verboseFlag) {
+			paramStr := make([]byte, 0)
+			if len(b.Params) > 0 {
+				paramStr, _ = json.Marshal(b.Params)
+			}
+
+			cli.Printf("%s %s %s\n", b.Name, b.Url, paramStr)
+		} else {
+			cli.Println(b.Name)
+		}
+	}
+
+	return nil
+}
+
+func addBackup(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	backupName := strings.TrimSpace(apr.Arg(1))
+
+	if !env.IsValidRemoteName(backupName) {
+		return errhand.BuildDError("invalid backup name: " + backupName).Build()
+	}
+
+	if _, ok := dEnv.RepoState.Backups[backupName]; ok {
+		return errhand.BuildDError("error: A backup named '%s' already exists.", backupName).AddDetails("remove it before running this command again").Build()
+	}
+
+	backupUrl := apr.Arg(2)
+	scheme, absBackupUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, backupUrl)
+
+	if err != nil {
+		return errhand.BuildDError("error: '%s' is not valid.", backupUrl).AddCause(err).Build()
+	}
+
+	params, verr := parseRemoteArgs(apr, scheme, absBackupUrl)
+
+	if verr != nil {
+		return verr
+	}
+
+	dEnv.RepoState.AddBackup(env.Remote{Name: backupName, Url: absBackupUrl, Params: params})
+	err = dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: Unable to save changes.").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func removeBackup(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	old := strings.TrimSpace(apr.Arg(1))
+
+	if _, ok := dEnv.RepoState.Backups[old]; !ok {
+		return errhand.BuildDError("error: unknown backup " + old).Build()
+	}
+
+	dEnv.RepoState.RemoveBackup(old)
+	err := dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: unable to save changes.").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func syncBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	backupName := strings.TrimSpace(apr.Arg(1))
+	b, ok := dEnv.RepoState.Backups[backupName]
+
+	if !ok {
+		return errhand.BuildDError("error: unknown backup " + backupName).Build()
+	}
+
+	backupDB, err := b.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get backup db").AddCause(err).Build()
+	}
+
+	err = copyTableFilesWithProgress(func(eventCh chan<- datas.TableFileEvent) error {
+		return actions.SyncBackup(ctx, dEnv, backupDB, eventCh)
+	})
+
+	if err != nil {
+		return errhand.BuildDError("error: backup sync failed").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func restoreBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	urlStr := apr.Arg(1)
+	dir := apr.Arg(2)
+	scheme, backupUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
+
+	if err != nil {
+		return errhand.BuildDError("error: '%s' is not valid.", urlStr).AddCause(err).Build()
+	}
+
+	params, verr := parseRemoteArgs(apr, scheme, backupUrl)
+
+	if verr != nil {
+		return verr
+	}
+
+	b := env.Remote{Name: dir, Url: backupUrl, Params: params}
+	backupDB, err := b.GetRemoteDB(ctx, types.Format_Default)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get backup db").AddCause(err).Build()
+	}
+
+	restoreEnv, verr := envForClone(ctx, backupDB.ValueReadWriter().Format(), env.NoRemote, dir, dEnv.FS, dEnv.Version)
+
+	if verr != nil {
+		return verr
+	}
+
+	err = copyTableFilesWithProgress(func(eventCh chan<- datas.TableFileEvent) error {
+		return actions.RestoreBackup(ctx, backupDB, restoreEnv, eventCh)
+	})
+
+	if err != nil {
+		// Make best effort to delete the directory we created.
+		_ = os.Chdir("../")
+		_ = dEnv.FS.Delete(dir, true)
+
+		if err == datas.ErrNoData || err == actions.ErrNotABackup {
+			return errhand.BuildDError("error: '%s' does not contain a dolt backup", urlStr).Build()
+		}
+
+		return errhand.BuildDError("error: backup restore failed").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// copyTableFilesWithProgress runs |copyFn| while printing the progress of the table files it copies.
+func copyTableFilesWithProgress(copyFn func(eventCh chan<- datas.TableFileEvent) error) error {
+	eventCh := make(chan datas.TableFileEvent, 128)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cloneProg(eventCh)
+	}()
+
+	err := copyFn(eventCh)
+	close(eventCh)
+
+	wg.Wait()
+
+	return err
+}
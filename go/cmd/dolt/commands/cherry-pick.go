@@ -0,0 +1,195 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var cherryPickDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Apply the changes introduced by an existing commit",
+	LongDesc: `Applies the changes introduced by {{.LessThan}}commit{{.GreaterThan}}, relative to its parent, to the current branch and records a new commit with the same commit message.
+
+The changes are applied using a three-way merge in which the parent of {{.LessThan}}commit{{.GreaterThan}} is used as the common ancestor. If the same rows were changed on the current branch, the conflicting rows are recorded in the {{.EmphasisLeft}}dolt_conflicts{{.EmphasisRight}} tables and no commit is made. Once the conflicts are resolved, add the affected tables using {{.EmphasisLeft}}dolt add{{.EmphasisRight}} and commit the result.
+
+Cherry-picking requires a clean working set. Merge commits and the initial commit of a repository cannot be cherry-picked.
+`,
+	Synopsis: []string{
+		"{{.LessThan}}commit{{.GreaterThan}}",
+	},
+}
+
+type CherryPickCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CherryPickCmd) Name() string {
+	return "cherry-pick"
+}
+
+// Description returns a description of the command
+func (cmd CherryPickCmd) Description() string {
+	return "Apply the changes introduced by an existing commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd CherryPickCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cli.CreateCherryPickArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+}
+
+// Exec executes the command
+func (cmd CherryPickCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cli.CreateCherryPickArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, cherryPickDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	if verr := checkCanApplyCommit(ctx, dEnv, "cherry-pick"); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, apr.Arg(0))
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	headRoot, err := dEnv.HeadRoot(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("Unable to get head.").AddCause(err).Build(), usage)
+	}
+
+	mergedRoot, tblToStats, err := merge.CherryPick(ctx, dEnv.DoltDB, headRoot, cm)
+	if err != nil {
+		return HandleVErrAndExitCode(cherryPickErrToVErr(apr.Arg(0), err), usage)
+	}
+
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read commit metadata").AddCause(err).Build(), usage)
+	}
+
+	return commitAppliedRoot(ctx, dEnv, mergedRoot, tblToStats, meta.Name, meta.Email, meta.Description, usage)
+}
+
+func cherryPickErrToVErr(commitStr string, err error) errhand.VerboseError {
+	switch err {
+	case merge.ErrCommitHasNoParent:
+		return errhand.BuildDError("error: cannot cherry-pick '%s' as it has no parent", commitStr).Build()
+	case merge.ErrCommitIsMerge:
+		return errhand.BuildDError("error: cannot cherry-pick '%s' as it is a merge commit", commitStr).Build()
+	default:
+		return errhand.BuildDError("error: failed to cherry-pick '%s'", commitStr).AddCause(err).Build()
+	}
+}
+
+// checkCanApplyCommit verifies that there are no unresolved conflicts, no active merge or rebase, and no uncommitted changes
+// which would be overwritten by applying the changes of another commit on top of HEAD.
+func checkCanApplyCommit(ctx context.Context, dEnv *env.DoltEnv, operation string) errhand.VerboseError {
+	working, staged, head, err := env.GetRoots(ctx, dEnv.DoltDB, dEnv.RepoStateReader())
+	if err != nil {
+		return errhand.BuildDError("fatal: Unable to read from data repository.").AddCause(err).Build()
+	}
+
+	if has, err := working.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		return errhand.BuildDError("error: %s is not possible because you have unmerged tables.", operation).
+			AddDetails("hint: Fix them up in the work tree, and then use 'dolt add <table>'").
+			AddDetails("hint: as appropriate to mark resolution and make a commit.").Build()
+	}
+
+	if dEnv.IsMergeActive() {
+		return errhand.BuildDError("error: %s is not possible because you have not committed an active merge.", operation).
+			AddDetails("hint: add affected tables using 'dolt add <table>' and commit using 'dolt commit -m <msg>'").Build()
+	}
+
+	if dEnv.IsRebaseActive() {
+		return errhand.BuildDError("error: %s is not possible because a rebase is in progress.", operation).
+			AddDetails("hint: use 'dolt rebase --continue' or 'dolt rebase --abort' to finish the rebase").Build()
+	}
+
+	headHash, err := head.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of head").AddCause(err).Build()
+	}
+
+	for _, root := range []*doltdb.RootValue{working, staged} {
+		h, err := root.HashOf()
+		if err != nil {
+			return errhand.BuildDError("error: failed to get hash of root").AddCause(err).Build()
+		}
+
+		if h != headHash {
+			return errhand.BuildDError("error: your local changes would be overwritten by %s.", operation).
+				AddDetails("hint: commit your changes before you %s.", operation).Build()
+		}
+	}
+
+	return nil
+}
+
+// commitAppliedRoot writes |appliedRoot| to the working set. If applying the changes resulted in conflicts they are
+// left in the working set to be resolved, otherwise the root is staged and committed with the message given.
+func commitAppliedRoot(ctx context.Context, dEnv *env.DoltEnv, appliedRoot *doltdb.RootValue, tblToStats map[string]*merge.MergeStats, name, email, msg string, usage cli.UsagePrinter) int {
+	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv.DbData())
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to determine unstaged docs").AddCause(err).Build(), usage)
+	}
+
+	if verr := UpdateWorkingWithVErr(dEnv, appliedRoot); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	if hasConflicts := printSuccessStats(tblToStats); hasConflicts {
+		cli.Println("Automatic merge failed; fix conflicts and then commit the result.")
+		return 0
+	}
+
+	err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build(), usage)
+	}
+
+	if verr := UpdateStagedWithVErr(dEnv.DoltDB, dEnv.RepoStateWriter(), appliedRoot); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	_, err = actions.CommitStaged(ctx, dEnv.DbData(), actions.CommitStagedProps{
+		Message:          msg,
+		Date:             doltdb.CommitNowFunc(),
+		AllowEmpty:       false,
+		CheckForeignKeys: true,
+		Name:             name,
+		Email:            email,
+	})
+
+	if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	return LogCmd{}.Exec(ctx, "log", []string{"-n=1"}, dEnv)
+}
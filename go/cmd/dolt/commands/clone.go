@@ -44,6 +44,7 @@ import (
 const (
 	remoteParam = "remote"
 	branchParam = "branch"
+	depthParam  = "depth"
 )
 
 var cloneDocs = cli.CommandDocumentationContent{
@@ -53,9 +54,11 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+With {{.EmphasisLeft}}--depth{{.EmphasisRight}}, a shallow clone is created. Only the branch given by {{.EmphasisLeft}}--branch{{.EmphasisRight}}, or master if no branch is given, is cloned, and its history is truncated to the given number of commits. The history of a shallow clone stops at its oldest commits, and commands which need commits beyond them, such as a merge whose common ancestor wasn't cloned, fail.
 `,
 	Synopsis: []string{
-		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}] [--depth {{.LessThan}}depth{{.GreaterThan}}] [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
@@ -87,6 +90,7 @@ func (cmd CloneCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsString(remoteParam, "", "name", "Name of the remote to be added. Default will be 'origin'.")
 	ap.SupportsString(branchParam, "b", "branch", "The branch to be cloned.  If not specified all branches will be cloned.")
+	ap.SupportsInt(depthParam, "", "depth", "Create a shallow clone of a single branch, with its history truncated to the specified number of commits.")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
@@ -107,8 +111,13 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	remoteName := apr.GetValueOrDefault(remoteParam, "origin")
 	branch := apr.GetValueOrDefault(branchParam, "")
+	depth := apr.GetIntOrDefault(depthParam, 0)
 	dir, urlStr, verr := parseArgs(apr)
 
+	if verr == nil && apr.Contains(depthParam) && depth < 1 {
+		verr = errhand.BuildDError("error: depth must be a positive number of commits").Build()
+	}
+
 	scheme, remoteUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
 
 	if err != nil {
@@ -128,7 +137,7 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 				dEnv, verr = envForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version)
 
 				if verr == nil {
-					verr = cloneRemote(ctx, srcDB, remoteName, branch, dEnv)
+					verr = cloneRemote(ctx, srcDB, remoteName, branch, depth, dEnv)
 
 					if verr == nil {
 						evt := events.GetEventFromContext(ctx)
@@ -275,20 +284,25 @@ func cloneProg(eventCh <-chan datas.TableFileEvent) {
 	cli.Println()
 }
 
-func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, dEnv *env.DoltEnv) errhand.VerboseError {
-	eventCh := make(chan datas.TableFileEvent, 128)
+func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, depth int, dEnv *env.DoltEnv) errhand.VerboseError {
+	var err error
+	if depth > 0 {
+		err = shallowCloneBranch(ctx, srcDB, branch, depth, dEnv)
+	} else {
+		eventCh := make(chan datas.TableFileEvent, 128)
 
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		cloneProg(eventCh)
-	}()
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cloneProg(eventCh)
+		}()
 
-	err := actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
-	close(eventCh)
+		err = actions.Clone(ctx, srcDB, dEnv.DoltDB, eventCh)
+		close(eventCh)
 
-	wg.Wait()
+		wg.Wait()
+	}
 
 	if err != nil {
 		if err == datas.ErrNoData {
@@ -387,6 +401,39 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	return nil
 }
 
+// shallowCloneBranch pulls |branch|, or master if no branch is given, from |srcDB| with at most |depth| commits of its
+// history.
+func shallowCloneBranch(ctx context.Context, srcDB *doltdb.DoltDB, branch string, depth int, dEnv *env.DoltEnv) error {
+	if branch == "" {
+		branches, err := srcDB.GetBranches(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		for _, brnch := range branches {
+			branch = brnch.GetPath()
+			if branch == doltdb.MasterBranch {
+				break
+			}
+		}
+
+		if branch == "" {
+			return datas.ErrNoData
+		}
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err := actions.ShallowClone(ctx, dEnv, srcDB, dEnv.DoltDB, ref.NewBranchRef(branch), depth, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err == doltdb.ErrBranchNotFound {
+		return fmt.Errorf("branch '%s' not found in the remote", branch)
+	}
+
+	return err
+}
+
 // Inits an empty, newly cloned repo. This would be unnecessary if we properly initialized the storage for a repository
 // when we created it on dolthub. If we do that, this code can be removed.
 func initEmptyClonedRepo(ctx context.Context, dEnv *env.DoltEnv) error {
@@ -155,6 +155,18 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 				return errhand.BuildDError("error: unable to read database").AddCause(err).Build()
 			}
 
+			if has, err := tbl.HasSchemaConflicts(); err != nil {
+				return errhand.BuildDError("error: unable to read database").AddCause(err).Build()
+			} else if has {
+				sc, err := merge.GetSchemaConflicts(ctx, tblName, tbl)
+
+				if err != nil {
+					return errhand.BuildDError("failed to read schema conflicts").AddCause(err).Build()
+				}
+
+				cli.Print(sc.String())
+			}
+
 			cnfRd, err := merge.NewConflictReader(ctx, tbl)
 
 			if err == doltdb.ErrNoConflicts {
@@ -41,16 +41,20 @@ When a merge operation finds conflicting changes, the rows with the conflicts ar
 In its first form {{.EmphasisLeft}}dolt conflicts resolve <table> <key>...{{.EmphasisRight}}, resolve runs in manual merge mode resolving the conflicts whose keys are provided.
 
 In its second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <table>...{{.EmphasisRight}}, resolve runs in auto resolve mode. Where conflicts are resolved using a rule to determine which version of a row should be used.
+
+In its third form {{.EmphasisLeft}}dolt conflicts resolve --schema --ours|--theirs <table> [<column_or_index>...]{{.EmphasisRight}}, resolve resolves the schema conflicts of a table by taking our or their definition of the conflicting columns and indexes. If no columns or indexes are given, all the schema conflicts of the table are resolved. The data of a table with schema conflicts is merged once its last schema conflict is resolved, which may result in new data conflicts.
 `,
 	Synopsis: []string{
 		`{{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}key_definition{{.GreaterThan}}] {{.LessThan}}key{{.GreaterThan}}...`,
 		`--ours|--theirs {{.LessThan}}table{{.GreaterThan}}...`,
+		`--schema --ours|--theirs {{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}column_or_index{{.GreaterThan}}...]`,
 	},
 }
 
 const (
 	oursFlag   = "ours"
 	theirsFlag = "theirs"
+	schemaFlag = "schema"
 )
 
 var autoResolvers = map[string]merge.AutoResolver{
@@ -96,6 +100,7 @@ func (cmd ResolveCmd) createArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"key", "key(s) of rows within a table whose conflicts have been resolved"})
 	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag("theirs", "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsFlag(schemaFlag, "", "Resolve the schema conflicts of the columns and indexes given, or of the whole table if none are given")
 
 	return ap
 }
@@ -107,7 +112,9 @@ func (cmd ResolveCmd) Exec(ctx context.Context, commandStr string, args []string
 	apr := cli.ParseArgs(ap, args, help)
 
 	var verr errhand.VerboseError
-	if apr.ContainsAny(autoResolverParams...) {
+	if apr.Contains(schemaFlag) {
+		verr = schemaResolve(ctx, apr, dEnv)
+	} else if apr.ContainsAny(autoResolverParams...) {
 		verr = autoResolve(ctx, apr, dEnv)
 	} else {
 		verr = manualResolve(ctx, apr, dEnv)
@@ -149,6 +156,33 @@ func autoResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.
 	return saveDocsOnResolve(ctx, dEnv)
 }
 
+func schemaResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
+	funcFlags := apr.FlagsEqualTo(autoResolverParams, true)
+
+	if funcFlags.Size() != 1 {
+		ff := strings.Join(autoResolverParams, ", ")
+		return errhand.BuildDError("specify a resolver func from [ %s ]", ff).SetPrintUsage().Build()
+	} else if apr.NArg() == 0 {
+		return errhand.BuildDError("specify the table to resolve schema conflicts for").SetPrintUsage().Build()
+	}
+
+	tblName := apr.Arg(0)
+	err := actions.ResolveSchemaConflicts(ctx, dEnv, tblName, apr.Contains(oursFlag), apr.Args()[1:])
+
+	if err != nil {
+		if err == doltdb.ErrNoConflicts {
+			cli.Println("no schema conflicts to resolve.")
+			return nil
+		} else if err == doltdb.ErrTableNotFound {
+			return errhand.BuildDError("error: table '%s' not found", tblName).Build()
+		}
+
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+
+	return saveDocsOnResolve(ctx, dEnv)
+}
+
 func manualResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
 	args := apr.Args()
 
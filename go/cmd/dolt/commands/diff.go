@@ -310,9 +310,11 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 		return from, to, nil, nil
 	}
 
-	from, ok := maybeResolve(ctx, dEnv, args[0])
+	from, ok, err := maybeResolve(ctx, dEnv, args[0])
 
-	if !ok {
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
 		// `dolt diff ...tables`
 		from = stagedRoot
 		to = workingRoot
@@ -333,9 +335,11 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 		return from, to, nil, nil
 	}
 
-	to, ok = maybeResolve(ctx, dEnv, args[1])
+	to, ok, err = maybeResolve(ctx, dEnv, args[1])
 
-	if !ok {
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
 		// `dolt diff from_commit ...tables`
 		to = workingRoot
 		if isCached {
@@ -351,23 +355,27 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 }
 
 // todo: distinguish between non-existent CommitSpec and other errors, don't assume non-existent
-func maybeResolve(ctx context.Context, dEnv *env.DoltEnv, spec string) (*doltdb.RootValue, bool) {
+// maybeResolve returns the root value of the commit |spec| resolves to, or false if |spec| isn't a commit. An error is
+// returned if |spec| is a commit which was left out of a shallow clone.
+func maybeResolve(ctx context.Context, dEnv *env.DoltEnv, spec string) (*doltdb.RootValue, bool, error) {
 	cs, err := doltdb.NewCommitSpec(spec)
 	if err != nil {
-		return nil, false
+		return nil, false, nil
 	}
 
 	cm, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoState.CWBHeadRef())
-	if err != nil {
-		return nil, false
+	if err == doltdb.ErrShallowBoundary {
+		return nil, false, fmt.Errorf("%s: %w", spec, err)
+	} else if err != nil {
+		return nil, false, nil
 	}
 
 	root, err := cm.GetRootValue()
 	if err != nil {
-		return nil, false
+		return nil, false, nil
 	}
 
-	return root, true
+	return root, true, nil
 }
 
 func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dArgs *diffArgs) (verr errhand.VerboseError) {
@@ -202,26 +202,29 @@ func mergeCommitSpec(ctx context.Context, apr *argparser.ArgParseResults, dEnv *
 
 	if ok, err := cm1.CanFastForwardTo(ctx, cm2); ok {
 		if apr.Contains(cli.NoFFParam) {
-			return execNoFFMerge(ctx, apr, dEnv, cm2, verr, workingDiffs)
+			return execNoFFMerge(ctx, apr, dEnv, commitSpecStr, cm2, verr, workingDiffs)
 		} else {
 			return executeFFMerge(ctx, squash, dEnv, cm2, workingDiffs)
 		}
 	} else if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
 		cli.Println("Already up to date.")
 		return nil
+	} else if err == doltdb.ErrShallowBoundary {
+		return errhand.BuildDError("error: cannot merge %s, finding its common ancestor with HEAD needs history which isn't in this shallow clone.", commitSpecStr).
+			AddDetails("Clone the repository without --depth to merge these commits.").Build()
 	} else {
-		return executeMerge(ctx, squash, dEnv, cm1, cm2, workingDiffs)
+		return executeMerge(ctx, squash, dEnv, commitSpecStr, cm1, cm2, workingDiffs)
 	}
 }
 
-func execNoFFMerge(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, cm2 *doltdb.Commit, verr errhand.VerboseError, workingDiffs map[string]hash.Hash) errhand.VerboseError {
+func execNoFFMerge(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv, commitSpecStr string, cm2 *doltdb.Commit, verr errhand.VerboseError, workingDiffs map[string]hash.Hash) errhand.VerboseError {
 	mergedRoot, err := cm2.GetRootValue()
 
 	if err != nil {
 		return errhand.BuildDError("error: reading from database").AddCause(err).Build()
 	}
 
-	verr = mergedRootToWorking(ctx, false, dEnv, mergedRoot, workingDiffs, cm2, map[string]*merge.MergeStats{})
+	verr = mergedRootToWorking(ctx, false, dEnv, mergedRoot, workingDiffs, commitSpecStr, cm2, map[string]*merge.MergeStats{})
 
 	if verr != nil {
 		return verr
@@ -344,7 +347,7 @@ and take the hash for your current branch and use it for the value for "staged"
 	return nil
 }
 
-func executeMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm1, cm2 *doltdb.Commit, workingDiffs map[string]hash.Hash) errhand.VerboseError {
+func executeMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, commitSpecStr string, cm1, cm2 *doltdb.Commit, workingDiffs map[string]hash.Hash) errhand.VerboseError {
 	verr := fkConstraintWarning(ctx, cm1, cm2)
 
 	if verr != nil {
@@ -364,7 +367,7 @@ func executeMerge(ctx context.Context, squash bool, dEnv *env.DoltEnv, cm1, cm2
 		}
 	}
 
-	return mergedRootToWorking(ctx, squash, dEnv, mergedRoot, workingDiffs, cm2, tblToStats)
+	return mergedRootToWorking(ctx, squash, dEnv, mergedRoot, workingDiffs, commitSpecStr, cm2, tblToStats)
 }
 
 func fkConstraintWarning(ctx context.Context, cm1, cm2 *doltdb.Commit) errhand.VerboseError {
@@ -459,7 +462,7 @@ func fkConstraintWarning(ctx context.Context, cm1, cm2 *doltdb.Commit) errhand.V
 	return nil
 }
 
-func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, mergedRoot *doltdb.RootValue, workingDiffs map[string]hash.Hash, cm2 *doltdb.Commit, tblToStats map[string]*merge.MergeStats) errhand.VerboseError {
+func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, mergedRoot *doltdb.RootValue, workingDiffs map[string]hash.Hash, commitSpecStr string, cm2 *doltdb.Commit, tblToStats map[string]*merge.MergeStats) errhand.VerboseError {
 	var err error
 
 	workingRoot := mergedRoot
@@ -478,7 +481,7 @@ func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, me
 	}
 
 	if !squash {
-		err = dEnv.RepoState.StartMerge(h2.String(), dEnv.FS)
+		err = dEnv.RepoState.StartMerge(h2.String(), commitSpecStr, dEnv.FS)
 
 		if err != nil {
 			return errhand.BuildDError("Unable to update the repo state").AddCause(err).Build()
@@ -539,7 +542,12 @@ func printDeletions(tblToStats map[string]*merge.MergeStats) {
 func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	hasConflicts := false
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
+		if stats.Operation == merge.TableModified && stats.SchemaConflicts > 0 {
+			cli.Println("Auto-merging", tblName)
+			cli.Println("CONFLICT (schema): Merge conflict in", tblName)
+
+			hasConflicts = true
+		} else if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
 			cli.Println("Auto-merging", tblName)
 			cli.Println("CONFLICT (content): Merge conflict in", tblName)
 
@@ -558,7 +566,7 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 	rowsChanged := 0
 	var tbls []string
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts == 0 {
+		if stats.Operation == merge.TableModified && !stats.HasConflicts() {
 			tbls = append(tbls, tblName)
 			nameLen := len(tblName)
 			modCount := stats.Adds + stats.Modifications + stats.Deletes + stats.Conflicts
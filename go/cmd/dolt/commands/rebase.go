@@ -0,0 +1,357 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var rebaseDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Reapply commits on top of another base commit",
+	LongDesc: `Replays the commits of the current branch which are not reachable from {{.LessThan}}upstream{{.GreaterThan}} on top of {{.LessThan}}upstream{{.GreaterThan}}, oldest first, and points the current branch at the result. Merge commits are not replayed, and commits whose changes are already present in {{.LessThan}}upstream{{.GreaterThan}} are skipped.
+
+Each commit is replayed using a three-way merge in which the parent of the commit is used as the common ancestor. If replaying a commit results in conflicts, the rebase stops and the conflicting rows are recorded in the {{.EmphasisLeft}}dolt_conflicts{{.EmphasisRight}} tables. Resolve them using {{.EmphasisLeft}}dolt conflicts resolve{{.EmphasisRight}}, add the affected tables using {{.EmphasisLeft}}dolt add{{.EmphasisRight}}, and run {{.EmphasisLeft}}dolt rebase --continue{{.EmphasisRight}} to commit the result and replay the remaining commits. {{.EmphasisLeft}}dolt rebase --abort{{.EmphasisRight}} stops the rebase and points the current branch back at the commit it pointed to before the rebase started.
+
+Rebasing requires a clean working set.
+`,
+	Synopsis: []string{
+		"{{.LessThan}}upstream{{.GreaterThan}}",
+		"--continue",
+		"--abort",
+	},
+}
+
+type RebaseCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RebaseCmd) Name() string {
+	return "rebase"
+}
+
+// Description returns a description of the command
+func (cmd RebaseCmd) Description() string {
+	return "Reapply commits on top of another base commit."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RebaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+}
+
+func (cmd RebaseCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"upstream", "The commit the current branch should be replayed on top of."})
+	ap.SupportsFlag(cli.ContinueParam, "", "Commit the resolved changes of the commit which stopped the rebase and replay the remaining commits.")
+	ap.SupportsFlag(cli.AbortParam, "", "Abort the rebase and point the current branch back at the commit it pointed to before the rebase started.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, rebaseDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.ContainsAll(cli.ContinueParam, cli.AbortParam) {
+		cli.PrintErrf("error: Flags '--%s' and '--%s' cannot be used together.\n", cli.ContinueParam, cli.AbortParam)
+		return 1
+	}
+
+	var verr errhand.VerboseError
+	if apr.Contains(cli.AbortParam) {
+		if !dEnv.IsRebaseActive() {
+			cli.PrintErrln("fatal: There is no rebase to abort")
+			return 1
+		}
+
+		verr = abortRebase(ctx, dEnv)
+	} else if apr.Contains(cli.ContinueParam) {
+		if !dEnv.IsRebaseActive() {
+			cli.PrintErrln("fatal: There is no rebase in progress")
+			return 1
+		}
+
+		verr = continueRebase(ctx, dEnv)
+	} else {
+		if apr.NArg() != 1 {
+			usage()
+			return 1
+		}
+
+		verr = startRebase(ctx, dEnv, apr.Arg(0))
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func startRebase(ctx context.Context, dEnv *env.DoltEnv, upstreamStr string) errhand.VerboseError {
+	if verr := checkCanApplyCommit(ctx, dEnv, "rebase"); verr != nil {
+		return verr
+	}
+
+	headCm, verr := ResolveCommitWithVErr(dEnv, "HEAD")
+	if verr != nil {
+		return verr
+	}
+
+	upstreamCm, verr := ResolveCommitWithVErr(dEnv, upstreamStr)
+	if verr != nil {
+		return verr
+	}
+
+	headHash, err := headCm.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	upstreamHash, err := upstreamCm.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	missing, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, upstreamHash, dEnv.DoltDB, headHash, 1)
+	if err != nil {
+		return errhand.BuildDError("error: failed to walk the commit history").AddCause(err).Build()
+	}
+
+	if len(missing) == 0 {
+		cli.Printf("Current branch %s is up to date.\n", dEnv.RepoState.CWBHeadRef().GetPath())
+		return nil
+	}
+
+	toReplay, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, headHash, dEnv.DoltDB, upstreamHash, -1)
+	if err != nil {
+		return errhand.BuildDError("error: failed to walk the commit history").AddCause(err).Build()
+	}
+
+	// commits are returned newest first, and are replayed oldest first
+	hashes := make([]string, 0, len(toReplay))
+	for i := len(toReplay) - 1; i >= 0; i-- {
+		n, err := toReplay[i].NumParents()
+		if err != nil {
+			return errhand.BuildDError("error: failed to read commit parents").AddCause(err).Build()
+		}
+
+		if n > 1 {
+			continue
+		}
+
+		h, err := toReplay[i].HashOf()
+		if err != nil {
+			return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+		}
+
+		hashes = append(hashes, h.String())
+	}
+
+	err = dEnv.RepoState.StartRebase(upstreamHash.String(), headHash.String(), hashes, dEnv.FS)
+	if err != nil {
+		return errhand.BuildDError("fatal: failed to save the rebase state").AddCause(err).Build()
+	}
+
+	cli.Println("First, rewinding head to replay your work on top of it...")
+	if verr := resetBranchToCommit(ctx, dEnv, upstreamCm); verr != nil {
+		return verr
+	}
+
+	return replayRemainingCommits(ctx, dEnv)
+}
+
+func continueRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	working, staged, _, err := env.GetRoots(ctx, dEnv.DoltDB, dEnv.RepoStateReader())
+	if err != nil {
+		return errhand.BuildDError("fatal: Unable to read from data repository.").AddCause(err).Build()
+	}
+
+	if has, err := working.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		return errhand.BuildDError("error: you must resolve all conflicts before continuing the rebase.").
+			AddDetails("hint: resolve them using 'dolt conflicts resolve' and mark them as resolved using 'dolt add <table>'").Build()
+	}
+
+	workingHash, err := working.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of root").AddCause(err).Build()
+	}
+
+	stagedHash, err := staged.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of root").AddCause(err).Build()
+	}
+
+	if workingHash != stagedHash {
+		return errhand.BuildDError("error: you have unstaged changes.").
+			AddDetails("hint: add them using 'dolt add <table>' before continuing the rebase").Build()
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, dEnv.RepoState.Rebase.Current)
+	if verr != nil {
+		return verr
+	}
+
+	if verr := commitReplayedChanges(ctx, dEnv, cm); verr != nil {
+		return verr
+	}
+
+	return replayRemainingCommits(ctx, dEnv)
+}
+
+func abortRebase(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	origHead, verr := ResolveCommitWithVErr(dEnv, dEnv.RepoState.Rebase.OrigHead)
+	if verr != nil {
+		return verr
+	}
+
+	if verr := resetBranchToCommit(ctx, dEnv, origHead); verr != nil {
+		return verr
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+	if err != nil {
+		return errhand.BuildDError("fatal: failed to clear the rebase state").AddCause(err).Build()
+	}
+
+	err = actions.SaveTrackedDocsFromWorking(ctx, dEnv)
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// replayRemainingCommits applies the remaining commits of the rebase in order, committing each one on top of the
+// current branch. It stops when replaying a commit results in conflicts, leaving them in the working set to be
+// resolved before the rebase is continued.
+func replayRemainingCommits(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	for len(dEnv.RepoState.Rebase.Remaining) > 0 {
+		h, err := dEnv.RepoState.NextRebaseCommit(dEnv.FS)
+		if err != nil {
+			return errhand.BuildDError("fatal: failed to save the rebase state").AddCause(err).Build()
+		}
+
+		cm, verr := ResolveCommitWithVErr(dEnv, h)
+		if verr != nil {
+			return verr
+		}
+
+		meta, err := cm.GetCommitMeta()
+		if err != nil {
+			return errhand.BuildDError("error: failed to read commit metadata").AddCause(err).Build()
+		}
+
+		headRoot, err := dEnv.HeadRoot(ctx)
+		if err != nil {
+			return errhand.BuildDError("Unable to get head.").AddCause(err).Build()
+		}
+
+		replayedRoot, tblToStats, err := merge.CherryPick(ctx, dEnv.DoltDB, headRoot, cm)
+		if err != nil {
+			return errhand.BuildDError("error: could not apply %s... %s", h, meta.Description).AddCause(err).Build()
+		}
+
+		if verr := UpdateWorkingWithVErr(dEnv, replayedRoot); verr != nil {
+			return verr
+		}
+
+		if hasConflicts := printConflicts(tblToStats); hasConflicts {
+			err = actions.SaveTrackedDocsFromWorking(ctx, dEnv)
+			if err != nil {
+				return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+			}
+
+			cli.Printf("error: could not apply %s... %s\n", h, meta.Description)
+			cli.Println("hint: Resolve all conflicts manually, mark them as resolved with 'dolt add <table>',")
+			cli.Println("hint: then run 'dolt rebase --continue'.")
+			cli.Println("hint: To abort and get back to the state before 'dolt rebase', run 'dolt rebase --abort'.")
+			return nil
+		}
+
+		if verr := UpdateStagedWithVErr(dEnv.DoltDB, dEnv.RepoStateWriter(), replayedRoot); verr != nil {
+			return verr
+		}
+
+		if verr := commitReplayedChanges(ctx, dEnv, cm); verr != nil {
+			return verr
+		}
+	}
+
+	err := dEnv.RepoState.ClearRebase(dEnv.FS)
+	if err != nil {
+		return errhand.BuildDError("fatal: failed to clear the rebase state").AddCause(err).Build()
+	}
+
+	err = actions.SaveTrackedDocsFromWorking(ctx, dEnv)
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	cli.Printf("Successfully rebased and updated %s.\n", dEnv.RepoState.CWBHeadRef().String())
+	return nil
+}
+
+// commitReplayedChanges commits the staged changes using the author and message of |cm|. Commits whose changes are
+// already present on the current branch are skipped.
+func commitReplayedChanges(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) errhand.VerboseError {
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return errhand.BuildDError("error: failed to read commit metadata").AddCause(err).Build()
+	}
+
+	_, err = actions.CommitStaged(ctx, dEnv.DbData(), actions.CommitStagedProps{
+		Message:          meta.Description,
+		Date:             doltdb.CommitNowFunc(),
+		AllowEmpty:       false,
+		CheckForeignKeys: true,
+		Name:             meta.Name,
+		Email:            meta.Email,
+	})
+
+	if err != nil && !actions.IsNothingStaged(err) {
+		return errhand.BuildDError("error: failed to commit the replayed changes of '%s'", meta.Description).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// resetBranchToCommit points the current branch at |cm| and resets the working and staged roots to its root.
+func resetBranchToCommit(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) errhand.VerboseError {
+	err := dEnv.DoltDB.SetHeadToCommit(ctx, dEnv.RepoState.CWBHeadRef(), cm)
+	if err != nil {
+		return errhand.BuildDError("error: failed to update the current branch").AddCause(err).Build()
+	}
+
+	root, err := cm.GetRootValue()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get root value").AddCause(err).Build()
+	}
+
+	if verr := UpdateWorkingWithVErr(dEnv, root); verr != nil {
+		return verr
+	}
+
+	return UpdateStagedWithVErr(dEnv.DoltDB, dEnv.RepoStateWriter(), root)
+}
@@ -249,7 +249,7 @@ func addRemote(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.Verbos
 
 	remoteName := strings.TrimSpace(apr.Arg(1))
 
-	if strings.IndexAny(remoteName, " \t\n\r./\\!@#$%^&*(){}[],.<>'\"?=+|") != -1 {
+	if !env.IsValidRemoteName(remoteName) {
 		return errhand.BuildDError("invalid remote name: " + remoteName).Build()
 	}
 
@@ -0,0 +1,119 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var revertDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Undo the changes introduced by existing commits",
+	LongDesc: `Removes the changes made in each of the given commits from the current branch and records a new commit describing the reverted commits. The commits being reverted remain in the history of the branch.
+
+Each commit is reverted using a three-way merge between HEAD and the parent of the commit, in which the commit itself is used as the common ancestor. For merge commits the first parent is used. If the reverted changes conflict with changes made since, the conflicting rows are recorded in the {{.EmphasisLeft}}dolt_conflicts{{.EmphasisRight}} tables and no commit is made. Once the conflicts are resolved, add the affected tables using {{.EmphasisLeft}}dolt add{{.EmphasisRight}} and commit the result.
+
+When several commits are given and reverting any of them results in conflicts, nothing is reverted. Revert the commits one at a time to resolve their conflicts.
+
+Reverting requires a clean working set.
+`,
+	Synopsis: []string{
+		"{{.LessThan}}commit{{.GreaterThan}}...",
+	},
+}
+
+type RevertCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RevertCmd) Name() string {
+	return "revert"
+}
+
+// Description returns a description of the command
+func (cmd RevertCmd) Description() string {
+	return "Undo the changes introduced by existing commits."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RevertCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+}
+
+func (cmd RevertCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "A commit whose changes should be reverted."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, revertDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 {
+		usage()
+		return 1
+	}
+
+	if verr := checkCanApplyCommit(ctx, dEnv, "revert"); verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	commits := make([]*doltdb.Commit, apr.NArg())
+	for i, cSpecStr := range apr.Args() {
+		cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		commits[i] = cm
+	}
+
+	name, email, err := actions.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return handleCommitErr(ctx, dEnv, err, usage)
+	}
+
+	headRoot, err := dEnv.HeadRoot(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("Unable to get head.").AddCause(err).Build(), usage)
+	}
+
+	revertedRoot, tblToStats, msg, err := merge.Revert(ctx, dEnv.DoltDB, headRoot, commits)
+	if err != nil {
+		if err == merge.ErrCommitHasNoParent {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: cannot revert the initial commit").Build(), usage)
+		} else if errors.Is(err, merge.ErrRevertConflicts) {
+			bdr := errhand.BuildDError("error: %s. Nothing was reverted.", err.Error())
+			bdr.AddDetails("Revert the commits one at a time to resolve their conflicts.")
+			return HandleVErrAndExitCode(bdr.Build(), usage)
+		}
+
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to revert").AddCause(err).Build(), usage)
+	}
+
+	return commitAppliedRoot(ctx, dEnv, revertedRoot, tblToStats, name, email, msg, usage)
+}
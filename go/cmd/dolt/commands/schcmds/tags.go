@@ -59,7 +59,7 @@ func (cmd TagsCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) e
 func (cmd TagsCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "table(s) whose tags will be displayed."})
-	ap.SupportsString(commands.FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json. Defaults to tabular.")
+	ap.SupportsString(commands.FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json, jsonl. Defaults to tabular.")
 	return ap
 }
 
@@ -118,7 +118,7 @@ func (cmd SqlCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "Commit to run read only queries against."})
 	ap.SupportsString(QueryFlag, "q", "SQL query to run", "Runs a single query and exits")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json. Defaults to tabular. ")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format result output. Valid values are tabular, csv, json, jsonl. Defaults to tabular. ")
 	ap.SupportsString(saveFlag, "s", "saved query name", "Used with --query, save the query to the query catalog with the name provided. Saved queries can be examined in the dolt_query_catalog system table.")
 	ap.SupportsString(executeFlag, "x", "saved query name", "Executes a saved query with the given name")
 	ap.SupportsFlag(listSavedFlag, "l", "Lists all saved queries")
@@ -460,10 +460,12 @@ func GetResultFormat(format string) (resultFormat, errhand.VerboseError) {
 		return FormatCsv, nil
 	case "json":
 		return FormatJson, nil
+	case "jsonl":
+		return FormatJsonl, nil
 	case "null":
 		return FormatNull, nil
 	default:
-		return FormatTabular, errhand.BuildDError("Invalid argument for --result-format. Valid values are tabular, csv, json").Build()
+		return FormatTabular, errhand.BuildDError("Invalid argument for --result-format. Valid values are tabular, csv, json, jsonl").Build()
 	}
 }
 
@@ -1239,6 +1241,7 @@ const (
 	FormatTabular resultFormat = iota
 	FormatCsv
 	FormatJson
+	FormatJsonl
 	FormatNull // used for profiling
 )
 
@@ -1377,6 +1380,8 @@ func PrettyPrintResults(ctx *sql.Context, resultFormat resultFormat, sqlSch sql.
 		p = createCSVPipeline(ctx, sqlSch, rowIter)
 	case FormatJson:
 		p = createJSONPipeline(ctx, sqlSch, rowIter)
+	case FormatJsonl:
+		p = createJSONLPipeline(ctx, sqlSch, rowIter)
 	case FormatTabular:
 		p = createTabularPipeline(ctx, sqlSch, rowIter)
 	case FormatNull:
@@ -18,7 +18,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"fmt"
+	"encoding/json"
 	"io"
 	"strconv"
 	"strings"
@@ -77,6 +77,8 @@ func sqlColToStr(col interface{}) string {
 			return strconv.FormatFloat(float64(typedCol), 'g', -1, 32)
 		case string:
 			return typedCol
+		case []byte:
+			return string(typedCol)
 		case bool:
 			if typedCol {
 				return "true"
@@ -213,21 +215,32 @@ func csvProcessStageFunc(ctx context.Context, items []pipeline.ItemWithProps) ([
 func createJSONPipeline(_ context.Context, sch sql.Schema, iter sql.RowIter) *pipeline.Pipeline {
 	p := pipeline.NewPipeline(
 		pipeline.NewStage("read", noParallelizationInitFunc, getReadStageFunc(iter, readBatchSize), 0, 0, 0),
-		pipeline.NewStage("process", nil, getJSONProcessFunc(sch), 2, 1000, readBatchSize),
+		pipeline.NewStage("process", nil, getJSONProcessFunc(sch, false), 2, 1000, readBatchSize),
 		pipeline.NewStage("write", noParallelizationInitFunc, writeJSONToCliOutStageFunc, 0, 100, writeBatchSize),
 	)
 
 	return p
 }
 
-func getJSONProcessFunc(sch sql.Schema) pipeline.StageFunc {
-	formats := make([]string, len(sch))
+// createJSONLPipeline creates a pipeline that writes each row as a JSON object on its own line
+func createJSONLPipeline(_ context.Context, sch sql.Schema, iter sql.RowIter) *pipeline.Pipeline {
+	p := pipeline.NewPipeline(
+		pipeline.NewStage("read", noParallelizationInitFunc, getReadStageFunc(iter, readBatchSize), 0, 0, 0),
+		pipeline.NewStage("process", nil, getJSONProcessFunc(sch, true), 2, 1000, readBatchSize),
+		pipeline.NewStage("write", noParallelizationInitFunc, writeToCliOutStageFunc, 0, 100, writeBatchSize),
+	)
+
+	return p
+}
+
+// getJSONProcessFunc returns a stage func that formats rows as JSON objects. If |lineDelimited| is true each object is
+// followed by a newline, otherwise the objects are separated by commas to be written into the "rows" array.
+func getJSONProcessFunc(sch sql.Schema, lineDelimited bool) pipeline.StageFunc {
+	quoted := make([]bool, len(sch))
 	for i, col := range sch {
 		switch col.Type.(type) {
 		case sql.StringType, sql.DatetimeType, sql.EnumType, sql.TimeType:
-			formats[i] = fmt.Sprintf(`"%s":"%%s"`, col.Name)
-		default:
-			formats[i] = fmt.Sprintf(`"%s":%%s`, col.Name)
+			quoted[i] = true
 		}
 	}
 
@@ -238,10 +251,26 @@ func getJSONProcessFunc(sch sql.Schema) pipeline.StageFunc {
 
 		sb := &strings.Builder{}
 		sb.Grow(2048)
+
+		// names and string values are written with a json.Encoder so that every character which must be is escaped
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		writeString := func(s string) error {
+			buf.Reset()
+			if err := enc.Encode(s); err != nil {
+				return err
+			}
+
+			// Encode follows each value with a newline
+			sb.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+			return nil
+		}
+
 		for i, item := range items {
 			r := item.GetItem().(sql.Row)
 
-			if i != 0 {
+			if i != 0 && !lineDelimited {
 				sb.WriteString(",{")
 			} else {
 				sb.WriteString("{")
@@ -255,14 +284,27 @@ func getJSONProcessFunc(sch sql.Schema) pipeline.StageFunc {
 					}
 
 					validCols++
+					if err := writeString(sch[colNum].Name); err != nil {
+						return nil, err
+					}
+					sb.WriteRune(':')
+
 					colStr := sqlColToStr(col)
-					colStr = strings.Replace(colStr, "\"", "\\\"", -1)
-					str := fmt.Sprintf(formats[colNum], colStr)
-					sb.WriteString(str)
+					if quoted[colNum] {
+						if err := writeString(colStr); err != nil {
+							return nil, err
+						}
+					} else {
+						// numbers are written as they are, and JSON documents as nested JSON values
+						sb.WriteString(colStr)
+					}
 				}
 			}
 
 			sb.WriteRune('}')
+			if lineDelimited {
+				sb.WriteRune('\n')
+			}
 		}
 
 		str := sb.String()
@@ -0,0 +1,252 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const (
+	metricsNamespace = "dolt"
+	metricsPath      = "/metrics"
+)
+
+// statementTypes are the statement types queries are labeled with in metrics. Queries starting with any other keyword
+// are labeled as "other".
+var statementTypes = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true, "replace": true, "create": true, "alter": true,
+	"drop": true, "rename": true, "truncate": true, "show": true, "describe": true, "explain": true, "set": true,
+	"use": true, "begin": true, "start": true, "commit": true, "rollback": true, "load": true, "with": true,
+	"call": true, "kill": true,
+}
+
+// statementType returns the type of the statement |query| as the lowercased keyword it starts with, after any
+// comments and parentheses.
+func statementType(query string) string {
+	for {
+		query = strings.TrimLeftFunc(query, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '('
+		})
+
+		if !strings.HasPrefix(query, "/*") {
+			break
+		}
+
+		end := strings.Index(query, "*/")
+		if end == -1 {
+			return "other"
+		}
+
+		query = query[end+2:]
+	}
+
+	end := strings.IndexFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	if end == -1 {
+		end = len(query)
+	}
+
+	keyword := strings.ToLower(query[:end])
+	if keyword == "desc" {
+		keyword = "describe"
+	}
+
+	if !statementTypes[keyword] {
+		return "other"
+	}
+
+	return keyword
+}
+
+// serverMetrics are the metrics of a running sql-server. Every server has its own registry, so that the servers a
+// process runs do not share them. The metrics of the databases of the server are collected by a databaseCollector
+// registered with it.
+type serverMetrics struct {
+	registry         *prometheus.Registry
+	connections      prometheus.Gauge
+	connectionsTotal prometheus.Counter
+	queryDuration    *prometheus.HistogramVec
+	queryErrors      *prometheus.CounterVec
+}
+
+func newServerMetrics() *serverMetrics {
+	m := &serverMetrics{
+		registry: prometheus.NewRegistry(),
+		connections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "sql_server",
+			Name:      "connections",
+			Help:      "The number of open connections.",
+		}),
+		connectionsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "sql_server",
+			Name:      "connections_total",
+			Help:      "The number of connections accepted.",
+		}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "sql_server",
+			Name:      "query_duration_seconds",
+			Help:      "The latency of queries by statement type.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"statement"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "sql_server",
+			Name:      "query_errors_total",
+			Help:      "The number of queries which failed by statement type.",
+		}, []string{"statement"}),
+	}
+
+	m.registry.MustRegister(
+		m.connections,
+		m.connectionsTotal,
+		m.queryDuration,
+		m.queryErrors,
+		prometheus.NewGoCollector(),
+	)
+
+	return m
+}
+
+func (m *serverMetrics) connectionOpened() {
+	m.connections.Inc()
+	m.connectionsTotal.Inc()
+}
+
+func (m *serverMetrics) connectionClosed() {
+	m.connections.Dec()
+}
+
+// queryDone records the latency of |query|, which started at |start|, and whether it failed.
+func (m *serverMetrics) queryDone(query string, start time.Time, err error) {
+	stmt := statementType(query)
+	m.queryDuration.WithLabelValues(stmt).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		m.queryErrors.WithLabelValues(stmt).Inc()
+	}
+}
+
+// serve serves the metrics over HTTP at |host|:|port| until the returned function is called.
+func (m *serverMetrics) serve(host string, port int) (func() error, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for metrics requests: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Handler: mux}
+
+	go func() {
+		err := srv.Serve(l)
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("metrics endpoint stopped: %v", err)
+		}
+	}()
+
+	logrus.Infof("serving metrics at http://%s%s", l.Addr(), metricsPath)
+	return srv.Close, nil
+}
+
+var (
+	chunkGetsDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "chunk_store", "chunk_gets_total"),
+		"The number of chunks requested from the chunk store of a database.", []string{"database"}, nil)
+	chunkHasChecksDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "chunk_store", "chunk_has_checks_total"),
+		"The number of chunks checked for in the chunk store of a database.", []string{"database"}, nil)
+	chunkPutsDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "chunk_store", "chunk_puts_total"),
+		"The number of chunks written to the chunk store of a database.", []string{"database"}, nil)
+	chunkCountDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "chunk_store", "chunks"),
+		"The number of chunks in the table files of the chunk store of a database.", []string{"database"}, nil)
+	physicalBytesDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "chunk_store", "physical_bytes"),
+		"The size of the table files of the chunk store of a database.", []string{"database"}, nil)
+	commitsDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "commits_total"),
+		"The number of commits written to the branches of a database, including merge commits.", []string{"database"}, nil)
+	mergesDesc = prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "merges_total"),
+		"The number of merges completed on the branches of a database, by merge commits and fast-forwards.", []string{"database"}, nil)
+)
+
+// databaseCollector is a prometheus.Collector for the metrics of the databases of a runningServer: the chunk store
+// metrics which DoltDB.CSMetricsSummary reports, and the commits and merges written to their branches.
+type databaseCollector struct {
+	rs *runningServer
+}
+
+func newDatabaseCollector(rs *runningServer) prometheus.Collector {
+	return databaseCollector{rs: rs}
+}
+
+// Describe implements prometheus.Collector.
+func (c databaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- commitsDesc
+	ch <- mergesDesc
+	ch <- chunkGetsDesc
+	ch <- chunkHasChecksDesc
+	ch <- chunkPutsDesc
+	ch <- chunkCountDesc
+	ch <- physicalBytesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c databaseCollector) Collect(ch chan<- prometheus.Metric) {
+	c.rs.mu.Lock()
+	dbs := make([]dsqle.Database, 0, len(c.rs.databases))
+	for _, db := range c.rs.databases {
+		dbs = append(dbs, db)
+	}
+	c.rs.mu.Unlock()
+
+	for _, db := range dbs {
+		updates := db.GetDoltDB().BranchUpdates()
+		ch <- prometheus.MustNewConstMetric(commitsDesc, prometheus.CounterValue, float64(updates.Commits), db.Name())
+		ch <- prometheus.MustNewConstMetric(mergesDesc, prometheus.CounterValue, float64(updates.Merges), db.Name())
+
+		metrics, ok, err := db.GetDoltDB().CSMetrics()
+
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(chunkCountDesc, err)
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(chunkGetsDesc, prometheus.CounterValue, float64(metrics.ChunkGets), db.Name())
+		ch <- prometheus.MustNewConstMetric(chunkHasChecksDesc, prometheus.CounterValue, float64(metrics.ChunkHasChecks), db.Name())
+		ch <- prometheus.MustNewConstMetric(chunkPutsDesc, prometheus.CounterValue, float64(metrics.ChunkPuts), db.Name())
+		ch <- prometheus.MustNewConstMetric(chunkCountDesc, prometheus.GaugeValue, float64(metrics.ChunkCount), db.Name())
+		ch <- prometheus.MustNewConstMetric(physicalBytesDesc, prometheus.GaugeValue, float64(metrics.PhysicalBytes), db.Name())
+	}
+}
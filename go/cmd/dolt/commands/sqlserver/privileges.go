@@ -0,0 +1,909 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/parse"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+)
+
+// ErrBranchPermission is returned when a user lacks the permissions needed on the current branch of a database.
+var ErrBranchPermission = errors.NewKind("user '%s' does not have %s permission on branch '%s' of database '%s'")
+
+// ErrDatabasePermission is returned when a user lacks the permissions needed to run a statement without a current
+// dolt database.
+var ErrDatabasePermission = errors.NewKind("user '%s' does not have %s permission without a current database")
+
+// ErrNonDoltDatabasePermission is returned when a user lacks the permissions needed on a database which is not a dolt
+// database.
+var ErrNonDoltDatabasePermission = errors.NewKind("user '%s' does not have %s permission on database '%s'")
+
+// ErrEveryBranchPermission is returned when a user lacks the permissions needed on every branch of a database, for a
+// statement which may change any of them.
+var ErrEveryBranchPermission = errors.NewKind("user '%s' does not have %s permission on every branch of database '%s'")
+
+// validateUsers returns an error if the users have no name, are defined more than once, or are granted unknown
+// permissions.
+func validateUsers(users []UserConfig) error {
+	seen := make(map[string]bool)
+	for _, user := range users {
+		if len(user.Name) == 0 {
+			return fmt.Errorf("user cannot be empty")
+		}
+
+		if seen[user.Name] {
+			return fmt.Errorf("user '%s' is defined more than once", user.Name)
+		}
+		seen[user.Name] = true
+
+		for _, priv := range user.Privileges {
+			if _, err := parsePermissions(priv.Permissions); err != nil {
+				return fmt.Errorf("invalid privileges for user '%s': %w", user.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parsePermissions(names []string) (auth.Permission, error) {
+	if len(names) == 0 {
+		return auth.DefaultPermissions, nil
+	}
+
+	var perm auth.Permission
+	for _, name := range names {
+		p, ok := auth.PermissionNames[strings.ToLower(name)]
+
+		if !ok {
+			return 0, fmt.Errorf("unknown permission '%s'", name)
+		}
+
+		perm |= p
+	}
+
+	return perm, nil
+}
+
+// matchPattern returns whether |s| matches |pattern|, in which a '*' matches any sequence of characters and which is
+// matched in its entirety by the empty string.
+func matchPattern(pattern, s string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+
+		if idx == -1 {
+			return false
+		}
+
+		s = s[idx+len(part):]
+	}
+
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
+
+// writeFunctions are the dolt functions which change the working set, the staged root or the head of the current
+// branch.
+var writeFunctions = map[string]bool{
+	dfunctions.CommitFuncName:         true,
+	dfunctions.MergeFuncName:          true,
+	dfunctions.ResetFuncName:          true,
+	dfunctions.DoltAddFuncName:        true,
+	dfunctions.DoltCommitFuncName:     true,
+	dfunctions.DoltResetFuncName:      true,
+	dfunctions.DoltMergeFuncName:      true,
+	dfunctions.DoltCherryPickFuncName: true,
+}
+
+// statementAccess is a database which a statement reads or writes, and the branch of it which is accessed. An empty
+// database is the current database of the session.
+type statementAccess struct {
+	database string
+	perm     auth.Permission
+	// branch is the branch accessed, if it is not the one checked out for the session
+	branch string
+	// everyBranch is true if the statement may change any branch of the database
+	everyBranch bool
+}
+
+// accessCollector collects the databases and branches the parsed plan of a statement accesses.
+type accessCollector struct {
+	ctx      *sql.Context
+	accesses []statementAccess
+	// databases is true once a table or database named by the statement is collected
+	databases bool
+}
+
+// statementAccesses returns the databases and branches the statement of |ctx| accesses, given the permission |perm|
+// the engine requires for it. Every table the statement reads is read from its database, and the tables it changes
+// are written. The engine only requires write permission for statements which change the rows of a table, so schema
+// changes, COMMIT, setting the head or working root of a database and the dolt functions which write require it
+// here. Writes to dolt_branches and branches created by dolt_checkout are writes to the branches they change.
+// Statements naming no database access the current one.
+func statementAccesses(ctx *sql.Context, perm auth.Permission) []statementAccess {
+	node, err := parse.Parse(ctx, ctx.Query())
+
+	if err != nil {
+		// the engine reports the error when it parses the statement
+		return []statementAccess{{perm: perm}}
+	}
+
+	ac := &accessCollector{ctx: ctx}
+	ac.collect(node)
+
+	if !ac.databases {
+		ac.add("", perm)
+	}
+
+	if perm&auth.WritePerm != 0 && !ac.writes() {
+		ac.add("", perm)
+	}
+
+	return ac.accesses
+}
+
+func (ac *accessCollector) add(dbName string, perm auth.Permission) {
+	ac.addAccess(statementAccess{database: dbName, perm: perm})
+}
+
+func (ac *accessCollector) addAccess(access statementAccess) {
+	for _, a := range ac.accesses {
+		if a == access {
+			return
+		}
+	}
+
+	ac.accesses = append(ac.accesses, access)
+}
+
+func (ac *accessCollector) writes() bool {
+	for _, access := range ac.accesses {
+		if access.perm&auth.WritePerm != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ac *accessCollector) collect(node sql.Node) {
+	plan.Inspect(node, func(n sql.Node) bool {
+		switch n := n.(type) {
+		case *plan.UnresolvedTable:
+			ac.databases = true
+			ac.add(n.Database, auth.ReadPerm)
+		case *plan.InsertInto:
+			ac.writeTables(n, n.Destination)
+			ac.collect(n.Source)
+		case *plan.Update:
+			ac.writeTables(n, n.Child)
+		case *plan.DeleteFrom:
+			ac.writeTables(n, n.Child)
+		case *plan.LoadData:
+			ac.writeTables(n, n.Destination)
+		case *plan.CreateIndex:
+			ac.writeTables(n, n.Table)
+		case *plan.AlterIndex:
+			ac.writeTables(n, n.Table)
+		case *plan.DropIndex:
+			ac.writeTables(n, n.Table)
+		case *plan.CreateForeignKey:
+			ac.writeTables(n, n.Left())
+		case *plan.DropForeignKey:
+			ac.writeTables(n, n.Child)
+		case *plan.AlterAutoIncrement:
+			ac.writeTables(n, n.Child)
+		case *plan.CreateTrigger:
+			ac.writeTables(n, n.Table)
+		case *plan.Commit:
+			ac.add("", auth.ReadPerm|auth.WritePerm)
+		case *plan.Set:
+			ac.setVariables(n)
+		case *plan.CreateDB, *plan.DropDB:
+			ac.databases = true
+			ac.add(dbDDLName(ac.ctx.Query()), auth.ReadPerm|auth.WritePerm)
+		case *plan.CreateView, *plan.SingleDropView:
+			ac.databases = true
+			ac.add(n.(sql.Databaser).Database().Name(), auth.ReadPerm|auth.WritePerm)
+		case sql.Databaser:
+			ac.databases = true
+			if !writesSchema(n.(sql.Node)) {
+				ac.add(n.Database().Name(), auth.ReadPerm)
+				break
+			}
+
+			ac.add(n.Database().Name(), auth.ReadPerm|auth.WritePerm)
+			for _, dbName := range tableQualifiers(ac.ctx.Query()) {
+				ac.add(dbName, auth.ReadPerm|auth.WritePerm)
+			}
+		}
+
+		return true
+	})
+
+	plan.InspectExpressions(node, func(e sql.Expression) bool {
+		switch e := e.(type) {
+		case *expression.UnresolvedFunction:
+			ac.function(e)
+		case *plan.Subquery:
+			ac.collect(e.Query)
+		}
+
+		return true
+	})
+}
+
+// writeTables collects the tables in |tables| as tables written by the statement |stmt|. Writes to dolt_branches are
+// writes to the branches the statement changes.
+func (ac *accessCollector) writeTables(stmt, tables sql.Node) {
+	plan.Inspect(tables, func(n sql.Node) bool {
+		t, ok := n.(*plan.UnresolvedTable)
+
+		if !ok {
+			return true
+		}
+
+		ac.databases = true
+		if !strings.EqualFold(t.Name(), doltdb.BranchesTableName) {
+			ac.add(t.Database, auth.ReadPerm|auth.WritePerm)
+			return true
+		}
+
+		ac.add(t.Database, auth.ReadPerm)
+		names, ok := branchesWritten(stmt)
+
+		if !ok {
+			ac.addAccess(statementAccess{database: t.Database, perm: auth.ReadPerm | auth.WritePerm, everyBranch: true})
+			return true
+		}
+
+		for _, name := range names {
+			ac.addAccess(statementAccess{database: t.Database, perm: auth.ReadPerm | auth.WritePerm, branch: name})
+		}
+
+		return true
+	})
+}
+
+// setVariables collects the databases whose head or working root the SET statement |set| changes, which are writes
+// to their current branches.
+func (ac *accessCollector) setVariables(set *plan.Set) {
+	for _, e := range set.Exprs {
+		sf, ok := e.(*expression.SetField)
+
+		if !ok {
+			continue
+		}
+
+		col, ok := sf.Left.(*expression.UnresolvedColumn)
+
+		if !ok {
+			continue
+		}
+
+		name := strings.ToLower(col.Name())
+		for _, prefix := range []string{"@@session.", "@@local.", "@@"} {
+			if strings.HasPrefix(name, prefix) {
+				name = name[len(prefix):]
+				break
+			}
+		}
+
+		if strings.HasPrefix(name, "@") {
+			// user variables are not session variables of a database
+			continue
+		}
+
+		if isHead, dbName := dsqle.IsHeadKey(name); isHead {
+			ac.databases = true
+			ac.add(dbName, auth.ReadPerm|auth.WritePerm)
+		} else if isWorking, dbName := dsqle.IsWorkingKey(name); isWorking {
+			ac.databases = true
+			ac.add(dbName, auth.ReadPerm|auth.WritePerm)
+		}
+	}
+}
+
+// function collects the accesses of the dolt function |f|. The functions which write change the current branch, and
+// dolt_checkout writes the branch it creates, or the current branch when it checks out tables.
+func (ac *accessCollector) function(f *expression.UnresolvedFunction) {
+	name := strings.ToLower(f.Name())
+
+	if writeFunctions[name] {
+		ac.add("", auth.ReadPerm|auth.WritePerm)
+		return
+	}
+
+	if name != dfunctions.DoltCheckoutFuncName {
+		return
+	}
+
+	args := f.Children()
+	for i, arg := range args {
+		if s, ok := stringLiteral(arg); !ok || s != "-b" {
+			continue
+		}
+
+		if i+1 < len(args) {
+			if branch, ok := stringLiteral(args[i+1]); ok {
+				ac.addAccess(statementAccess{perm: auth.ReadPerm | auth.WritePerm, branch: branch})
+				return
+			}
+		}
+
+		ac.addAccess(statementAccess{perm: auth.ReadPerm | auth.WritePerm, everyBranch: true})
+		return
+	}
+
+	if len(args) == 1 {
+		if branch, ok := stringLiteral(args[0]); ok && ac.isBranch(branch) {
+			// switching branches changes nothing, the statements run on the branch are checked against it
+			return
+		}
+	}
+
+	ac.add("", auth.ReadPerm|auth.WritePerm)
+}
+
+// isBranch returns whether |name| is a branch of the current database.
+func (ac *accessCollector) isBranch(name string) bool {
+	dSess, ok := ac.ctx.Session.(*dsqle.DoltSession)
+
+	if !ok {
+		return false
+	}
+
+	ddb, ok := dSess.GetDoltDB(ac.ctx.GetCurrentDatabase())
+
+	if !ok || !doltdb.IsValidUserBranchName(name) {
+		return false
+	}
+
+	exists, err := ddb.HasRef(ac.ctx, ref.NewBranchRef(name))
+	return err == nil && exists
+}
+
+// branchesWritten returns the names of the branches which the statement |stmt| changes by writing to dolt_branches.
+// They are the names inserted, and for updates and deletes the names their filter selects and the names set. The
+// returned bool is false if they can not be determined from the statement.
+func branchesWritten(stmt sql.Node) ([]string, bool) {
+	switch stmt := stmt.(type) {
+	case *plan.InsertInto:
+		values, ok := stmt.Source.(*plan.Values)
+
+		if !ok {
+			return nil, false
+		}
+
+		idx := 0
+		if len(stmt.ColumnNames) > 0 {
+			idx = -1
+			for i, col := range stmt.ColumnNames {
+				if strings.EqualFold(col, "name") {
+					idx = i
+				}
+			}
+		}
+
+		var names []string
+		for _, tuple := range values.ExpressionTuples {
+			if idx == -1 || idx >= len(tuple) {
+				return nil, false
+			}
+
+			name, ok := stringLiteral(tuple[idx])
+
+			if !ok {
+				return nil, false
+			}
+
+			names = append(names, name)
+		}
+
+		return names, true
+	case *plan.Update:
+		src, ok := stmt.Child.(*plan.UpdateSource)
+
+		if !ok {
+			return nil, false
+		}
+
+		names, ok := filteredBranches(src.Child)
+
+		if !ok {
+			return nil, false
+		}
+
+		for _, e := range src.UpdateExprs {
+			sf, ok := e.(*expression.SetField)
+
+			if !ok {
+				return nil, false
+			}
+
+			if !isNameColumn(sf.Left) {
+				continue
+			}
+
+			name, ok := stringLiteral(sf.Right)
+
+			if !ok {
+				return nil, false
+			}
+
+			names = append(names, name)
+		}
+
+		return names, true
+	case *plan.DeleteFrom:
+		return filteredBranches(stmt.Child)
+	}
+
+	return nil, false
+}
+
+// filteredBranches returns the names of the branches the filter of |node|, the rows of an update or delete, selects.
+func filteredBranches(node sql.Node) ([]string, bool) {
+	for {
+		switch n := node.(type) {
+		case *plan.Filter:
+			return filterNames(n.Expression)
+		case *plan.Limit:
+			node = n.Child
+		case *plan.Offset:
+			node = n.Child
+		case *plan.Sort:
+			node = n.Child
+		default:
+			return nil, false
+		}
+	}
+}
+
+// filterNames returns the values of the name column which the filter expression |e| allows, if it only allows a
+// known set of them.
+func filterNames(e sql.Expression) ([]string, bool) {
+	switch e := e.(type) {
+	case *expression.Equals:
+		if isNameColumn(e.Left()) {
+			name, ok := stringLiteral(e.Right())
+			return []string{name}, ok
+		} else if isNameColumn(e.Right()) {
+			name, ok := stringLiteral(e.Left())
+			return []string{name}, ok
+		}
+	case *expression.InTuple:
+		tuple, ok := e.Right().(expression.Tuple)
+
+		if !ok || !isNameColumn(e.Left()) {
+			return nil, false
+		}
+
+		names := make([]string, len(tuple))
+		for i, val := range tuple {
+			if names[i], ok = stringLiteral(val); !ok {
+				return nil, false
+			}
+		}
+
+		return names, true
+	case *expression.And:
+		if names, ok := filterNames(e.Left); ok {
+			return names, true
+		}
+
+		return filterNames(e.Right)
+	case *expression.Or:
+		left, ok := filterNames(e.Left)
+
+		if !ok {
+			return nil, false
+		}
+
+		right, ok := filterNames(e.Right)
+
+		if !ok {
+			return nil, false
+		}
+
+		return append(left, right...), true
+	}
+
+	return nil, false
+}
+
+func isNameColumn(e sql.Expression) bool {
+	col, ok := e.(*expression.UnresolvedColumn)
+	return ok && strings.EqualFold(col.Name(), "name")
+}
+
+func stringLiteral(e sql.Expression) (string, bool) {
+	lit, ok := e.(*expression.Literal)
+
+	if !ok {
+		return "", false
+	}
+
+	s, ok := lit.Value().(string)
+	return s, ok
+}
+
+// tableQualifiers returns the database names which qualify table names in |query|. The engine makes schema changes to
+// the current database whichever database their tables are qualified with, and they are checked against both.
+func tableQualifiers(query string) []string {
+	stmt, err := sqlparser.Parse(query)
+
+	if err != nil {
+		return nil
+	}
+
+	var dbNames []string
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if tn, ok := node.(sqlparser.TableName); ok && !tn.Qualifier.IsEmpty() {
+			dbNames = append(dbNames, tn.Qualifier.String())
+		}
+
+		return true, nil
+	}, stmt)
+
+	return dbNames
+}
+
+// dbDDLName returns the name of the database |query| creates or drops.
+func dbDDLName(query string) string {
+	stmt, err := sqlparser.Parse(query)
+
+	if err != nil {
+		return ""
+	}
+
+	if ddl, ok := stmt.(*sqlparser.DBDDL); ok {
+		return ddl.DBName
+	}
+
+	return ""
+}
+
+func writesSchema(node sql.Node) bool {
+	switch node.(type) {
+	case *plan.CreateTable, *plan.DropTable, *plan.RenameTable, *plan.AddColumn, *plan.DropColumn,
+		*plan.RenameColumn, *plan.ModifyColumn, *plan.CreateProcedure, *plan.DropProcedure, *plan.DropTrigger:
+		return true
+	}
+
+	return false
+}
+
+type branchPrivilege struct {
+	database string
+	branch   string
+	perm     auth.Permission
+}
+
+// matches returns whether the privilege applies to |branch| of |dbName|. Database names are not case sensitive.
+func (bp branchPrivilege) matches(dbName, branch string) bool {
+	return matchPattern(strings.ToLower(bp.database), strings.ToLower(dbName)) && matchPattern(bp.branch, branch)
+}
+
+type privilegedUser struct {
+	password   string
+	privileges []branchPrivilege
+}
+
+// permissions returns the permissions of the first privilege matching |branch| of |dbName|.
+func (pu privilegedUser) permissions(dbName, branch string) auth.Permission {
+	for _, priv := range pu.privileges {
+		if priv.matches(dbName, branch) {
+			return priv.perm
+		}
+	}
+
+	return 0
+}
+
+// permissionsOnEveryBranch returns the permissions the user has on every branch of |dbName|, which are those that
+// every privilege matching the database up to the first one matching every branch allows.
+func (pu privilegedUser) permissionsOnEveryBranch(dbName string) auth.Permission {
+	perm := auth.AllPermissions
+	for _, priv := range pu.privileges {
+		if !matchPattern(strings.ToLower(priv.database), strings.ToLower(dbName)) {
+			continue
+		}
+
+		perm &= priv.perm
+		if priv.branch == "" || priv.branch == "*" {
+			return perm
+		}
+	}
+
+	return 0
+}
+
+// permissionsWithoutDatabase returns the permissions of statements run without a current dolt database. They may read
+// if any privilege allows reading, and may write only if a privilege applies to every database and branch.
+func (pu privilegedUser) permissionsWithoutDatabase() auth.Permission {
+	var perm auth.Permission
+	for _, priv := range pu.privileges {
+		perm |= priv.perm & auth.ReadPerm
+	}
+
+	return perm | pu.permissions("", "")
+}
+
+// PrivilegeAuth is an auth.Auth implementation for multiple users which are granted permissions per database and per
+// branch. The permissions of a statement are checked against every database it accesses and the branch checked out
+// for it, and against the branches it changes without checking them out.
+type PrivilegeAuth struct {
+	users map[string]privilegedUser
+}
+
+var _ auth.Auth = (*PrivilegeAuth)(nil)
+
+// NewPrivilegeAuth creates a PrivilegeAuth for |users|. If |readOnly| is true no user is allowed to write.
+func NewPrivilegeAuth(users []UserConfig, readOnly bool) (*PrivilegeAuth, error) {
+	if err := validateUsers(users); err != nil {
+		return nil, err
+	}
+
+	userMap := make(map[string]privilegedUser, len(users))
+	for _, user := range users {
+		pu := privilegedUser{password: auth.NativePassword(user.Password)}
+
+		for _, priv := range user.Privileges {
+			perm, err := parsePermissions(priv.Permissions)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if readOnly {
+				perm &= auth.ReadPerm
+			}
+
+			pu.privileges = append(pu.privileges, branchPrivilege{database: priv.Database, branch: priv.Branch, perm: perm})
+		}
+
+		userMap[user.Name] = pu
+	}
+
+	return &PrivilegeAuth{users: userMap}, nil
+}
+
+// Mysql returns the mysql.AuthServer which validates the passwords of the users.
+func (pa *PrivilegeAuth) Mysql() mysql.AuthServer {
+	authServer := mysql.NewAuthServerStatic()
+
+	for name, user := range pa.users {
+		authServer.Entries[name] = []*mysql.AuthServerStaticEntry{
+			{
+				MysqlNativePassword: user.password,
+				Password:            user.password,
+			},
+		}
+	}
+
+	return authServer
+}
+
+// Allowed returns an error if the user of the session is not granted |permission|, along with the permissions the
+// statement requires, on every database and branch the statement accesses.
+func (pa *PrivilegeAuth) Allowed(ctx *sql.Context, permission auth.Permission) error {
+	userName := ctx.Client().User
+	user, ok := pa.users[userName]
+
+	if !ok {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(permission))
+	}
+
+	for _, access := range statementAccesses(ctx, permission) {
+		if err := user.allowed(ctx, userName, access); err != nil {
+			return auth.ErrNotAuthorized.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// allowed returns an error if the user is not granted the permissions of |access|.
+func (pu privilegedUser) allowed(ctx *sql.Context, userName string, access statementAccess) error {
+	dbName := access.database
+	if len(dbName) == 0 {
+		dbName = ctx.GetCurrentDatabase()
+	}
+
+	baseName, branch, ok := databaseBranch(ctx, dbName)
+
+	var granted auth.Permission
+	switch {
+	case !ok:
+		granted = pu.permissionsWithoutDatabase()
+	case access.everyBranch:
+		granted = pu.permissionsOnEveryBranch(baseName)
+	case len(access.branch) > 0:
+		branch = access.branch
+		granted = pu.permissions(baseName, branch)
+	default:
+		granted = pu.permissions(baseName, branch)
+	}
+
+	if granted&access.perm == access.perm {
+		return nil
+	}
+
+	missing := permissionString(access.perm &^ granted)
+	switch {
+	case !ok && len(dbName) == 0:
+		return ErrDatabasePermission.New(userName, missing)
+	case !ok:
+		return ErrNonDoltDatabasePermission.New(userName, missing, dbName)
+	case access.everyBranch:
+		return ErrEveryBranchPermission.New(userName, missing, baseName)
+	}
+
+	return ErrBranchPermission.New(userName, missing, branch, baseName)
+}
+
+// permissionString returns the names of the permissions in |perm|. auth.Permission's own String method ranges over a
+// map, so it doesn't list them in a stable order.
+func permissionString(perm auth.Permission) string {
+	var names []string
+	if perm&auth.ReadPerm != 0 {
+		names = append(names, "read")
+	}
+	if perm&auth.WritePerm != 0 {
+		names = append(names, "write")
+	}
+	return strings.Join(names, ", ")
+}
+
+// currentBranch returns the current database of the session and the branch checked out for it. For a revision
+// database it returns the database it is a revision of and the revision. The returned bool is false if there is no
+// current database or if it is not a dolt database.
+func currentBranch(ctx *sql.Context) (string, string, bool) {
+	return databaseBranch(ctx, ctx.GetCurrentDatabase())
+}
+
+// databaseBranch returns the name of the database |dbName| and the branch checked out for it in the session. For a
+// revision database it returns the database it is a revision of and the revision. The returned bool is false if
+// |dbName| is empty or is not a dolt database.
+func databaseBranch(ctx *sql.Context, dbName string) (string, string, bool) {
+	if len(dbName) == 0 {
+		return "", "", false
+	}
+
+	dSess, ok := ctx.Session.(*dsqle.DoltSession)
+
+	if !ok {
+		return "", "", false
+	}
+
+	baseName, revision, isRevision := dsqle.SplitRevisionDbName(dbName)
+	rsr, ok := dSess.GetDoltDBRepoStateReader(dbName)
+
+	if ok {
+		return baseName, rsr.CWBHeadRef().GetPath(), true
+	}
+
+	// a revision database is added to the session once the statement using it is analyzed
+	if _, ok := dSess.GetDoltDBRepoStateReader(baseName); ok && isRevision {
+		return baseName, revision, true
+	}
+
+	return "", "", false
+}
+
+// auditLog is an auth.AuditMethod which logs authentication events as info, denied statements as warnings, and
+// allowed statements and their execution as debug, so that the log is not flooded with a line for every statement.
+// Denied statements are logged with the database and branch they were denied on.
+type auditLog struct {
+	log *logrus.Entry
+}
+
+var _ auth.AuditMethod = (*auditLog)(nil)
+
+func newAuditLog(l *logrus.Logger) auth.AuditMethod {
+	return &auditLog{log: l.WithField("system", "audit")}
+}
+
+// Authentication logs an authentication event.
+func (al *auditLog) Authentication(user, address string, err error) {
+	fields := logrus.Fields{
+		"action":  "authentication",
+		"user":    user,
+		"address": address,
+		"success": err == nil,
+	}
+
+	if err != nil {
+		fields["err"] = err
+	}
+
+	al.log.WithFields(fields).Info("audit trail")
+}
+
+// Authorization logs an authorization event.
+func (al *auditLog) Authorization(ctx *sql.Context, p auth.Permission, err error) {
+	fields := auditFields(ctx, err)
+	fields["action"] = "authorization"
+	fields["permission"] = p.String()
+
+	if err == nil {
+		al.log.WithFields(fields).Debug("audit trail")
+		return
+	}
+
+	if dbName, branch, ok := currentBranch(ctx); ok {
+		fields["database"] = dbName
+		fields["branch"] = branch
+	}
+
+	al.log.WithFields(fields).Warn("denied statement")
+}
+
+// Query logs a query execution.
+func (al *auditLog) Query(ctx *sql.Context, d time.Duration, err error) {
+	fields := auditFields(ctx, err)
+	fields["action"] = "query"
+	fields["duration"] = d
+
+	al.log.WithFields(fields).Debug("audit trail")
+}
+
+func auditFields(ctx *sql.Context, err error) logrus.Fields {
+	fields := logrus.Fields{
+		"user":          ctx.Client().User,
+		"query":         ctx.Query(),
+		"address":       ctx.Client().Address,
+		"connection_id": ctx.Session.ID(),
+		"pid":           ctx.Pid(),
+		"success":       err == nil,
+	}
+
+	if err != nil {
+		fields["err"] = err
+	}
+
+	return fields
+}
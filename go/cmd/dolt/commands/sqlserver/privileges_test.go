@@ -0,0 +1,163 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		s        string
+		expected bool
+	}{
+		{"", "master", true},
+		{"*", "", true},
+		{"*", "analyst/feature", true},
+		{"master", "master", true},
+		{"master", "master2", false},
+		{"analyst/*", "analyst/feature", true},
+		{"analyst/*", "analyst/", true},
+		{"analyst/*", "other/feature", false},
+		{"*/wip", "analyst/wip", true},
+		{"*/wip", "analyst/wip2", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "axbxc", true},
+		{"a*b*c", "axcxb", false},
+		{"ab*b", "ab", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.s, func(t *testing.T) {
+			assert.Equal(t, test.expected, matchPattern(test.pattern, test.s))
+		})
+	}
+}
+
+func TestStatementAccesses(t *testing.T) {
+	readWrite := auth.ReadPerm | auth.WritePerm
+	read := statementAccess{perm: auth.ReadPerm}
+	write := statementAccess{perm: readWrite}
+
+	tests := []struct {
+		query    string
+		perm     auth.Permission
+		expected []statementAccess
+	}{
+		{"SELECT * FROM people", auth.ReadPerm, []statementAccess{read}},
+		{"SELECT 1", auth.ReadPerm, []statementAccess{read}},
+		{"SELECT * FROM prod.people JOIN scratch.people", auth.ReadPerm, []statementAccess{
+			{database: "prod", perm: auth.ReadPerm},
+			{database: "scratch", perm: auth.ReadPerm},
+		}},
+		{"SELECT DOLT_CHECKOUT('-b', 'analyst/feature')", auth.ReadPerm, []statementAccess{
+			{perm: readWrite, branch: "analyst/feature"},
+			read,
+		}},
+		{"SELECT DOLT_COMMIT('-m', 'message')", auth.ReadPerm, []statementAccess{write, read}},
+		{"SELECT RESET('hard')", auth.ReadPerm, []statementAccess{write, read}},
+		{"SELECT MERGE('other')", auth.ReadPerm, []statementAccess{write, read}},
+		{"SELECT * FROM people WHERE name IN (SELECT dolt_merge('other'))", auth.ReadPerm, []statementAccess{read, write}},
+		{"CREATE TABLE t (pk int primary key)", auth.ReadPerm, []statementAccess{write}},
+		{"ALTER TABLE people ADD COLUMN c int", auth.ReadPerm, []statementAccess{write}},
+		{"DROP TABLE people", auth.ReadPerm, []statementAccess{write}},
+		{"DROP TABLE prod.people", auth.ReadPerm, []statementAccess{write, {database: "prod", perm: readWrite}}},
+		{"CREATE DATABASE other", auth.ReadPerm, []statementAccess{{database: "other", perm: readWrite}}},
+		{"INSERT INTO people VALUES ('Jane Janeson', 30, true, '')", readWrite, []statementAccess{read, write}},
+		{"INSERT INTO prod.people SELECT * FROM scratch.people", readWrite, []statementAccess{
+			{database: "prod", perm: auth.ReadPerm},
+			{database: "prod", perm: readWrite},
+			{database: "scratch", perm: auth.ReadPerm},
+		}},
+		{"UPDATE prod.people SET age = 1 WHERE name IN (SELECT name FROM scratch.people)", readWrite, []statementAccess{
+			{database: "prod", perm: auth.ReadPerm},
+			{database: "prod", perm: readWrite},
+			{database: "scratch", perm: auth.ReadPerm},
+		}},
+		{"INSERT INTO dolt_branches (hash, name) VALUES ('abc', 'analyst/a'), ('def', 'analyst/b')", readWrite, []statementAccess{
+			read,
+			{perm: readWrite, branch: "analyst/a"},
+			{perm: readWrite, branch: "analyst/b"},
+		}},
+		{"INSERT INTO dolt_branches (name, hash) SELECT name, hash FROM dolt_branches", readWrite, []statementAccess{
+			read,
+			{perm: readWrite, everyBranch: true},
+		}},
+		{"UPDATE dolt_branches SET name = 'analyst/b' WHERE name = 'analyst/a'", readWrite, []statementAccess{
+			read,
+			{perm: readWrite, branch: "analyst/a"},
+			{perm: readWrite, branch: "analyst/b"},
+		}},
+		{"DELETE FROM dolt_branches WHERE name IN ('analyst/a', 'master') AND hash = 'abc'", readWrite, []statementAccess{
+			read,
+			{perm: readWrite, branch: "analyst/a"},
+			{perm: readWrite, branch: "master"},
+		}},
+		{"DELETE FROM dolt_branches WHERE name LIKE 'analyst/%'", readWrite, []statementAccess{
+			read,
+			{perm: readWrite, everyBranch: true},
+		}},
+		{"COMMIT", auth.ReadPerm, []statementAccess{write, read}},
+		{"SET @@prod_head = 'abc'", auth.ReadPerm, []statementAccess{{database: "prod", perm: readWrite}}},
+		{"SET SESSION prod_working = 'abc', @x = 1", auth.ReadPerm, []statementAccess{{database: "prod", perm: readWrite}}},
+		{"SET autocommit = 1", auth.ReadPerm, []statementAccess{read}},
+		{"not a statement", auth.ReadPerm, []statementAccess{read}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			ctx := sql.NewContext(context.Background(), sql.WithQuery(test.query))
+			assert.ElementsMatch(t, test.expected, statementAccesses(ctx, test.perm))
+		})
+	}
+}
+
+func TestPermissionsOnEveryBranch(t *testing.T) {
+	readWrite := auth.ReadPerm | auth.WritePerm
+	pu := privilegedUser{privileges: []branchPrivilege{
+		{database: "scratch", perm: readWrite},
+		{database: "prod", branch: "analyst/*", perm: readWrite},
+		{database: "prod", branch: "*", perm: auth.ReadPerm},
+	}}
+
+	assert.Equal(t, readWrite, pu.permissionsOnEveryBranch("scratch"))
+	assert.Equal(t, auth.ReadPerm, pu.permissionsOnEveryBranch("prod"))
+	assert.Equal(t, auth.Permission(0), pu.permissionsOnEveryBranch("other"))
+}
+
+func TestNewPrivilegeAuthErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		users []UserConfig
+	}{
+		{"empty name", []UserConfig{{Name: ""}}},
+		{"duplicate user", []UserConfig{{Name: "analyst"}, {Name: "analyst"}}},
+		{"unknown permission", []UserConfig{{Name: "analyst", Privileges: []PrivilegeConfig{{Permissions: []string{"read", "drop"}}}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewPrivilegeAuth(test.users, false)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,613 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+// runningServer is the state of a running sql-server which changes when its config is reloaded. It tracks the
+// databases loaded from the config and the dolt databases each session has, so that databases added to the config
+// can be attached to existing sessions, and databases removed from it can be detached once no session uses them.
+type runningServer struct {
+	ctx        context.Context
+	version    string
+	dEnv       *env.DoltEnv
+	engine     *sqle.Engine
+	listener   *limitListener
+	metrics    *serverMetrics
+	loadConfig func() (ServerConfig, error)
+
+	mu *sync.Mutex
+	// started is the config the server was started with. config is the last config loaded, which applies to new
+	// sessions.
+	started ServerConfig
+	config  ServerConfig
+	// databases are the dolt databases loaded from the config, keyed by their lowercased names
+	databases map[string]dsqle.Database
+	// detached are the databases which were removed from the config, but are still used by a session
+	detached map[string]bool
+	// revisions are the revision databases in the catalog, keyed by their lowercased names
+	revisions map[string]dsqle.RevisionDatabase
+	sessions  map[uint32]*serverSession
+}
+
+// serverSession is a session of a runningServer and the databases it has.
+type serverSession struct {
+	sess *dsqle.DoltSession
+	ir   *sql.IndexRegistry
+	vr   *sql.ViewRegistry
+	dbs  map[string]bool
+	// revisions are the lowercased names of the revision databases the session uses
+	revisions map[string]bool
+}
+
+func newRunningServer(ctx context.Context, version string, dEnv *env.DoltEnv, engine *sqle.Engine, serverConfig ServerConfig, dbs []dsqle.Database) *runningServer {
+	databases := make(map[string]dsqle.Database, len(dbs))
+	for _, db := range dbs {
+		databases[strings.ToLower(db.Name())] = db
+	}
+
+	return &runningServer{
+		ctx:     ctx,
+		version: version,
+		dEnv:    dEnv,
+		engine:  engine,
+		loadConfig: func() (ServerConfig, error) {
+			return serverConfig, nil
+		},
+		mu:        &sync.Mutex{},
+		started:   serverConfig,
+		config:    serverConfig,
+		databases: databases,
+		detached:  make(map[string]bool),
+		revisions: make(map[string]dsqle.RevisionDatabase),
+		sessions:  make(map[uint32]*serverSession),
+	}
+}
+
+// hasDatabase returns whether the database |name| can be selected by a connection. Databases which are waiting to be
+// detached, and their revisions, can not.
+func (rs *runningServer) hasDatabase(name string) bool {
+	baseName, _, _ := dsqle.SplitRevisionDbName(name)
+
+	rs.mu.Lock()
+	detached := rs.detached[strings.ToLower(baseName)]
+	rs.mu.Unlock()
+
+	return !detached && rs.engine.Catalog.HasDB(name)
+}
+
+// registerSession records a new session for the connection |connID|, and returns the databases it should be created
+// with and whether it should autocommit.
+func (rs *runningServer) registerSession(connID uint32) ([]dsqle.Database, bool) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	s := &serverSession{dbs: make(map[string]bool), revisions: make(map[string]bool)}
+	var dbs []dsqle.Database
+	for name, db := range rs.databases {
+		if !rs.detached[name] {
+			s.dbs[name] = true
+			dbs = append(dbs, db)
+		}
+	}
+
+	rs.sessions[connID] = s
+	return dbs, rs.config.AutoCommit()
+}
+
+// sessionCreated records the session created for the connection |connID|, which databases are attached to from then
+// on.
+func (rs *runningServer) sessionCreated(connID uint32, sess *dsqle.DoltSession, ir *sql.IndexRegistry, vr *sql.ViewRegistry) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if s, ok := rs.sessions[connID]; ok {
+		s.sess, s.ir, s.vr = sess, ir, vr
+	}
+}
+
+// sessionClosed forgets the session of the connection |connID| and removes the detached databases and the revision
+// databases no other session uses.
+func (rs *runningServer) sessionClosed(connID uint32) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	delete(rs.sessions, connID)
+	rs.removeDetached()
+	rs.removeRevisions()
+}
+
+// attachDatabases adds the databases loaded since the session of the connection |connID| was created to the session.
+// It is called before the commands of the connection, so the session is not in use.
+func (rs *runningServer) attachDatabases(connID uint32) error {
+	rs.mu.Lock()
+	s, ok := rs.sessions[connID]
+	if !ok || s.sess == nil {
+		rs.mu.Unlock()
+		return nil
+	}
+
+	var dbs []dsqle.Database
+	for name, db := range rs.databases {
+		if !rs.detached[name] && !s.dbs[name] {
+			s.dbs[name] = true
+			dbs = append(dbs, db)
+		}
+	}
+	rs.mu.Unlock()
+
+	if len(dbs) == 0 {
+		return nil
+	}
+
+	ctx := sql.NewContext(rs.ctx, sql.WithSession(s.sess), sql.WithIndexRegistry(s.ir), sql.WithViewRegistry(s.vr))
+	for _, db := range dbs {
+		err := s.sess.AddDB(ctx, db)
+
+		if err != nil {
+			return err
+		}
+
+		err = loadDatabase(ctx, db)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reload loads the config again and applies it. Databases added to the config are attached to every session, and
+// databases removed from it are detached once no session uses them. The log level, the maximum number of connections
+// and autocommit for new sessions are applied, and a warning is logged for changed settings which are only applied
+// when the server starts.
+func (rs *runningServer) reload() error {
+	cfg, err := rs.loadConfig()
+
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateConfig(cfg); err != nil {
+		return err
+	}
+
+	level, err := logrus.ParseLevel(cfg.LogLevel().String())
+
+	if err != nil {
+		return err
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if err = rs.reloadDatabases(cfg.DatabaseNamesAndPaths()); err != nil {
+		return err
+	}
+
+	logrus.SetLevel(level)
+	rs.listener.setLimit(cfg.MaxConnections())
+
+	for _, setting := range restartRequired(rs.started, cfg) {
+		logrus.Warnf("%s was changed, but it is only applied when the server starts", setting)
+	}
+
+	rs.config = cfg
+	logrus.Info("reloaded server config")
+	return nil
+}
+
+// reloadDatabases loads the databases of |namesAndPaths| which are not loaded yet and marks the loaded databases
+// missing from it as detached. A database keeps the path it was loaded from. rs.mu must be held.
+func (rs *runningServer) reloadDatabases(namesAndPaths []env.EnvNameAndPath) error {
+	if len(rs.started.DatabaseNamesAndPaths()) == 0 {
+		if len(namesAndPaths) > 0 {
+			logrus.Warn("databases were configured, but a server started for the repository in its working directory can not serve other databases until it restarts")
+		}
+
+		return nil
+	}
+
+	configured := make(map[string]bool)
+	var toLoad []env.EnvNameAndPath
+	for _, nameAndPath := range namesAndPaths {
+		name := strings.ToLower(nameAndPath.Name)
+		configured[name] = true
+
+		if _, ok := rs.databases[name]; ok {
+			delete(rs.detached, name)
+			continue
+		}
+
+		if rs.engine.Catalog.HasDB(nameAndPath.Name) {
+			return fmt.Errorf("cannot load database '%s': a database with that name already exists", nameAndPath.Name)
+		}
+
+		toLoad = append(toLoad, nameAndPath)
+	}
+
+	mrEnv, err := env.LoadMultiEnv(rs.ctx, env.GetCurrentUserHomeDir, rs.dEnv.FS, rs.version, toLoad...)
+
+	if err != nil {
+		return err
+	}
+
+	for _, db := range commands.CollectDBs(mrEnv, newDatabase) {
+		rs.engine.AddDatabase(db)
+		rs.databases[strings.ToLower(db.Name())] = db
+		logrus.Infof("attached database '%s'", db.Name())
+	}
+
+	for name, db := range rs.databases {
+		if !configured[name] && !rs.detached[name] {
+			rs.detached[name] = true
+			logrus.Infof("database '%s' will be detached once the sessions using it end", db.Name())
+		}
+	}
+
+	rs.removeDetached()
+	rs.removeRevisions()
+	return nil
+}
+
+// removeDetached removes the detached databases which no session uses from the catalog. rs.mu must be held.
+func (rs *runningServer) removeDetached() {
+	for name := range rs.detached {
+		inUse := false
+		for _, s := range rs.sessions {
+			if s.dbs[name] {
+				inUse = true
+				break
+			}
+		}
+
+		if inUse {
+			continue
+		}
+
+		db := rs.databases[name]
+		rs.engine.Catalog.RemoveDatabase(db.Name())
+		delete(rs.databases, name)
+		delete(rs.detached, name)
+		logrus.Infof("detached database '%s'", db.Name())
+	}
+}
+
+// restartRequired returns the settings which differ between |started| and |cfg|, but which are only applied when the
+// server starts.
+func restartRequired(started, cfg ServerConfig) []string {
+	var settings []string
+	check := func(setting string, changed bool) {
+		if changed {
+			settings = append(settings, setting)
+		}
+	}
+
+	check("listener.host", started.Host() != cfg.Host())
+	check("listener.port", started.Port() != cfg.Port())
+	check("listener.read_timeout_millis", started.ReadTimeout() != cfg.ReadTimeout())
+	check("listener.write_timeout_millis", started.WriteTimeout() != cfg.WriteTimeout())
+	check("listener.tls_key", started.TLSKey() != cfg.TLSKey())
+	check("listener.tls_cert", started.TLSCert() != cfg.TLSCert())
+	check("listener.tls_ca", started.TLSCA() != cfg.TLSCA())
+	check("listener.require_secure_transport", started.RequireSecureTransport() != cfg.RequireSecureTransport())
+	check("behavior.read_only", started.ReadOnly() != cfg.ReadOnly())
+	check("user", started.User() != cfg.User() || started.Password() != cfg.Password())
+	check("users", !reflect.DeepEqual(started.Users(), cfg.Users()))
+	check("performance.query_parallelism", started.QueryParallelism() != cfg.QueryParallelism())
+	check("metrics.host", started.MetricsHost() != cfg.MetricsHost())
+	check("metrics.port", started.MetricsPort() != cfg.MetricsPort())
+
+	return settings
+}
+
+// loadDatabase loads the working root of |db| into the session of |ctx| and registers its views and triggers.
+func loadDatabase(ctx *sql.Context, db dsqle.Database) error {
+	err := db.LoadRootFromRepoState(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := db.GetRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return dsqle.RegisterSchemaFragments(ctx, db, root)
+}
+
+// serverHandler is the mysql.Handler of a runningServer. It prepares the session of a connection before the
+// connection's next command, see runningServer.prepareConnection, and lets the runningServer know when a connection
+// closes.
+type serverHandler struct {
+	*server.Handler
+	rs *runningServer
+}
+
+var _ mysql.Handler = serverHandler{}
+
+// ComInitDB implements mysql.Handler.
+func (h serverHandler) ComInitDB(c *mysql.Conn, schemaName string) error {
+	if err := h.rs.prepareConnection(c.ConnectionID, schemaName); err != nil {
+		return err
+	}
+
+	return h.Handler.ComInitDB(c, schemaName)
+}
+
+// ComQuery implements mysql.Handler.
+func (h serverHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) (err error) {
+	start := time.Now()
+	defer func() {
+		h.rs.metrics.queryDone(query, start, err)
+	}()
+
+	if err = h.rs.prepareConnection(c.ConnectionID, referencedDatabases(query)...); err != nil {
+		return err
+	}
+
+	return h.Handler.ComQuery(c, query, callback)
+}
+
+// ComPrepare implements mysql.Handler.
+func (h serverHandler) ComPrepare(c *mysql.Conn, query string) ([]*query.Field, error) {
+	if err := h.rs.prepareConnection(c.ConnectionID, referencedDatabases(query)...); err != nil {
+		return nil, err
+	}
+
+	return h.Handler.ComPrepare(c, query)
+}
+
+// ComStmtExecute implements mysql.Handler.
+func (h serverHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) (err error) {
+	start := time.Now()
+	defer func() {
+		h.rs.metrics.queryDone(prepare.PrepareStmt, start, err)
+	}()
+
+	if err = h.rs.prepareConnection(c.ConnectionID, referencedDatabases(prepare.PrepareStmt)...); err != nil {
+		return err
+	}
+
+	return h.Handler.ComStmtExecute(c, prepare, callback)
+}
+
+// NewConnection implements mysql.Handler.
+func (h serverHandler) NewConnection(c *mysql.Conn) {
+	h.Handler.NewConnection(c)
+	h.rs.metrics.connectionOpened()
+}
+
+// ConnectionClosed implements mysql.Handler.
+func (h serverHandler) ConnectionClosed(c *mysql.Conn) {
+	h.Handler.ConnectionClosed(c)
+	h.rs.sessionClosed(c.ConnectionID)
+	h.rs.metrics.connectionClosed()
+}
+
+// limitListener is a net.Listener which does not accept connections while the number of connections it accepted that
+// are still open is at its limit. Unlike the limit of a mysql.Listener, the limit can be changed while the server is
+// running. A limit of 0 allows any number of connections.
+type limitListener struct {
+	net.Listener
+	cond   *sync.Cond
+	limit  uint64
+	open   uint64
+	closed bool
+}
+
+func newLimitListener(l net.Listener, limit uint64) *limitListener {
+	return &limitListener{Listener: l, cond: sync.NewCond(&sync.Mutex{}), limit: limit}
+}
+
+// Accept waits until the number of open connections is below the limit and then accepts the next connection.
+func (l *limitListener) Accept() (net.Conn, error) {
+	l.cond.L.Lock()
+	for !l.closed && l.limit > 0 && l.open >= l.limit {
+		l.cond.Wait()
+	}
+	l.open++
+	l.cond.L.Unlock()
+
+	conn, err := l.Listener.Accept()
+
+	if err != nil {
+		l.release()
+		return nil, err
+	}
+
+	return &limitConn{Conn: conn, once: &sync.Once{}, release: l.release}, nil
+}
+
+// Close closes the listener. An Accept waiting for connections to close returns an error.
+func (l *limitListener) Close() error {
+	l.cond.L.Lock()
+	l.closed = true
+	l.cond.Broadcast()
+	l.cond.L.Unlock()
+
+	return l.Listener.Close()
+}
+
+func (l *limitListener) setLimit(limit uint64) {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	l.limit = limit
+	l.cond.Broadcast()
+}
+
+func (l *limitListener) release() {
+	l.cond.L.Lock()
+	defer l.cond.L.Unlock()
+
+	l.open--
+	l.cond.Broadcast()
+}
+
+type limitConn struct {
+	net.Conn
+	once    *sync.Once
+	release func()
+}
+
+// Close closes the connection and makes room for another one.
+func (c *limitConn) Close() error {
+	c.once.Do(c.release)
+	return c.Conn.Close()
+}
+
+// reloadOnSignal reloads the config of |rs| whenever the process receives SIGHUP, until the returned function is
+// called.
+func reloadOnSignal(rs *runningServer) func() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		for range sigCh {
+			if err := rs.reload(); err != nil {
+				logrus.Errorf("failed to reload server config: %v", err)
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(sigCh)
+	}
+}
+
+// pidFilePath returns the path of the file holding the process id of the server listening on |port|, which
+// `dolt sql-server reload` signals.
+func pidFilePath(port int) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("dolt-sql-server-%d.pid", port))
+}
+
+// writePidFile writes the process id of this process to the pid file of |port|, and returns a function which removes
+// it. The pid file is in a shared directory, so a file left at its path, such as the pid file of a server which was
+// killed or a link planted by another user, is removed and the pid file is created anew, readable only by this user.
+func writePidFile(port int) (func(), error) {
+	path := pidFilePath(port)
+	err := os.Remove(path)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = f.WriteString(strconv.Itoa(os.Getpid()))
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return func() {
+		_ = os.Remove(path)
+	}, nil
+}
+
+// readPidFile returns the process id in the pid file of |port|. Only a regular file which no other user can write to
+// is trusted, as written by writePidFile.
+func readPidFile(port int) (int, error) {
+	path := pidFilePath(port)
+	info, err := os.Lstat(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0077 != 0 {
+		return 0, fmt.Errorf("pid file '%s' was not written by a server of this user", path)
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return 0, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid pid file '%s': %w", path, err)
+	}
+
+	return pid, nil
+}
+
+// signalReload sends SIGHUP to the server listening on |host| and |port|, which makes it reload its config. The pid
+// file of a server which was killed is left behind, so the server must accept connections for it to be signaled.
+func signalReload(host string, port int) error {
+	pid, err := readPidFile(port)
+
+	if err != nil {
+		return fmt.Errorf("no server is running on port %d: %w", port, err)
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), time.Second)
+
+	if err != nil {
+		return fmt.Errorf("no server is running on port %d: %w", port, err)
+	}
+
+	_ = conn.Close()
+
+	proc, err := os.FindProcess(pid)
+
+	if err != nil {
+		return err
+	}
+
+	return proc.Signal(syscall.SIGHUP)
+}
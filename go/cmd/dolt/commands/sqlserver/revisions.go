@@ -0,0 +1,189 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+// referencedDatabases returns the names of the databases which |query| selects or qualifies tables with, if it may
+// reference a revision database such as `mydb/feature-x`. Queries which can not be parsed reference none, the engine
+// reports their errors.
+func referencedDatabases(query string) []string {
+	if !strings.Contains(query, dsqle.RevisionDelimiter) {
+		return nil
+	}
+
+	stmt, err := sqlparser.Parse(query)
+
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	add := func(name string) {
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case sqlparser.TableName:
+			add(node.Qualifier.String())
+		case *sqlparser.Use:
+			add(node.DBName.String())
+		case *sqlparser.Show:
+			add(node.Database)
+			if node.ShowTablesOpt != nil {
+				add(node.ShowTablesOpt.DbName)
+			}
+		}
+
+		return true, nil
+	}, stmt)
+
+	return names
+}
+
+// prepareConnection readies the session of the connection |connID| for its next command, which references the
+// databases |dbNames|. It attaches the databases loaded by a reload, resolves the revision databases the command or
+// the current database of the session name, and refreshes the revision databases the session uses.
+func (rs *runningServer) prepareConnection(connID uint32, dbNames ...string) error {
+	if err := rs.attachDatabases(connID); err != nil {
+		return err
+	}
+
+	rs.mu.Lock()
+	if s, ok := rs.sessions[connID]; ok && s.sess != nil {
+		dbNames = append(dbNames, s.sess.GetCurrentDatabase())
+	}
+	rs.mu.Unlock()
+
+	var revNames []string
+	for _, name := range dbNames {
+		if _, _, ok := dsqle.SplitRevisionDbName(name); ok {
+			revNames = append(revNames, name)
+		}
+	}
+
+	if err := rs.addRevisionDatabases(connID, revNames); err != nil {
+		return err
+	}
+
+	return rs.refreshRevisionDatabases(connID)
+}
+
+// addRevisionDatabases resolves the revision databases named in |names| which are not in the catalog yet and adds
+// them to it, and records that the session of the connection |connID|, once it is created, uses them. Names of
+// revisions which do not exist are left for the engine to report.
+func (rs *runningServer) addRevisionDatabases(connID uint32, names []string) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	s := rs.sessions[connID]
+	for _, name := range names {
+		key := strings.ToLower(name)
+
+		if _, ok := rs.revisions[key]; ok {
+			if s != nil {
+				s.revisions[key] = true
+			}
+			continue
+		}
+
+		baseName, revision, _ := dsqle.SplitRevisionDbName(name)
+		baseKey := strings.ToLower(baseName)
+		db, ok := rs.databases[baseKey]
+
+		if !ok || rs.detached[baseKey] {
+			continue
+		}
+
+		revDb, err := dsqle.NewRevisionDatabase(rs.ctx, db, revision)
+
+		if sql.ErrDatabaseNotFound.Is(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		rs.engine.AddDatabase(revDb)
+		rs.revisions[key] = revDb
+		if s != nil {
+			s.revisions[key] = true
+		}
+		logrus.Debugf("resolved revision database '%s'", revDb.Name())
+	}
+
+	return nil
+}
+
+// refreshRevisionDatabases moves the session of the connection |connID| to the latest commit of the branches and tags
+// of the revision databases it uses. They can not be written, so the session has no changes to them to lose.
+func (rs *runningServer) refreshRevisionDatabases(connID uint32) error {
+	rs.mu.Lock()
+	s, ok := rs.sessions[connID]
+	if !ok || s.sess == nil || len(s.revisions) == 0 {
+		rs.mu.Unlock()
+		return nil
+	}
+
+	revDbs := make([]dsqle.RevisionDatabase, 0, len(s.revisions))
+	for name := range s.revisions {
+		if revDb, ok := rs.revisions[name]; ok {
+			revDbs = append(revDbs, revDb)
+		}
+	}
+	rs.mu.Unlock()
+
+	ctx := sql.NewContext(rs.ctx, sql.WithSession(s.sess), sql.WithIndexRegistry(s.ir), sql.WithViewRegistry(s.vr))
+	for _, revDb := range revDbs {
+		if err := revDb.Refresh(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeRevisions removes the revision databases which no session uses from the catalog, along with those of
+// databases which are no longer loaded. rs.mu must be held.
+func (rs *runningServer) removeRevisions() {
+	for name, revDb := range rs.revisions {
+		_, loaded := rs.databases[strings.ToLower(revDb.BaseName())]
+
+		inUse := false
+		for _, s := range rs.sessions {
+			if s.revisions[name] {
+				inUse = true
+				break
+			}
+		}
+
+		if loaded && inUse {
+			continue
+		}
+
+		rs.engine.Catalog.RemoveDatabase(revDb.Name())
+		delete(rs.revisions, name)
+	}
+}
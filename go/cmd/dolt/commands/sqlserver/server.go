@@ -16,6 +16,11 @@ package sqlserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net"
 	"strconv"
 	"time"
@@ -27,6 +32,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/information_schema"
 	"github.com/dolthub/vitess/go/mysql"
+	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -76,16 +82,34 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		logrus.SetLevel(level)
 	}
 
-	permissions := auth.AllPermissions
-	if serverConfig.ReadOnly() {
-		permissions = auth.ReadPerm
+	var userAuth auth.Auth
+	if users := serverConfig.Users(); len(users) > 0 {
+		var privAuth *PrivilegeAuth
+		privAuth, startError = NewPrivilegeAuth(users, serverConfig.ReadOnly())
+		if startError != nil {
+			return
+		}
+
+		userAuth = auth.NewAudit(privAuth, newAuditLog(logrus.StandardLogger()))
+	} else {
+		permissions := auth.AllPermissions
+		if serverConfig.ReadOnly() {
+			permissions = auth.ReadPerm
+		}
+
+		userAuth = auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), newAuditLog(logrus.StandardLogger()))
 	}
 
-	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), auth.NewAuditLog(logrus.StandardLogger()))
+	var tlsConfig *tls.Config
+	tlsConfig, startError = LoadTLSConfig(serverConfig)
+	if startError != nil {
+		cli.PrintErr(startError)
+		return
+	}
 
 	c := sql.NewCatalog()
 	a := analyzer.NewBuilder(c).WithParallelism(serverConfig.QueryParallelism()).Build()
-	sqlEngine := sqle.New(c, a, nil)
+	sqlEngine := sqle.New(c, a, &sqle.Config{Auth: userAuth})
 
 	err := sqlEngine.Catalog.Register(dfunctions.DoltFunctions...)
 
@@ -124,29 +148,46 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 
 	sqlEngine.AddDatabase(information_schema.NewInformationSchemaDatabase(sqlEngine.Catalog))
 
-	hostPort := net.JoinHostPort(serverConfig.Host(), strconv.Itoa(serverConfig.Port()))
-	readTimeout := time.Duration(serverConfig.ReadTimeout()) * time.Millisecond
-	writeTimeout := time.Duration(serverConfig.WriteTimeout()) * time.Millisecond
-	mySQLServer, startError = server.NewServer(
-		server.Config{
-			Protocol:         "tcp",
-			Address:          hostPort,
-			Auth:             userAuth,
-			ConnReadTimeout:  readTimeout,
-			ConnWriteTimeout: writeTimeout,
-			MaxConnections:   serverConfig.MaxConnections(),
-			// Do not set the value of Version.  Let it default to what go-mysql-server uses.  This should be equivalent
-			// to the value of mysql that we support.
-		},
-		sqlEngine,
-		newSessionBuilder(sqlEngine, username, email, serverConfig.AutoCommit()),
-	)
+	rs := newRunningServer(ctx, version, dEnv, sqlEngine, serverConfig, dbs)
+	if serverController.configLoader != nil {
+		rs.loadConfig = serverController.configLoader
+	}
+	metrics := newServerMetrics()
+	rs.metrics = metrics
+	metrics.registry.MustRegister(newDatabaseCollector(rs))
+
+	mySQLServer, startError = newMySQLServer(serverConfig, sqlEngine, userAuth, rs, newSessionBuilder(rs, username, email, serverConfig.RequireSecureTransport()))
 
 	if startError != nil {
 		cli.PrintErr(startError)
 		return
 	}
 
+	// clients upgrade their connections to TLS during the handshake of the MySQL protocol
+	mySQLServer.Listener.TLSConfig = tlsConfig
+	mySQLServer.Listener.RequireSecureTransport = serverConfig.RequireSecureTransport()
+
+	if serverConfig.MetricsPort() != defaultMetricsPort {
+		var stopMetrics func() error
+		stopMetrics, startError = metrics.serve(serverConfig.MetricsHost(), serverConfig.MetricsPort())
+		if startError != nil {
+			cli.PrintErr(startError)
+			return
+		}
+		defer stopMetrics()
+	}
+
+	removePidFile, err := writePidFile(serverConfig.Port())
+	if err != nil {
+		logrus.Warnf("failed to write pid file, `dolt sql-server reload` will not find this server: %v", err)
+	} else {
+		defer removePidFile()
+	}
+
+	stopReloading := reloadOnSignal(rs)
+	defer stopReloading()
+
+	serverController.registerReloadFunction(rs.reload)
 	serverController.registerCloseFunction(startError, mySQLServer.Close)
 	closeError = mySQLServer.Start()
 	if closeError != nil {
@@ -156,10 +197,91 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 	return
 }
 
-func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommit bool) server.SessionBuilder {
+// LoadTLSConfig returns the tls.Config the server uses for connections which upgrade to TLS, or nil if TLS is not
+// configured. If a certificate authority is configured clients must present a certificate signed by it.
+func LoadTLSConfig(serverConfig ServerConfig) (*tls.Config, error) {
+	if serverConfig.TLSKey() == "" && serverConfig.TLSCert() == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(serverConfig.TLSCert(), serverConfig.TLSKey())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls_cert '%s' and tls_key '%s': %w", serverConfig.TLSCert(), serverConfig.TLSKey(), err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if serverConfig.TLSCA() != "" {
+		data, err := ioutil.ReadFile(serverConfig.TLSCA())
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls_ca '%s': %w", serverConfig.TLSCA(), err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("tls_ca '%s' does not contain any PEM encoded certificates", serverConfig.TLSCA())
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// newMySQLServer creates the server for |sqlEngine|. Its connections are limited by a listener whose limit can be
+// changed while the server is running, and its handler attaches the databases loaded by a reload of |rs|.
+func newMySQLServer(serverConfig ServerConfig, sqlEngine *sqle.Engine, userAuth auth.Auth, rs *runningServer, sb server.SessionBuilder) (*server.Server, error) {
+	hostPort := net.JoinHostPort(serverConfig.Host(), strconv.Itoa(serverConfig.Port()))
+	readTimeout := time.Duration(serverConfig.ReadTimeout()) * time.Millisecond
+	writeTimeout := time.Duration(serverConfig.WriteTimeout()) * time.Millisecond
+
+	handler := server.NewHandler(
+		sqlEngine,
+		server.NewSessionManager(sb, opentracing.NoopTracer{}, rs.hasDatabase, sqlEngine.Catalog.MemoryManager, hostPort),
+		readTimeout)
+
+	l, err := server.NewListener("tcp", hostPort, handler)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rs.listener = newLimitListener(l, serverConfig.MaxConnections())
+	vtListener, err := mysql.NewListenerWithConfig(mysql.ListenerConfig{
+		Listener:           rs.listener,
+		AuthServer:         userAuth.Mysql(),
+		Handler:            serverHandler{Handler: handler, rs: rs},
+		ConnReadTimeout:    readTimeout,
+		ConnWriteTimeout:   writeTimeout,
+		ConnReadBufferSize: mysql.DefaultConnBufferSize,
+		// Do not set the value of ServerVersion.  Let it default to what go-mysql-server uses.  This should be
+		// equivalent to the value of mysql that we support.
+	})
+
+	if err != nil {
+		_ = rs.listener.Close()
+		return nil, err
+	}
+
+	return &server.Server{Listener: vtListener}, nil
+}
+
+func newSessionBuilder(rs *runningServer, username, email string, requireSecureTransport bool) server.SessionBuilder {
 	return func(ctx context.Context, conn *mysql.Conn, host string) (sql.Session, *sql.IndexRegistry, *sql.ViewRegistry, error) {
+		// The listener reports an error to clients which do not upgrade to TLS, but continues the handshake with them
+		if requireSecureTransport && conn.Capabilities&mysql.CapabilityClientSSL == 0 {
+			return nil, nil, nil, errors.New("server does not allow insecure connections, client must use SSL/TLS")
+		}
+
+		dbs, autocommit := rs.registerSession(conn.ConnectionID)
 		mysqlSess := sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
-		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())...)
+		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, dbs...)
 
 		if err != nil {
 			return nil, nil, nil, err
@@ -180,26 +302,15 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 			sql.WithSession(doltSess),
 			sql.WithTracer(tracing.Tracer(ctx)))
 
-		dbs := dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())
 		for _, db := range dbs {
-			err := db.LoadRootFromRepoState(sqlCtx)
+			err := loadDatabase(sqlCtx, db)
 			if err != nil {
-				return nil, nil, nil, err
-			}
-
-			root, err := db.GetRoot(sqlCtx)
-			if err != err {
 				cli.PrintErrln(err)
 				return nil, nil, nil, err
 			}
-
-			err = dsqle.RegisterSchemaFragments(sqlCtx, db, root)
-			if err != nil {
-				cli.PrintErr(err)
-				return nil, nil, nil, err
-			}
 		}
 
+		rs.sessionCreated(conn.ConnectionID, doltSess, ir, vr)
 		return doltSess, ir, vr, nil
 	}
 }
@@ -207,17 +318,3 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 func newDatabase(name string, dEnv *env.DoltEnv) dsqle.Database {
 	return dsqle.NewDatabase(name, dEnv.DbData())
 }
-
-func dbsAsDSQLDBs(dbs []sql.Database) []dsqle.Database {
-	dsqlDBs := make([]dsqle.Database, 0, len(dbs))
-
-	for _, db := range dbs {
-		dsqlDB, ok := db.(dsqle.Database)
-
-		if ok {
-			dsqlDBs = append(dsqlDBs, dsqlDB)
-		}
-	}
-
-	return dsqlDBs
-}
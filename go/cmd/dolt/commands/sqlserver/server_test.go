@@ -15,16 +15,36 @@
 package sqlserver
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/gocraft/dbr/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 type testPerson struct {
@@ -216,3 +236,692 @@ func TestServerSelect(t *testing.T) {
 		})
 	}
 }
+
+func TestServerPrivileges(t *testing.T) {
+	const yamlConfig = `
+log_level: fatal
+
+users:
+    - name: admin
+      password: secret
+      privileges:
+          - permissions: [read, write]
+    - name: analyst
+      password: password
+      privileges:
+          - branch: master
+            permissions: [read]
+          - branch: analyst/*
+            permissions: [read, write]
+
+listener:
+    host: localhost
+    port: 15301
+    max_connections: 10
+`
+	serverController := CreateServerController()
+	defer serverController.StopServer()
+	go func() {
+		dEnv := dtestutils.CreateEnvWithSeedData(t)
+		dEnv.FS.WriteFile("config.yaml", []byte(yamlConfig))
+		startServer(context.Background(), "test", "dolt sql-server", []string{
+			"--config", "config.yaml",
+		}, dEnv, serverController)
+	}()
+	err := serverController.WaitForStart()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	analystConn, err := dbr.Open("mysql", "analyst:password@tcp(localhost:15301)/dolt", nil)
+	require.NoError(t, err)
+	defer analystConn.Close()
+	analyst, err := analystConn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer analyst.Close()
+
+	_, err = analyst.ExecContext(ctx, "SELECT * FROM people")
+	assert.NoError(t, err)
+	_, err = analyst.ExecContext(ctx, "INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000004', 'Jane Janeson', 30, true)")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user 'analyst' does not have write permission on branch 'master' of database 'dolt'")
+	_, err = analyst.ExecContext(ctx, "CREATE TABLE t (pk int primary key)")
+	assert.Error(t, err)
+	_, err = analyst.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'commit on master')")
+	assert.Error(t, err)
+	_, err = analyst.ExecContext(ctx, "COMMIT")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user 'analyst' does not have write permission on branch 'master' of database 'dolt'")
+	_, err = analyst.ExecContext(ctx, "SET @@dolt_head = hashof('master')")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user 'analyst' does not have write permission on branch 'master' of database 'dolt'")
+	_, err = analyst.ExecContext(ctx, "SET @@dolt_working = @@dolt_working")
+	assert.Error(t, err)
+
+	_, err = analyst.ExecContext(ctx, "SELECT DOLT_CHECKOUT('-b', 'analyst/feature')")
+	require.NoError(t, err)
+	_, err = analyst.ExecContext(ctx, "INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000004', 'Jane Janeson', 30, true)")
+	assert.NoError(t, err)
+	_, err = analyst.ExecContext(ctx, "COMMIT")
+	assert.NoError(t, err)
+
+	// branches other than the checked out one can not be changed through dolt_branches or dolt_checkout
+	_, err = analyst.ExecContext(ctx, "UPDATE dolt_branches SET hash = hashof('analyst/feature') WHERE name = 'master'")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user 'analyst' does not have write permission on branch 'master' of database 'dolt'")
+	_, err = analyst.ExecContext(ctx, "DELETE FROM dolt_branches WHERE name = 'master'")
+	assert.Error(t, err)
+	_, err = analyst.ExecContext(ctx, "DELETE FROM dolt_branches WHERE name LIKE 'm%'")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user 'analyst' does not have read, write permission on every branch of database 'dolt'")
+	_, err = analyst.ExecContext(ctx, "SELECT DOLT_CHECKOUT('-b', 'other')")
+	assert.Error(t, err)
+	_, err = analyst.ExecContext(ctx, "INSERT INTO dolt_branches (name, hash) VALUES ('analyst/other', hashof('master'))")
+	assert.NoError(t, err)
+
+	adminConn, err := dbr.Open("mysql", "admin:secret@tcp(localhost:15301)/dolt", nil)
+	require.NoError(t, err)
+	defer adminConn.Close()
+	_, err = adminConn.Exec("CREATE TABLE t (pk int primary key)")
+	assert.NoError(t, err)
+
+	badConn, err := dbr.Open("mysql", "root:@tcp(localhost:15301)/dolt", nil)
+	require.NoError(t, err)
+	defer badConn.Close()
+	assert.Error(t, badConn.Ping())
+}
+
+type testCerts struct {
+	dir        string
+	caFile     string
+	certFile   string
+	keyFile    string
+	clientCert tls.Certificate
+}
+
+// writeTestCerts writes a certificate authority, and a certificate and key for localhost signed by it, to a temporary
+// directory. It also returns a client certificate signed by the authority.
+func writeTestCerts(t *testing.T) testCerts {
+	dir, err := ioutil.TempDir("", "sql_server_tls")
+	require.NoError(t, err)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "dolt test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	newCert := func(serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "localhost"},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	serverCert, serverKey := newCert(2, x509.ExtKeyUsageServerAuth)
+	clientCertPEM, clientKeyPEM := newCert(3, x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	require.NoError(t, err)
+
+	certs := testCerts{
+		dir:        dir,
+		caFile:     filepath.Join(dir, "ca.pem"),
+		certFile:   filepath.Join(dir, "cert.pem"),
+		keyFile:    filepath.Join(dir, "key.pem"),
+		clientCert: clientCert,
+	}
+	require.NoError(t, ioutil.WriteFile(certs.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600))
+	require.NoError(t, ioutil.WriteFile(certs.certFile, serverCert, 0600))
+	require.NoError(t, ioutil.WriteFile(certs.keyFile, serverKey, 0600))
+
+	return certs
+}
+
+func TestServerPrivilegesAcrossDatabases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestServerPrivilegesAcrossDatabases")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	prodPath := filepath.Join(dir, "prod")
+	scratchPath := filepath.Join(dir, "scratch")
+	initTestRepo(t, prodPath)
+	initTestRepo(t, scratchPath)
+
+	const yamlConfig = `
+log_level: fatal
+
+users:
+    - name: admin
+      password: secret
+      privileges:
+          - permissions: [read, write]
+    - name: analyst
+      password: password
+      privileges:
+          - database: scratch
+            permissions: [read, write]
+          - database: prod
+            permissions: [read]
+
+listener:
+    host: localhost
+    port: 15302
+    max_connections: 10
+
+databases:
+    - name: prod
+      path: %s
+    - name: scratch
+      path: %s
+`
+	dEnv := dtestutils.CreateTestEnv()
+	require.NoError(t, dEnv.FS.WriteFile("config.yaml", []byte(fmt.Sprintf(yamlConfig, prodPath, scratchPath))))
+
+	serverController := CreateServerController()
+	defer serverController.StopServer()
+	go func() {
+		startServer(context.Background(), "test", "dolt sql-server", []string{
+			"--config", "config.yaml",
+		}, dEnv, serverController)
+	}()
+	err = serverController.WaitForStart()
+	require.NoError(t, err)
+
+	adminConn, err := dbr.Open("mysql", "admin:secret@tcp(localhost:15302)/prod", nil)
+	require.NoError(t, err)
+	defer adminConn.Close()
+	_, err = adminConn.Exec("CREATE TABLE t (pk int primary key)")
+	require.NoError(t, err)
+	_, err = adminConn.Exec("INSERT INTO t VALUES (1)")
+	require.NoError(t, err)
+	scratchConn, err := dbr.Open("mysql", "admin:secret@tcp(localhost:15302)/scratch", nil)
+	require.NoError(t, err)
+	defer scratchConn.Close()
+	_, err = scratchConn.Exec("CREATE TABLE t (pk int primary key)")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	analystConn, err := dbr.Open("mysql", "analyst:password@tcp(localhost:15302)/scratch", nil)
+	require.NoError(t, err)
+	defer analystConn.Close()
+	analyst, err := analystConn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer analyst.Close()
+
+	_, err = analyst.ExecContext(ctx, "INSERT INTO scratch.t SELECT * FROM prod.t")
+	assert.NoError(t, err)
+
+	for _, query := range []string{
+		"INSERT INTO prod.t VALUES (2)",
+		"UPDATE prod.t SET pk = 3",
+		"DELETE FROM prod.t",
+		"INSERT INTO prod.t SELECT pk + 1 FROM scratch.t",
+		"DROP TABLE prod.t",
+		"CREATE TABLE prod.t2 (pk int primary key)",
+	} {
+		_, err = analyst.ExecContext(ctx, query)
+		require.Error(t, err, query)
+		assert.Contains(t, err.Error(), "user 'analyst' does not have write permission on branch 'master' of database 'prod'", query)
+	}
+
+	rows, err := adminConn.Query("SELECT pk FROM t")
+	require.NoError(t, err)
+	var pks []int
+	for rows.Next() {
+		var pk int
+		require.NoError(t, rows.Scan(&pk))
+		pks = append(pks, pk)
+	}
+	require.NoError(t, rows.Close())
+	assert.Equal(t, []int{1}, pks)
+}
+
+func TestServerTLS(t *testing.T) {
+	certs := writeTestCerts(t)
+	defer os.RemoveAll(certs.dir)
+
+	// the server certificate is only valid for localhost, and is signed by the test certificate authority
+	caData, err := ioutil.ReadFile(certs.caFile)
+	require.NoError(t, err)
+	caPool := x509.NewCertPool()
+	require.True(t, caPool.AppendCertsFromPEM(caData))
+	err = mysqlDriver.RegisterTLSConfig("dolt-test-ca", &tls.Config{RootCAs: caPool, ServerName: "localhost"})
+	require.NoError(t, err)
+	err = mysqlDriver.RegisterTLSConfig("dolt-client-cert", &tls.Config{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{certs.clientCert},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		listener   string
+		successful []string
+		failing    []string
+	}{
+		{
+			name:       "tls",
+			listener:   "",
+			successful: []string{"dolt-test-ca", "skip-verify", "false"},
+		},
+		{
+			name:       "require secure transport",
+			listener:   "require_secure_transport: true",
+			successful: []string{"skip-verify"},
+			failing:    []string{"false"},
+		},
+		{
+			name:       "client certificate authority",
+			listener:   "tls_ca: " + certs.caFile,
+			successful: []string{"dolt-client-cert"},
+			failing:    []string{"skip-verify"},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			port := 15310 + i
+			yamlConfig := fmt.Sprintf(`
+log_level: fatal
+
+user:
+    name: username
+    password: password
+
+listener:
+    host: localhost
+    port: %d
+    max_connections: 10
+    tls_key: %s
+    tls_cert: %s
+    %s
+`, port, certs.keyFile, certs.certFile, test.listener)
+
+			serverController := CreateServerController()
+			go func() {
+				dEnv := dtestutils.CreateEnvWithSeedData(t)
+				dEnv.FS.WriteFile("config.yaml", []byte(yamlConfig))
+				startServer(context.Background(), "test", "dolt sql-server", []string{
+					"--config", "config.yaml",
+				}, dEnv, serverController)
+			}()
+			err := serverController.WaitForStart()
+			require.NoError(t, err)
+
+			query := func(tlsParam string) error {
+				conn, err := dbr.Open("mysql", fmt.Sprintf("username:password@tcp(localhost:%d)/dolt?tls=%s", port, tlsParam), nil)
+				require.NoError(t, err)
+				defer conn.Close()
+				_, err = conn.Exec("SELECT * FROM people")
+				return err
+			}
+
+			for _, tlsParam := range test.successful {
+				assert.NoError(t, query(tlsParam), "tls=%s", tlsParam)
+			}
+			for _, tlsParam := range test.failing {
+				assert.Error(t, query(tlsParam), "tls=%s", tlsParam)
+			}
+
+			serverController.StopServer()
+			err = serverController.WaitForClose()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServerTLSBadConfig(t *testing.T) {
+	tests := []ServerConfig{
+		YAMLConfig{ListenerConfig: ListenerYAMLConfig{TLSKey: strPtr("key.pem")}},
+		YAMLConfig{ListenerConfig: ListenerYAMLConfig{TLSCert: strPtr("cert.pem")}},
+		YAMLConfig{ListenerConfig: ListenerYAMLConfig{TLSCA: strPtr("ca.pem")}},
+		YAMLConfig{ListenerConfig: ListenerYAMLConfig{RequireSecureTransport: boolPtr(true)}},
+	}
+
+	for _, test := range tests {
+		assert.Error(t, ValidateConfig(test))
+	}
+
+	_, err := LoadTLSConfig(YAMLConfig{ListenerConfig: ListenerYAMLConfig{TLSKey: strPtr("missing_key.pem"), TLSCert: strPtr("missing_cert.pem")}})
+	assert.Error(t, err)
+}
+
+// initTestRepo creates a dolt repository on disk at |path|.
+func initTestRepo(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(path, os.ModePerm))
+	fs, err := filesys.LocalFilesysWithWorkingDir(path)
+	require.NoError(t, err)
+
+	urlStr := earl.FileUrlFromPath(filepath.Join(path, dbfactory.DoltDataDir), os.PathSeparator)
+	dEnv := env.Load(context.Background(), env.GetCurrentUserHomeDir, fs, urlStr, "test")
+	err = dEnv.InitRepo(context.Background(), types.Format_7_18, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+}
+
+func TestServerReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestServerReload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db1Path := filepath.Join(dir, "db1")
+	db2Path := filepath.Join(dir, "db2")
+	initTestRepo(t, db1Path)
+	initTestRepo(t, db2Path)
+
+	const yamlConfig = `
+log_level: fatal
+
+listener:
+    host: localhost
+    port: 15320
+    max_connections: %d
+
+databases:
+    - name: %s
+      path: %s
+`
+	dEnv := dtestutils.CreateTestEnv()
+	require.NoError(t, dEnv.FS.WriteFile("config.yaml", []byte(fmt.Sprintf(yamlConfig, 1, "db1", db1Path))))
+
+	serverController := CreateServerController()
+	defer serverController.StopServer()
+	go func() {
+		startServer(context.Background(), "test", "dolt sql-server", []string{
+			"--config", "config.yaml",
+		}, dEnv, serverController)
+	}()
+	err = serverController.WaitForStart()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	conn1, err := dbr.Open("mysql", "root:@tcp(localhost:15320)/db1", nil)
+	require.NoError(t, err)
+	defer conn1.Close()
+	c1, err := conn1.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer c1.Close()
+	_, err = c1.ExecContext(ctx, "CREATE TABLE t1 (pk int primary key)")
+	require.NoError(t, err)
+
+	require.NoError(t, dEnv.FS.WriteFile("config.yaml", []byte(fmt.Sprintf(yamlConfig, 2, "db2", db2Path))))
+	require.NoError(t, serverController.ReloadServer())
+
+	// the connection which is open gets the added database, and keeps the removed one until it closes
+	_, err = c1.ExecContext(ctx, "USE db2")
+	require.NoError(t, err)
+	_, err = c1.ExecContext(ctx, "CREATE TABLE t2 (pk int primary key)")
+	require.NoError(t, err)
+	_, err = c1.ExecContext(ctx, "SELECT * FROM db1.t1")
+	require.NoError(t, err)
+
+	// the second connection is only accepted because the limit was raised
+	conn2, err := dbr.Open("mysql", "root:@tcp(localhost:15320)/db2?readTimeout=5s", nil)
+	require.NoError(t, err)
+	defer conn2.Close()
+	_, err = conn2.Exec("SELECT * FROM t2")
+	require.NoError(t, err)
+
+	require.NoError(t, c1.Close())
+	require.NoError(t, conn1.Close())
+
+	assert.Eventually(t, func() bool {
+		var dbs []string
+		_, err := conn2.NewSession(nil).Select("schema_name").From("information_schema.schemata").LoadContext(ctx, &dbs)
+		return err == nil && !contains(dbs, "db1") && contains(dbs, "db2")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, dEnv.FS.WriteFile("config.yaml", []byte(fmt.Sprintf(yamlConfig, 2, "db3", filepath.Join(dir, "db3")))))
+	assert.Error(t, serverController.ReloadServer())
+}
+
+func TestPidFile(t *testing.T) {
+	const port = 15399
+	path := pidFilePath(port)
+	defer os.Remove(path)
+
+	dir, err := ioutil.TempDir("", "TestPidFile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a link planted at the path of the pid file is replaced rather than followed
+	target := filepath.Join(dir, "target")
+	require.NoError(t, ioutil.WriteFile(target, []byte("target"), 0644))
+	_ = os.Remove(path)
+	require.NoError(t, os.Symlink(target, path))
+
+	removePidFile, err := writePidFile(port)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "target", string(data))
+
+	info, err := os.Lstat(path)
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	pid, err := readPidFile(port)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+
+	removePidFile()
+	_, err = readPidFile(port)
+	assert.True(t, os.IsNotExist(err))
+
+	// pid files which other users can write to aren't trusted
+	require.NoError(t, ioutil.WriteFile(path, []byte("1"), 0666))
+	require.NoError(t, os.Chmod(path, 0666))
+	_, err = readPidFile(port)
+	assert.Error(t, err)
+}
+
+func TestServerRevisionDatabases(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateEnvWithSeedData(t)
+	require.NoError(t, actions.StageAllTables(ctx, dEnv.DbData()))
+	_, err := actions.CommitStaged(ctx, dEnv.DbData(), actions.CommitStagedProps{Message: "add people", Date: time.Now(), Name: "Bill Billerson", Email: "bigbillieb@fake.horse"})
+	require.NoError(t, err)
+	require.NoError(t, actions.CreateBranchWithStartPt(ctx, dEnv.DbData(), "feature/x", "master", false))
+	require.NoError(t, actions.CreateTag(ctx, dEnv, "v1", "master", actions.TagProps{TaggerName: "Bill Billerson", TaggerEmail: "bigbillieb@fake.horse"}))
+
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15321).withMaxConnections(2)
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, dEnv)
+	}()
+	err = sc.WaitForStart()
+	require.NoError(t, err)
+
+	branchConn, err := dbr.Open("mysql", ConnectionString(serverConfig), nil)
+	require.NoError(t, err)
+	defer branchConn.Close()
+	branch, err := branchConn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer branch.Close()
+	_, err = branch.ExecContext(ctx, "USE `dolt/feature/x`")
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, branch.QueryRowContext(ctx, "SELECT COUNT(*) FROM people").Scan(&count))
+	assert.Equal(t, 3, count)
+	_, err = branch.ExecContext(ctx, "INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000004', 'Jane Janeson', 30, true)")
+	assert.Error(t, err)
+	_, err = branch.ExecContext(ctx, "CREATE TABLE t (pk int primary key)")
+	assert.Error(t, err)
+	_, err = branch.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'commit on a revision')")
+	assert.Error(t, err)
+
+	// revisions can't be changed by any function, nor by setting their heads
+	var head string
+	require.NoError(t, branch.QueryRowContext(ctx, "SELECT HASHOF('HEAD')").Scan(&head))
+	for _, query := range []string{
+		"SELECT DOLT_COMMIT('--allow-empty', '-m', 'empty commit on a revision')",
+		"SELECT DOLT_MERGE('master')",
+		"SELECT DOLT_CHECKOUT('master')",
+		"SET @@`dolt/feature/x_head` = COMMIT('--allow-empty', '-m', 'empty commit on a revision')",
+		"SET @@`dolt/feature/x_head` = MERGE('master')",
+	} {
+		_, err = branch.ExecContext(ctx, query)
+		assert.Error(t, err, query)
+	}
+	var newHead string
+	require.NoError(t, branch.QueryRowContext(ctx, "SELECT HASHOF('HEAD')").Scan(&newHead))
+	assert.Equal(t, head, newHead)
+
+	// commits to the branch are seen by the next statement
+	conn, err := dbr.Open("mysql", ConnectionString(serverConfig)+"dolt", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	c, err := conn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+	_, err = c.ExecContext(ctx, "SELECT DOLT_CHECKOUT('feature/x')")
+	require.NoError(t, err)
+	_, err = c.ExecContext(ctx, "INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000004', 'Jane Janeson', 30, true)")
+	require.NoError(t, err)
+	_, err = c.ExecContext(ctx, "SELECT DOLT_COMMIT('-a', '-m', 'add Jane')")
+	require.NoError(t, err)
+
+	require.NoError(t, branch.QueryRowContext(ctx, "SELECT COUNT(*) FROM people").Scan(&count))
+	assert.Equal(t, 4, count)
+
+	// other revisions can be referenced from any database
+	_, err = c.ExecContext(ctx, "SELECT DOLT_CHECKOUT('master')")
+	require.NoError(t, err)
+	require.NoError(t, c.QueryRowContext(ctx, "SELECT COUNT(*) FROM `dolt/v1`.people").Scan(&count))
+	assert.Equal(t, 3, count)
+	require.NoError(t, c.QueryRowContext(ctx, "SELECT COUNT(*) FROM `dolt/feature/x~`.people").Scan(&count))
+	assert.Equal(t, 3, count)
+	_, err = c.ExecContext(ctx, "USE `dolt/feature/x`")
+	require.NoError(t, err)
+	require.NoError(t, c.QueryRowContext(ctx, "SELECT COUNT(*) FROM people").Scan(&count))
+	assert.Equal(t, 4, count)
+
+	_, err = c.ExecContext(ctx, "SELECT * FROM `dolt/nonexistent`.people")
+	assert.Error(t, err)
+}
+
+func TestServerMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestServerMetrics")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, "db1")
+	initTestRepo(t, dbPath)
+
+	const yamlConfig = `
+log_level: fatal
+
+listener:
+    host: localhost
+    port: 15330
+
+databases:
+    - name: db1
+      path: %s
+
+metrics:
+    host: localhost
+    port: 15331
+`
+	dEnv := dtestutils.CreateTestEnv()
+	require.NoError(t, dEnv.FS.WriteFile("config.yaml", []byte(fmt.Sprintf(yamlConfig, dbPath))))
+
+	serverController := CreateServerController()
+	defer serverController.StopServer()
+	go func() {
+		startServer(context.Background(), "test", "dolt sql-server", []string{
+			"--config", "config.yaml",
+		}, dEnv, serverController)
+	}()
+	err = serverController.WaitForStart()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	conn, err := dbr.Open("mysql", "root:@tcp(localhost:15330)/db1", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	c, err := conn.DB.Conn(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+
+	for _, query := range []string{
+		"CREATE TABLE t (pk int primary key)",
+		"SELECT DOLT_COMMIT('-a', '--author', 'Bill Billerson <bigbillieb@fake.horse>', '-m', 'create t')",
+		"SELECT DOLT_CHECKOUT('-b', 'feature')",
+		"INSERT INTO t VALUES (1)",
+		"SELECT DOLT_COMMIT('-a', '--author', 'Bill Billerson <bigbillieb@fake.horse>', '-m', 'insert into t')",
+		"SELECT DOLT_CHECKOUT('master')",
+		"SELECT DOLT_MERGE('feature')",
+		"SELECT DOLT_CHECKOUT('feature')",
+		"INSERT INTO t VALUES (2)",
+		"SELECT DOLT_COMMIT('-a', '--author', 'Bill Billerson <bigbillieb@fake.horse>', '-m', 'insert into t again')",
+		"SELECT DOLT_CHECKOUT('master')",
+		// MERGE() only writes a dangling merge commit, which is not counted
+		"SELECT MERGE('feature')",
+		"SELECT DOLT_CHERRY_PICK('feature')",
+		"SELECT * FROM t",
+	} {
+		_, err = c.ExecContext(ctx, query)
+		require.NoError(t, err, query)
+	}
+	_, err = c.ExecContext(ctx, "SELECT * FROM nonexistent")
+	require.Error(t, err)
+
+	resp, err := http.Get("http://localhost:15331/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	metrics := make(map[string]string)
+	for _, line := range strings.Split(string(body), "\n") {
+		if idx := strings.LastIndex(line, " "); idx != -1 && !strings.HasPrefix(line, "#") {
+			metrics[line[:idx]] = line[idx+1:]
+		}
+	}
+
+	assert.Equal(t, "1", metrics["dolt_sql_server_connections"])
+	assert.Equal(t, "1", metrics["dolt_sql_server_connections_total"])
+	assert.Equal(t, "1", metrics[`dolt_sql_server_query_duration_seconds_count{statement="create"}`])
+	assert.Equal(t, "12", metrics[`dolt_sql_server_query_duration_seconds_count{statement="select"}`])
+	assert.Equal(t, "1", metrics[`dolt_sql_server_query_errors_total{statement="select"}`])
+	assert.Equal(t, "4", metrics[`dolt_commits_total{database="db1"}`])
+	assert.Equal(t, "1", metrics[`dolt_merges_total{database="db1"}`])
+	assert.Contains(t, metrics, `dolt_chunk_store_chunks{database="db1"}`)
+	assert.Contains(t, metrics, `dolt_chunk_store_physical_bytes{database="db1"}`)
+	assert.Contains(t, metrics, `dolt_chunk_store_chunk_gets_total{database="db1"}`)
+}
+
+func contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
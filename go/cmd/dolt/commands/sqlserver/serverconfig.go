@@ -44,6 +44,7 @@ const (
 	defaultAutoCommit       = true
 	defaultMaxConnections   = 1
 	defaultQueryParallelism = 2
+	defaultMetricsPort      = -1
 )
 
 // String returns the string representation of the log level.
@@ -94,6 +95,39 @@ type ServerConfig interface {
 	MaxConnections() uint64
 	// QueryParallelism returns the parallelism that should be used by the go-mysql-server analyzer
 	QueryParallelism() int
+	// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+	TLSKey() string
+	// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+	TLSCert() string
+	// TLSCA returns a path to the PEM-encoded certificate authorities client certificates are verified with. "" if
+	// client certificates are not required.
+	TLSCA() string
+	// RequireSecureTransport returns whether the server will turn away connections which do not use TLS.
+	RequireSecureTransport() bool
+	// Users returns the accounts clients can connect with and the privileges they are granted. If no users are
+	// returned clients connect with User and Password and are granted all permissions, or read only permissions.
+	Users() []UserConfig
+	// MetricsHost returns the domain that the Prometheus metrics endpoint listens on.
+	MetricsHost() string
+	// MetricsPort returns the port that the Prometheus metrics endpoint listens on, or -1 if it is disabled.
+	MetricsPort() int
+}
+
+// UserConfig defines an account that clients can connect with.
+type UserConfig struct {
+	Name     string
+	Password string
+	// Privileges are checked in order, and the first one matching the current database and branch of a session
+	// determines the permissions of the statements it runs. A statement matching no privilege is denied.
+	Privileges []PrivilegeConfig
+}
+
+// PrivilegeConfig grants permissions on the branches of the databases matching its patterns. A '*' in a pattern
+// matches any sequence of characters, and an empty pattern matches everything.
+type PrivilegeConfig struct {
+	Database    string
+	Branch      string
+	Permissions []string
 }
 
 type commandLineServerConfig struct {
@@ -165,6 +199,41 @@ func (cfg *commandLineServerConfig) QueryParallelism() int {
 	return cfg.queryParallelism
 }
 
+// TLSKey returns "", as TLS can only be configured in a config file.
+func (cfg *commandLineServerConfig) TLSKey() string {
+	return ""
+}
+
+// TLSCert returns "", as TLS can only be configured in a config file.
+func (cfg *commandLineServerConfig) TLSCert() string {
+	return ""
+}
+
+// TLSCA returns "", as TLS can only be configured in a config file.
+func (cfg *commandLineServerConfig) TLSCA() string {
+	return ""
+}
+
+// RequireSecureTransport returns false, as TLS can only be configured in a config file.
+func (cfg *commandLineServerConfig) RequireSecureTransport() bool {
+	return false
+}
+
+// Users returns nil, as users can only be defined in a config file.
+func (cfg *commandLineServerConfig) Users() []UserConfig {
+	return nil
+}
+
+// MetricsHost returns the default host, as metrics can only be configured in a config file.
+func (cfg *commandLineServerConfig) MetricsHost() string {
+	return defaultHost
+}
+
+// MetricsPort returns -1, as metrics can only be configured in a config file.
+func (cfg *commandLineServerConfig) MetricsPort() int {
+	return defaultMetricsPort
+}
+
 // DatabaseNamesAndPaths returns an array of env.EnvNameAndPathObjects corresponding to the databases to be loaded in
 // a multiple db configuration. If nil is returned the server will look for a database in the current directory and
 // give it a name automatically.
@@ -262,6 +331,26 @@ func ValidateConfig(config ServerConfig) error {
 	if len(config.User()) == 0 {
 		return fmt.Errorf("user cannot be empty")
 	}
+	if (config.TLSKey() == "") != (config.TLSCert() == "") {
+		return fmt.Errorf("tls_key and tls_cert must both be set to enable TLS")
+	}
+	if config.TLSKey() == "" && (config.TLSCA() != "" || config.RequireSecureTransport()) {
+		return fmt.Errorf("tls_key and tls_cert must be set to use tls_ca or require_secure_transport")
+	}
+	if err := validateUsers(config.Users()); err != nil {
+		return err
+	}
+	if config.MetricsPort() != defaultMetricsPort {
+		if config.MetricsHost() != "localhost" && net.ParseIP(config.MetricsHost()) == nil {
+			return fmt.Errorf("metrics address is not a valid IP: %v", config.MetricsHost())
+		}
+		if config.MetricsPort() < 1024 || config.MetricsPort() > 65535 {
+			return fmt.Errorf("metrics port is not in the range between 1024-65535: %v", config.MetricsPort())
+		}
+		if config.MetricsPort() == config.Port() {
+			return fmt.Errorf("metrics port cannot be the port of the server: %v", config.MetricsPort())
+		}
+	}
 	if config.LogLevel().String() == "unknown" {
 		return fmt.Errorf("loglevel is invalid: %v\n", string(config.LogLevel()))
 	}
@@ -15,6 +15,7 @@
 package sqlserver
 
 import (
+	"errors"
 	"sync"
 )
 
@@ -27,6 +28,8 @@ type ServerController struct {
 	closeRegistered *sync.Once
 	stopRegistered  *sync.Once
 	closeFunction   func() error
+	reloadFunction  func() error
+	configLoader    func() (ServerConfig, error)
 	startError      error
 	closeError      error
 }
@@ -55,6 +58,12 @@ func (controller *ServerController) registerCloseFunction(startError error, clos
 	})
 }
 
+// registerReloadFunction is called within `Serve` before the server starts to associate the reload function with
+// future `ReloadServer` calls.
+func (controller *ServerController) registerReloadFunction(reloadFunc func() error) {
+	controller.reloadFunction = reloadFunc
+}
+
 // serverStopped is called within `Serve` to signal that the server has stopped and set the exit code.
 // Only the first call will register and unblock, thus it is safe to be called multiple times.
 func (controller *ServerController) serverStopped(closeError error) {
@@ -78,6 +87,16 @@ func (controller *ServerController) StopServer() {
 	}
 }
 
+// ReloadServer loads the config of the server again and applies it without restarting the server. An error is
+// returned if the server has not started or the config could not be applied.
+func (controller *ServerController) ReloadServer() error {
+	if controller.reloadFunction == nil {
+		return errors.New("server has not started")
+	}
+
+	return controller.reloadFunction()
+}
+
 // WaitForClose blocks the caller until the server has closed. The return is the last error encountered, if any.
 func (controller *ServerController) WaitForClose() error {
 	select {
@@ -42,6 +42,7 @@ const (
 	noAutoCommitFlag     = "no-auto-commit"
 	configFileFlag       = "config"
 	queryParallelismFlag = "query-parallelism"
+	reloadCommand        = "reload"
 )
 
 var sqlServerDocs = cli.CommandDocumentationContent{
@@ -67,6 +68,20 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}user.password{{.EmphasisRight}} - The password that connections should use for authentication.
 
+		{{.EmphasisLeft}}users{{.EmphasisRight}} - a list of user accounts and the privileges they are granted. If users is given the user section is ignored and connections must use one of these accounts
+
+		{{.EmphasisLeft}}users[i].name{{.EmphasisRight}} - The username of the account
+
+		{{.EmphasisLeft}}users[i].password{{.EmphasisRight}} - The password of the account
+
+		{{.EmphasisLeft}}users[i].privileges{{.EmphasisRight}} - a list of privileges which are checked in order against every database a statement reads or writes and the branch checked out for it. Statements which change other branches, such as writes to {{.EmphasisLeft}}dolt_branches{{.EmphasisRight}} and {{.EmphasisLeft}}dolt_checkout('-b', ...){{.EmphasisRight}}, are checked against the branches they change. The first matching privilege determines whether a statement is allowed, and statements matching no privilege are denied and logged as warnings
+
+		{{.EmphasisLeft}}users[i].privileges[j].database{{.EmphasisRight}} - The databases the privilege applies to. A {{.EmphasisLeft}}*{{.EmphasisRight}} matches any sequence of characters, and an empty pattern matches every database
+
+		{{.EmphasisLeft}}users[i].privileges[j].branch{{.EmphasisRight}} - The branches the privilege applies to, such as {{.EmphasisLeft}}master{{.EmphasisRight}} or {{.EmphasisLeft}}analyst/*{{.EmphasisRight}}. An empty pattern matches every branch
+
+		{{.EmphasisLeft}}users[i].privileges[j].permissions{{.EmphasisRight}} - The permissions granted, {{.EmphasisLeft}}read{{.EmphasisRight}} and {{.EmphasisLeft}}write{{.EmphasisRight}}. Defaults to {{.EmphasisLeft}}read{{.EmphasisRight}}
+
 		{{.EmphasisLeft}}listener.host{{.EmphasisRight}} - The host address that the server will run on.  This may be {{.EmphasisLeft}}localhost{{.EmphasisRight}} or an IPv4 or IPv6 address
 
 		{{.EmphasisLeft}}listener.port{{.EmphasisRight}} - The port that the server should listen on
@@ -77,18 +92,35 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}listener.write_timeout_millis{{.EmphasisRight}} - The number of milliseconds that the server will wait for a write operation
 
+		{{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} - A path to an unencrypted private TLS key in PEM format. When it is set along with listener.tls_cert clients may upgrade their connections to TLS, such as with {{.EmphasisLeft}}--ssl-mode=REQUIRED{{.EmphasisRight}}
+
+		{{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} - A path to the TLS certificate chain of the server in PEM format
+
+		{{.EmphasisLeft}}listener.tls_ca{{.EmphasisRight}} - A path to PEM encoded certificate authorities. If set clients must present a TLS certificate signed by one of them
+
+		{{.EmphasisLeft}}listener.require_secure_transport{{.EmphasisRight}} - If true connections which do not use TLS are turned away
+
 		{{.EmphasisLeft}}performance.query_parallelism{{.EmphasisRight}} - Amount of go routines spawned to process each query
 
+		{{.EmphasisLeft}}metrics.host{{.EmphasisRight}} - The host address that the Prometheus metrics endpoint listens on. Defaults to {{.EmphasisLeft}}localhost{{.EmphasisRight}}
+
+		{{.EmphasisLeft}}metrics.port{{.EmphasisRight}} - The port of the Prometheus metrics endpoint. If set the server's connections, the counts and latencies of its queries by statement type, the counts of its commits and merges, and the chunk store metrics of its databases are served at {{.EmphasisLeft}}/metrics{{.EmphasisRight}}
+
 		{{.EmphasisLeft}}databases{{.EmphasisRight}} - a list of dolt data repositories to make available as SQL databases. If databases is missing or empty then the working directory must be a valid dolt data repository which will be made available as a SQL database
 		
 		{{.EmphasisLeft}}databases[i].path{{.EmphasisRight}} - A path to a dolt data repository
 		
 		{{.EmphasisLeft}}databases[i].name{{.EmphasisRight}} - The name that the database corresponding to the given path should be referenced via SQL
 
-If a config file is not provided many of these settings may be configured on the command line.`,
+If a config file is not provided many of these settings may be configured on the command line.
+
+A running server loads its config again when it receives SIGHUP, or when {{.EmphasisLeft}}dolt sql-server reload{{.EmphasisRight}} is run with the {{.EmphasisLeft}}--config <file>{{.EmphasisRight}}, or the {{.EmphasisLeft}}--host{{.EmphasisRight}} and {{.EmphasisLeft}}--port{{.EmphasisRight}}, the server was started with. Databases added to {{.EmphasisLeft}}databases{{.EmphasisRight}} or to the {{.EmphasisLeft}}--multi-db-dir{{.EmphasisRight}} directory are made available to every connection, and removed databases are dropped once the connections using them close. Changes to {{.EmphasisLeft}}log_level{{.EmphasisRight}}, {{.EmphasisLeft}}listener.max_connections{{.EmphasisRight}} and {{.EmphasisLeft}}behavior.autocommit{{.EmphasisRight}} are applied, the latter to new connections. Other settings are only applied when the server restarts.
+
+A branch, tag or commit of a database can be read without checking it out by qualifying the database name with it, as in {{.EmphasisLeft}}USE ` + "`mydb/feature-x`" + `{{.EmphasisRight}} or {{.EmphasisLeft}}SELECT * FROM ` + "`mydb/v1.0`" + `.tbl{{.EmphasisRight}}. These revision databases are read-only. A revision database of a branch or tag shows its latest commit at the start of each statement.`,
 	Synopsis: []string{
 		"--config {{.LessThan}}file{{.GreaterThan}}",
 		"[-H {{.LessThan}}host{{.GreaterThan}}] [-P {{.LessThan}}port{{.GreaterThan}}] [-u {{.LessThan}}user{{.GreaterThan}}] [-p {{.LessThan}}password{{.GreaterThan}}] [-t {{.LessThan}}timeout{{.GreaterThan}}] [-l {{.LessThan}}loglevel{{.GreaterThan}}] [--multi-db-dir {{.LessThan}}directory{{.GreaterThan}}] [--query-parallelism {{.LessThan}}num-go-routines{{.GreaterThan}}] [-r]",
+		"reload [--config {{.LessThan}}file{{.GreaterThan}}] [-H {{.LessThan}}host{{.GreaterThan}}] [-P {{.LessThan}}port{{.GreaterThan}}]",
 	},
 }
 
@@ -145,6 +177,10 @@ func (cmd SqlServerCmd) RequiresRepo() bool {
 
 // Exec executes the command
 func (cmd SqlServerCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	if len(args) > 0 && args[0] == reloadCommand {
+		return reloadServer(commandStr, args[1:], dEnv)
+	}
+
 	return startServer(ctx, cmd.VersionStr, commandStr, args, dEnv, nil)
 }
 
@@ -166,6 +202,14 @@ func startServer(ctx context.Context, versionStr, commandStr string, args []stri
 		return 1
 	}
 
+	if serverController == nil {
+		serverController = CreateServerController()
+	}
+
+	serverController.configLoader = func() (ServerConfig, error) {
+		return GetServerConfig(dEnv, apr)
+	}
+
 	cli.PrintErrf("Starting server with Config %v\n", ConfigInfo(serverConfig))
 
 	if startError, closeError := Serve(ctx, versionStr, serverConfig, serverController, dEnv); startError != nil || closeError != nil {
@@ -181,6 +225,45 @@ func startServer(ctx context.Context, versionStr, commandStr string, args []stri
 	return 0
 }
 
+// reloadServer makes the server started with the config file or port given in |args| reload its config.
+func reloadServer(commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := SqlServerCmd{}.CreateArgParser()
+	help, _ := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, sqlServerDocs, ap))
+
+	apr := cli.ParseArgs(ap, args, help)
+	host := DefaultServerConfig().Host()
+	port := DefaultServerConfig().Port()
+
+	if cfgFile, ok := apr.GetValue(configFileFlag); ok {
+		serverConfig, err := getYAMLServerConfig(dEnv.FS, cfgFile)
+
+		if err != nil {
+			cli.PrintErrln(color.RedString("Failed to reload server. Bad Configuration"))
+			cli.PrintErrln(err.Error())
+			return 1
+		}
+
+		host = serverConfig.Host()
+		port = serverConfig.Port()
+	} else {
+		if h, ok := apr.GetValue(hostFlag); ok {
+			host = h
+		}
+		if p, ok := apr.GetInt(portFlag); ok {
+			port = p
+		}
+	}
+
+	if err := signalReload(host, port); err != nil {
+		cli.PrintErrln(color.RedString("Failed to reload server on port %d", port))
+		cli.PrintErrln(err.Error())
+		return 1
+	}
+
+	cli.Printf("Reloading server on port %d\n", port)
+	return 0
+}
+
 func GetServerConfig(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (ServerConfig, error) {
 	cfgFile, ok := apr.GetValue(configFileFlag)
 
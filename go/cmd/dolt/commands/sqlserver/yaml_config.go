@@ -48,8 +48,16 @@ type BehaviorYAMLConfig struct {
 
 // UserYAMLConfig contains server configuration regarding the user account clients must use to connect
 type UserYAMLConfig struct {
-	Name     *string
-	Password *string
+	Name       *string
+	Password   *string
+	Privileges []PrivilegeYAMLConfig `yaml:"privileges,omitempty"`
+}
+
+// PrivilegeYAMLConfig contains the permissions a user is granted on the branches of the matching databases
+type PrivilegeYAMLConfig struct {
+	Database    string   `yaml:"database,omitempty"`
+	Branch      string   `yaml:"branch,omitempty"`
+	Permissions []string `yaml:"permissions"`
 }
 
 // DatabaseYAMLConfig contains information on a database that this server will provide access to
@@ -65,6 +73,20 @@ type ListenerYAMLConfig struct {
 	MaxConnections     *uint64 `yaml:"max_connections"`
 	ReadTimeoutMillis  *uint64 `yaml:"read_timeout_millis"`
 	WriteTimeoutMillis *uint64 `yaml:"write_timeout_millis"`
+	// TLSKey is a file system path to an unencrypted private TLS key in PEM format.
+	TLSKey *string `yaml:"tls_key,omitempty"`
+	// TLSCert is a file system path to a TLS certificate chain in PEM format.
+	TLSCert *string `yaml:"tls_cert,omitempty"`
+	// TLSCA is a file system path to the PEM encoded certificate authorities client certificates are verified with.
+	TLSCA *string `yaml:"tls_ca,omitempty"`
+	// RequireSecureTransport can enable a mode where non-TLS connections are turned away.
+	RequireSecureTransport *bool `yaml:"require_secure_transport,omitempty"`
+}
+
+// MetricsYAMLConfig contains the configuration of the HTTP endpoint which exposes the server's metrics to Prometheus
+type MetricsYAMLConfig struct {
+	HostStr    *string `yaml:"host,omitempty"`
+	PortNumber *int    `yaml:"port,omitempty"`
 }
 
 // PerformanceYAMLConfig contains configuration parameters for performance tweaking
@@ -77,9 +99,11 @@ type YAMLConfig struct {
 	LogLevelStr       *string               `yaml:"log_level"`
 	BehaviorConfig    BehaviorYAMLConfig    `yaml:"behavior"`
 	UserConfig        UserYAMLConfig        `yaml:"user"`
+	UsersConfig       []UserYAMLConfig      `yaml:"users,omitempty"`
 	ListenerConfig    ListenerYAMLConfig    `yaml:"listener"`
 	DatabaseConfig    []DatabaseYAMLConfig  `yaml:"databases"`
 	PerformanceConfig PerformanceYAMLConfig `yaml:"performance"`
+	MetricsConfig     MetricsYAMLConfig     `yaml:"metrics,omitempty"`
 }
 
 func newYamlConfig(configFileData []byte) (YAMLConfig, error) {
@@ -92,13 +116,13 @@ func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 	return YAMLConfig{
 		LogLevelStr:    strPtr(string(cfg.LogLevel())),
 		BehaviorConfig: BehaviorYAMLConfig{boolPtr(cfg.ReadOnly()), boolPtr(cfg.AutoCommit())},
-		UserConfig:     UserYAMLConfig{strPtr(cfg.User()), strPtr(cfg.Password())},
+		UserConfig:     UserYAMLConfig{Name: strPtr(cfg.User()), Password: strPtr(cfg.Password())},
 		ListenerConfig: ListenerYAMLConfig{
-			strPtr(cfg.Host()),
-			intPtr(cfg.Port()),
-			uint64Ptr(cfg.MaxConnections()),
-			uint64Ptr(cfg.ReadTimeout()),
-			uint64Ptr(cfg.WriteTimeout()),
+			HostStr:            strPtr(cfg.Host()),
+			PortNumber:         intPtr(cfg.Port()),
+			MaxConnections:     uint64Ptr(cfg.MaxConnections()),
+			ReadTimeoutMillis:  uint64Ptr(cfg.ReadTimeout()),
+			WriteTimeoutMillis: uint64Ptr(cfg.WriteTimeout()),
 		},
 		DatabaseConfig: nil,
 	}
@@ -172,6 +196,43 @@ func (cfg YAMLConfig) WriteTimeout() uint64 {
 	return *cfg.ListenerConfig.WriteTimeoutMillis
 }
 
+// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+func (cfg YAMLConfig) TLSKey() string {
+	if cfg.ListenerConfig.TLSKey == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSKey
+}
+
+// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+func (cfg YAMLConfig) TLSCert() string {
+	if cfg.ListenerConfig.TLSCert == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSCert
+}
+
+// TLSCA returns a path to the PEM-encoded certificate authorities client certificates are verified with. "" if
+// client certificates are not required.
+func (cfg YAMLConfig) TLSCA() string {
+	if cfg.ListenerConfig.TLSCA == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSCA
+}
+
+// RequireSecureTransport returns whether the server will turn away connections which do not use TLS.
+func (cfg YAMLConfig) RequireSecureTransport() bool {
+	if cfg.ListenerConfig.RequireSecureTransport == nil {
+		return false
+	}
+
+	return *cfg.ListenerConfig.RequireSecureTransport
+}
+
 // User returns the username that connecting clients must use.
 func (cfg YAMLConfig) User() string {
 	if cfg.UserConfig.Name == nil {
@@ -190,6 +251,33 @@ func (cfg YAMLConfig) Password() string {
 	return *cfg.UserConfig.Password
 }
 
+// Users returns the accounts clients can connect with and the privileges they are granted. If no users are
+// returned clients connect with User and Password and are granted all permissions, or read only permissions.
+func (cfg YAMLConfig) Users() []UserConfig {
+	var users []UserConfig
+	for _, userConfig := range cfg.UsersConfig {
+		user := UserConfig{}
+		if userConfig.Name != nil {
+			user.Name = *userConfig.Name
+		}
+		if userConfig.Password != nil {
+			user.Password = *userConfig.Password
+		}
+
+		for _, privConfig := range userConfig.Privileges {
+			user.Privileges = append(user.Privileges, PrivilegeConfig{
+				Database:    privConfig.Database,
+				Branch:      privConfig.Branch,
+				Permissions: privConfig.Permissions,
+			})
+		}
+
+		users = append(users, user)
+	}
+
+	return users
+}
+
 // ReadOnly returns whether the server will only accept read statements or all statements.
 func (cfg YAMLConfig) ReadOnly() bool {
 	if cfg.BehaviorConfig.ReadOnly == nil {
@@ -246,3 +334,21 @@ func (cfg YAMLConfig) QueryParallelism() int {
 
 	return *cfg.PerformanceConfig.QueryParallelism
 }
+
+// MetricsHost returns the domain that the Prometheus metrics endpoint listens on.
+func (cfg YAMLConfig) MetricsHost() string {
+	if cfg.MetricsConfig.HostStr == nil {
+		return defaultHost
+	}
+
+	return *cfg.MetricsConfig.HostStr
+}
+
+// MetricsPort returns the port that the Prometheus metrics endpoint listens on, or -1 if metrics.port is not set.
+func (cfg YAMLConfig) MetricsPort() int {
+	if cfg.MetricsConfig.PortNumber == nil {
+		return defaultMetricsPort
+	}
+
+	return *cfg.MetricsConfig.PortNumber
+}
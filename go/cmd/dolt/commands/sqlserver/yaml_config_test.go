@@ -111,4 +111,63 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultLogLevel, cfg.LogLevel())
 	assert.Equal(t, defaultAutoCommit, cfg.AutoCommit())
 	assert.Equal(t, uint64(defaultMaxConnections), cfg.MaxConnections())
+	assert.Equal(t, defaultHost, cfg.MetricsHost())
+	assert.Equal(t, defaultMetricsPort, cfg.MetricsPort())
+}
+
+func TestYAMLConfigMetrics(t *testing.T) {
+	cfg, err := newYamlConfig([]byte(`
+metrics:
+    host: 0.0.0.0
+    port: 9091
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0", cfg.MetricsHost())
+	assert.Equal(t, 9091, cfg.MetricsPort())
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg, err = newYamlConfig([]byte(`
+metrics:
+    port: 3306
+`))
+	require.NoError(t, err)
+	assert.Equal(t, defaultHost, cfg.MetricsHost())
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestYAMLConfigUsers(t *testing.T) {
+	testStr := `
+users:
+    - name: admin
+      password: secret
+      privileges:
+          - permissions: [read, write]
+    - name: analyst
+      privileges:
+          - database: mydb
+            branch: master
+            permissions: [read]
+          - branch: analyst/*
+            permissions: [read, write]
+`
+
+	config, err := newYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	assert.Equal(t, []UserConfig{
+		{
+			Name:       "admin",
+			Password:   "secret",
+			Privileges: []PrivilegeConfig{{Permissions: []string{"read", "write"}}},
+		},
+		{
+			Name: "analyst",
+			Privileges: []PrivilegeConfig{
+				{Database: "mydb", Branch: "master", Permissions: []string{"read"}},
+				{Branch: "analyst/*", Permissions: []string{"read", "write"}},
+			},
+		},
+	}, config.Users())
+
+	var defaults YAMLConfig
+	assert.Nil(t, defaults.Users())
 }
@@ -0,0 +1,279 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var stashDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Stash the changes in a dirty working set away",
+	LongDesc: `Use {{.EmphasisLeft}}dolt stash{{.EmphasisRight}} to record the current state of the working set and the staged tables, and go back to a clean working set. This includes new tables which have not been added yet. The stashed changes can be listed with {{.EmphasisLeft}}dolt stash list{{.EmphasisRight}} and restored, potentially on top of a different commit or branch, with {{.EmphasisLeft}}dolt stash pop{{.EmphasisRight}}.
+
+The most recently created stash is referred to as {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}}, the one before it as {{.EmphasisLeft}}stash@{1}{{.EmphasisRight}}, and so on. Commands which take a {{.LessThan}}stash{{.GreaterThan}} accept either form, or just the index.
+
+{{.EmphasisLeft}}push{{.EmphasisRight}}
+Save the local changes to a new stash entry and reset the working set and staged tables to HEAD. This is the default when no subcommand is given.
+
+{{.EmphasisLeft}}list{{.EmphasisRight}}
+List the stash entries, most recent first.
+
+{{.EmphasisLeft}}pop{{.EmphasisRight}}
+Apply the changes of a stash entry to the working set and remove it from the stash list. The changes are applied using a three-way merge in which the commit HEAD pointed to when the changes were stashed is used as the common ancestor. Staged changes are restored as staged. If applying either the working set changes or the staged changes results in conflicts, nothing is changed and the stash entry is kept. Defaults to {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}drop{{.EmphasisRight}}
+Remove a stash entry from the stash list. Defaults to {{.EmphasisLeft}}stash@{0}{{.EmphasisRight}}.
+`,
+	Synopsis: []string{
+		"[push]",
+		"list",
+		"pop [{{.LessThan}}stash{{.GreaterThan}}]",
+		"drop [{{.LessThan}}stash{{.GreaterThan}}]",
+	},
+}
+
+const (
+	pushStashId = "push"
+	listStashId = "list"
+	popStashId  = "pop"
+	dropStashId = "drop"
+)
+
+var stashNameRegex = regexp.MustCompile(`^stash@\{(\d+)\}$`)
+
+type StashCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd StashCmd) Name() string {
+	return "stash"
+}
+
+// Description returns a description of the command
+func (cmd StashCmd) Description() string {
+	return "Stash the changes in a dirty working set away."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd StashCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+}
+
+func (cmd StashCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"stash", "A stash entry, either in the form stash@{<n>} or as the index <n>."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd StashCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, stashDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	var verr errhand.VerboseError
+
+	switch {
+	case apr.NArg() == 0:
+		verr = pushStash(ctx, dEnv)
+	case apr.Arg(0) == pushStashId && apr.NArg() == 1:
+		verr = pushStash(ctx, dEnv)
+	case apr.Arg(0) == listStashId && apr.NArg() == 1:
+		verr = listStashes(ctx, dEnv)
+	case apr.Arg(0) == popStashId && apr.NArg() <= 2:
+		verr = popStash(ctx, dEnv, apr.Args()[1:])
+	case apr.Arg(0) == dropStashId && apr.NArg() <= 2:
+		verr = dropStash(ctx, dEnv, apr.Args()[1:])
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func pushStash(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	if verr := checkCanStash(ctx, dEnv, "stash"); verr != nil {
+		return verr
+	}
+
+	name, email, err := actions.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return errhand.BuildDError("error: could not determine the name and email of the user").AddCause(err).Build()
+	}
+
+	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv.DbData())
+	if err != nil {
+		return errhand.BuildDError("error: failed to determine unstaged docs").AddCause(err).Build()
+	}
+
+	stash, err := actions.StashChanges(ctx, dEnv.DbData(), name, email)
+	if err == actions.ErrNoLocalChanges {
+		cli.Println("No local changes to save")
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to stash changes").AddCause(err).Build()
+	}
+
+	err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	cli.Println("Saved working directory and index state", stash.Meta.Description)
+	return nil
+}
+
+func listStashes(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read the stash list").AddCause(err).Build()
+	}
+
+	for i, stash := range stashes {
+		cli.Printf("%s: %s\n", stashName(i), stash.Meta.Description)
+	}
+
+	return nil
+}
+
+func popStash(ctx context.Context, dEnv *env.DoltEnv, args []string) errhand.VerboseError {
+	if verr := checkCanStash(ctx, dEnv, "stash pop"); verr != nil {
+		return verr
+	}
+
+	stashes, idx, verr := resolveStash(ctx, dEnv, args)
+	if verr != nil {
+		return verr
+	}
+
+	unstagedDocs, err := actions.GetUnstagedDocs(ctx, dEnv.DbData())
+	if err != nil {
+		return errhand.BuildDError("error: failed to determine unstaged docs").AddCause(err).Build()
+	}
+
+	tblToStats, err := actions.ApplyStash(ctx, dEnv.DbData(), stashes[idx])
+	if err == actions.ErrStashConflicts {
+		printConflicts(tblToStats)
+		return errhand.BuildDError("error: applying %s results in conflicts. Nothing was changed and the stash entry is kept.", stashName(idx)).
+			AddDetails("Commit or reset the conflicting changes and pop the stash again.").Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to apply %s", stashName(idx)).AddCause(err).Build()
+	}
+
+	err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+	if err != nil {
+		return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+	}
+
+	return dropStashAt(ctx, dEnv, stashes, idx)
+}
+
+func dropStash(ctx context.Context, dEnv *env.DoltEnv, args []string) errhand.VerboseError {
+	stashes, idx, verr := resolveStash(ctx, dEnv, args)
+	if verr != nil {
+		return verr
+	}
+
+	return dropStashAt(ctx, dEnv, stashes, idx)
+}
+
+func dropStashAt(ctx context.Context, dEnv *env.DoltEnv, stashes []*actions.Stash, idx int) errhand.VerboseError {
+	h, err := stashes[idx].Commit.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: failed to get hash of commit").AddCause(err).Build()
+	}
+
+	err = actions.DropStash(ctx, dEnv.DoltDB, stashes[idx])
+	if err != nil {
+		return errhand.BuildDError("error: failed to drop %s", stashName(idx)).AddCause(err).Build()
+	}
+
+	cli.Printf("Dropped %s (%s)\n", stashName(idx), h.String())
+	return nil
+}
+
+// resolveStash returns the stash list along with the index of the entry named by |args|, which defaults to the most
+// recent entry.
+func resolveStash(ctx context.Context, dEnv *env.DoltEnv, args []string) ([]*actions.Stash, int, errhand.VerboseError) {
+	stashes, err := actions.GetStashes(ctx, dEnv.DoltDB)
+	if err != nil {
+		return nil, 0, errhand.BuildDError("error: failed to read the stash list").AddCause(err).Build()
+	}
+
+	if len(stashes) == 0 {
+		return nil, 0, errhand.BuildDError("error: No stash entries found.").Build()
+	}
+
+	idx := 0
+	if len(args) > 0 {
+		idxStr := args[0]
+		if matches := stashNameRegex.FindStringSubmatch(idxStr); matches != nil {
+			idxStr = matches[1]
+		}
+
+		idx, err = strconv.Atoi(idxStr)
+		if err != nil || idx < 0 {
+			return nil, 0, errhand.BuildDError("error: '%s' is not a valid stash reference", args[0]).Build()
+		}
+	}
+
+	if idx >= len(stashes) {
+		return nil, 0, errhand.BuildDError("error: %s does not exist", stashName(idx)).Build()
+	}
+
+	return stashes, idx, nil
+}
+
+// checkCanStash verifies that there are no unresolved conflicts and no active merge or rebase.
+func checkCanStash(ctx context.Context, dEnv *env.DoltEnv, operation string) errhand.VerboseError {
+	working, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return errhand.BuildDError("fatal: Unable to read from data repository.").AddCause(err).Build()
+	}
+
+	if has, err := working.HasConflicts(ctx); err != nil {
+		return errhand.BuildDError("error: failed to get conflicts").AddCause(err).Build()
+	} else if has {
+		return errhand.BuildDError("error: %s is not possible because you have unmerged tables.", operation).
+			AddDetails("hint: Fix them up in the work tree, and then use 'dolt add <table>'").
+			AddDetails("hint: as appropriate to mark resolution and make a commit.").Build()
+	}
+
+	if dEnv.IsMergeActive() {
+		return errhand.BuildDError("error: %s is not possible because you have not committed an active merge.", operation).
+			AddDetails("hint: add affected tables using 'dolt add <table>' and commit using 'dolt commit -m <msg>'").Build()
+	}
+
+	if dEnv.IsRebaseActive() {
+		return errhand.BuildDError("error: %s is not possible because a rebase is in progress.", operation).
+			AddDetails("hint: use 'dolt rebase --continue' or 'dolt rebase --abort' to finish the rebase").Build()
+	}
+
+	return nil
+}
+
+func stashName(idx int) string {
+	return fmt.Sprintf("stash@{%d}", idx)
+}
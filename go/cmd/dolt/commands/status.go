@@ -130,6 +130,8 @@ const (
 	stagedHeader     = `Changes to be committed:`
 	stagedHeaderHelp = `  (use "dolt reset <table>..." to unstage)`
 
+	mergingHeader = "You are merging %s (%s).\n"
+
 	unmergedTablesHeader = `You have unmerged tables.
   (fix conflicts and run "dolt commit")
   (use "dolt merge --abort" to abort the merge)
@@ -137,6 +139,12 @@ const (
 
 	allMergedHeader = `All conflicts fixed but you are still merging.
   (use "dolt commit" to conclude merge)
+`
+
+	rebaseHeader = `You are currently rebasing.
+  (fix conflicts, add them using "dolt add <table>" and run "dolt rebase --continue")
+  (use "dolt rebase --abort" to check out the original branch)
+
 `
 
 	mergedTableHeader = `Unmerged paths:`
@@ -149,9 +157,10 @@ const (
 	untrackedHeader     = `Untracked files:`
 	untrackedHeaderHelp = `  (use "dolt add <table|doc>" to include in what will be committed)`
 
-	statusFmt         = "\t%-16s%s"
-	statusRenameFmt   = "\t%-16s%s -> %s"
-	bothModifiedLabel = "both modified:"
+	statusFmt           = "\t%-16s%s"
+	statusRenameFmt     = "\t%-16s%s -> %s"
+	bothModifiedLabel   = "both modified:"
+	schemaConflictLabel = "schema changed:"
 )
 
 func printStagedDiffs(wr io.Writer, stagedTbls []diff.TableDelta, stagedDocs *diff.DocDiffs, printHelp bool) int {
@@ -204,9 +213,14 @@ func printDiffsNotStaged(ctx context.Context, dEnv *env.DoltEnv, wr io.Writer, n
 			iohelp.WriteLine(wr, mergedTableHelp)
 		}
 
+		schCnfSet, _ := tablesWithSchemaConflicts(ctx, dEnv)
 		lines := make([]string, 0, len(notStagedTbls))
 		for _, tblName := range workingTblsInConflict {
-			lines = append(lines, fmt.Sprintf(statusFmt, bothModifiedLabel, tblName))
+			if schCnfSet.Contains(tblName) {
+				lines = append(lines, fmt.Sprintf(statusFmt, schemaConflictLabel, tblName))
+			} else {
+				lines = append(lines, fmt.Sprintf(statusFmt, bothModifiedLabel, tblName))
+			}
 		}
 
 		iohelp.WriteLine(wr, color.RedString(strings.Join(lines, "\n")))
@@ -326,9 +340,12 @@ func getAddedNotStaged(notStagedTbls []diff.TableDelta, notStagedDocs *diff.DocD
 }
 
 func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTbls []diff.TableDelta, workingTblsInConflict []string, workingDocsInConflict *diff.DocDiffs, stagedDocs, notStagedDocs *diff.DocDiffs) {
-	cli.Printf(branchHeader, dEnv.RepoState.CWBHeadRef().GetPath())
+	rsr := dEnv.RepoStateReader()
+	cli.Printf(branchHeader, rsr.CWBHeadRef().GetPath())
+
+	if rsr.IsMergeActive() {
+		cli.Printf(mergingHeader, rsr.GetMergeCommitSpec(), rsr.GetMergeCommit())
 
-	if dEnv.RepoState.Merge != nil {
 		if len(workingTblsInConflict) > 0 {
 			cli.Println(unmergedTablesHeader)
 		} else {
@@ -336,10 +353,14 @@ func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTb
 		}
 	}
 
+	if dEnv.RepoState.Rebase != nil {
+		cli.Print(rebaseHeader)
+	}
+
 	n := printStagedDiffs(cli.CliOut, stagedTbls, stagedDocs, true)
 	n = printDiffsNotStaged(ctx, dEnv, cli.CliOut, notStagedTbls, notStagedDocs, true, n, workingTblsInConflict)
 
-	if dEnv.RepoState.Merge == nil && n == 0 {
+	if !rsr.IsMergeActive() && n == 0 {
 		cli.Println("nothing to commit, working tree clean")
 	}
 }
@@ -364,3 +385,19 @@ func docCnfsOnWorkingRoot(ctx context.Context, dEnv *env.DoltEnv) (bool, error)
 
 	return docTbl.HasConflicts()
 }
+
+func tablesWithSchemaConflicts(ctx context.Context, dEnv *env.DoltEnv) (*set.StrSet, error) {
+	root, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return set.NewStrSet(nil), err
+	}
+
+	tblNames, err := root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return set.NewStrSet(nil), err
+	}
+
+	return set.NewStrSet(tblNames), nil
+}
@@ -76,7 +76,7 @@ A mapping file can be used to map fields between the file being imported and the
 ` + schcmds.MappingFileHelp +
 
 		`
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, xlsx).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimeter`,
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimeter`,
 
 	Synopsis: []string{
 		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
@@ -191,7 +191,7 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		if val.Format == mvdata.XlsxFile {
 			// table name must match sheet name currently
 			srcOpts = mvdata.XlsxOptions{SheetName: tableName}
-		} else if val.Format == mvdata.JsonFile {
+		} else if val.Format == mvdata.JsonFile || val.Format == mvdata.JsonlFile {
 			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
 		}
 
@@ -203,6 +203,8 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 
 		if hasDelim {
 			srcOpts = mvdata.CsvOptions{Delim: delim}
+		} else if val.Format == mvdata.JsonlFile {
+			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
 		}
 	}
 
@@ -303,6 +305,16 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		if srcFileLoc.Format == mvdata.JsonFile && apr.Contains(createParam) && !hasSchema {
 			return errhand.BuildDError("Please specify schema file for .json tables.").Build()
 		}
+		if srcFileLoc.Format == mvdata.JsonlFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .jsonl tables.").Build()
+		}
+	}
+
+	if srcStreamLoc, isStream := srcLoc.(mvdata.StreamDataLocation); isStream {
+		_, hasSchema := apr.GetValue(schemaParam)
+		if srcStreamLoc.Format == mvdata.JsonlFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .jsonl tables.").Build()
+		}
 	}
 
 	return nil
@@ -63,10 +63,15 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	commands.DiffCmd{},
 	commands.BlameCmd{},
 	commands.MergeCmd{},
+	commands.CherryPickCmd{},
+	commands.RevertCmd{},
+	commands.RebaseCmd{},
+	commands.StashCmd{},
 	commands.BranchCmd{},
 	commands.TagCmd{},
 	commands.CheckoutCmd{},
 	commands.RemoteCmd{},
+	commands.BackupCmd{},
 	commands.PushCmd{},
 	commands.PullCmd{},
 	commands.FetchCmd{},
@@ -300,6 +305,7 @@ func commandNeedsMigrationCheck(args []string) bool {
 		commands.BranchCmd{},
 		commands.CheckoutCmd{},
 		commands.RemoteCmd{},
+		commands.BackupCmd{},
 		commands.PushCmd{},
 		commands.PullCmd{},
 		commands.FetchCmd{},
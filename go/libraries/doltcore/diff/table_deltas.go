@@ -464,7 +464,11 @@ func (td TableDelta) IsKeyless(ctx context.Context) (bool, error) {
 
 	from, to := schema.IsKeyless(f), schema.IsKeyless(t)
 
-	if from && to {
+	if td.IsAdd() {
+		return to, nil
+	} else if td.IsDrop() {
+		return from, nil
+	} else if from && to {
 		return true, nil
 	} else if !from && !to {
 		return false, nil
@@ -158,6 +158,9 @@ func (c *Commit) getParent(ctx context.Context, idx int) (*types.Struct, error)
 	if err != nil {
 		return nil, err
 	}
+	if targVal == nil {
+		return nil, missingCommitErr(ctx, c.vrw, parentRef.TargetHash())
+	}
 	parentSt := targVal.(types.Struct)
 	return &parentSt, nil
 }
@@ -209,6 +212,8 @@ func GetCommitAncestor(ctx context.Context, cm1, cm2 *Commit) (*Commit, error) {
 
 	if err != nil {
 		return nil, err
+	} else if targetVal == nil {
+		return nil, missingCommitErr(ctx, cm1.vrw, ref.TargetHash())
 	}
 
 	ancestorSt := targetVal.(types.Struct)
@@ -223,7 +228,16 @@ func GetCommitAncestor(ctx context.Context, cm1, cm2 *Commit) (*Commit, error) {
 func getCommitAncestorRef(ctx context.Context, ref1, ref2 types.Ref, vrw1, vrw2 types.ValueReadWriter) (types.Ref, error) {
 	ancestorRef, ok, err := datas.FindCommonAncestor(ctx, ref1, ref2, vrw1, vrw2)
 
-	if err != nil {
+	var notFound datas.CommitNotFoundError
+	if errors.As(err, &notFound) {
+		err = missingCommitErr(ctx, vrw1, notFound.Hash)
+
+		if errors.Is(err, ErrCommitMissing) && vrw2 != vrw1 {
+			err = missingCommitErr(ctx, vrw2, notFound.Hash)
+		}
+
+		return types.Ref{}, err
+	} else if err != nil {
 		return types.Ref{}, err
 	}
 
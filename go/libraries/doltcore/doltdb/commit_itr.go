@@ -16,7 +16,6 @@ package doltdb
 
 import (
 	"context"
-	"errors"
 	"io"
 
 	"github.com/dolthub/dolt/go/store/hash"
@@ -122,23 +121,24 @@ func (cmItr *commitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
 		}
 	}
 
-	numUnprocessed := len(cmItr.unprocessed)
+	for len(cmItr.unprocessed) > 0 {
+		numUnprocessed := len(cmItr.unprocessed)
+		next := cmItr.unprocessed[numUnprocessed-1]
+		cmItr.unprocessed = cmItr.unprocessed[:numUnprocessed-1]
+		cmItr.curr, err = hashToCommit(ctx, cmItr.ddb.ValueReadWriter(), next)
 
-	if numUnprocessed == 0 {
-		cmItr.curr = nil
-		cmItr.currentRoot++
-		return cmItr.Next(ctx)
-	}
-
-	next := cmItr.unprocessed[numUnprocessed-1]
-	cmItr.unprocessed = cmItr.unprocessed[:numUnprocessed-1]
-	cmItr.curr, err = hashToCommit(ctx, cmItr.ddb.ValueReadWriter(), next)
+		if err == ErrShallowBoundary {
+			continue
+		} else if err != nil {
+			return hash.Hash{}, nil, err
+		}
 
-	if err != nil {
-		return hash.Hash{}, nil, err
+		return next, cmItr.curr, nil
 	}
 
-	return next, cmItr.curr, nil
+	cmItr.curr = nil
+	cmItr.currentRoot++
+	return cmItr.Next(ctx)
 }
 
 func hashToCommit(ctx context.Context, vrw types.ValueReadWriter, h hash.Hash) (*Commit, error) {
@@ -149,7 +149,7 @@ func hashToCommit(ctx context.Context, vrw types.ValueReadWriter, h hash.Hash) (
 	}
 
 	if val == nil {
-		return nil, errors.New("failed to get commit")
+		return nil, missingCommitErr(ctx, vrw, h)
 	}
 
 	cmSt := val.(types.Struct)
@@ -20,6 +20,9 @@ type Conflict struct {
 	Base       types.Value
 	Value      types.Value
 	MergeValue types.Value
+	// EditedValue is our value of a conflicted row as edited in the conflicts table, or nil if it has not been edited.
+	// It is written to the table when the conflict is resolved.
+	EditedValue types.Value
 }
 
 func NewConflict(base, value, mergeValue types.Value) Conflict {
@@ -32,7 +35,7 @@ func NewConflict(base, value, mergeValue types.Value) Conflict {
 	if mergeValue == nil {
 		mergeValue = types.NullValue
 	}
-	return Conflict{base, value, mergeValue}
+	return Conflict{Base: base, Value: value, MergeValue: mergeValue}
 }
 
 func ConflictFromTuple(tpl types.Tuple) (Conflict, error) {
@@ -53,9 +56,35 @@ func ConflictFromTuple(tpl types.Tuple) (Conflict, error) {
 	if err != nil {
 		return Conflict{}, err
 	}
-	return Conflict{base, val, mv}, nil
+
+	var edited types.Value
+	if tpl.Len() > 3 {
+		edited, err = tpl.Get(3)
+
+		if err != nil {
+			return Conflict{}, err
+		}
+	}
+
+	return Conflict{Base: base, Value: val, MergeValue: mv, EditedValue: edited}, nil
+}
+
+// IsEdited returns whether our value of the conflict has been edited.
+func (c Conflict) IsEdited() bool {
+	return c.EditedValue != nil && !types.IsNull(c.EditedValue)
+}
+
+// OurValue returns our value of the conflict, as edited if it has been edited.
+func (c Conflict) OurValue() types.Value {
+	if c.IsEdited() {
+		return c.EditedValue
+	}
+	return c.Value
 }
 
 func (c Conflict) ToNomsList(vrw types.ValueReadWriter) (types.Tuple, error) {
+	if c.IsEdited() {
+		return types.NewTuple(vrw.Format(), c.Base, c.Value, c.MergeValue, c.EditedValue)
+	}
 	return types.NewTuple(vrw.Format(), c.Base, c.Value, c.MergeValue)
 }
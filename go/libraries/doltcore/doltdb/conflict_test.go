@@ -0,0 +1,49 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestConflictToAndFromNomsList(t *testing.T) {
+	vrw := types.NewMemoryValueStore()
+	cnf := NewConflict(types.String("base"), nil, types.String("theirs"))
+
+	tpl, err := cnf.ToNomsList(vrw)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), tpl.Len())
+
+	result, err := ConflictFromTuple(tpl)
+	require.NoError(t, err)
+	assert.False(t, result.IsEdited())
+	assert.Equal(t, types.NullValue, result.OurValue())
+	assert.Equal(t, cnf.MergeValue, result.MergeValue)
+
+	result.EditedValue = types.String("edited")
+	tpl, err = result.ToNomsList(vrw)
+	require.NoError(t, err)
+
+	result, err = ConflictFromTuple(tpl)
+	require.NoError(t, err)
+	assert.True(t, result.IsEdited())
+	assert.Equal(t, types.NullValue, result.Value)
+	assert.Equal(t, types.String("edited"), result.OurValue())
+}
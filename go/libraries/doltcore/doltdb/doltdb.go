@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
@@ -57,14 +58,51 @@ var InMemDoltDB = "mem://"
 // Additionally the noms codebase uses panics in a way that is non idiomatic and We've opted to recover and return
 // errors in many cases.
 type DoltDB struct {
-	db datas.Database
+	db      datas.Database
+	updates *branchUpdateCounts
 }
 
 // DoltDBFromCS creates a DoltDB from a noms chunks.ChunkStore
 func DoltDBFromCS(cs chunks.ChunkStore) *DoltDB {
 	db := datas.NewDatabase(cs)
 
-	return &DoltDB{db}
+	return &DoltDB{db, &branchUpdateCounts{}}
+}
+
+// ReadOnlyDoltDB returns a DoltDB for the same database as |ddb| which refuses every change to its branches, tags and
+// other refs with |err|. Values, including dangling commits, can still be written.
+func ReadOnlyDoltDB(ddb *DoltDB, err error) *DoltDB {
+	return &DoltDB{readOnlyDatabase{ddb.db, err}, &branchUpdateCounts{}}
+}
+
+// readOnlyDatabase is a datas.Database whose datasets can not be changed.
+type readOnlyDatabase struct {
+	datas.Database
+	err error
+}
+
+func (db readOnlyDatabase) Commit(ctx context.Context, ds datas.Dataset, v types.Value, opts datas.CommitOptions) (datas.Dataset, error) {
+	return datas.Dataset{}, db.err
+}
+
+func (db readOnlyDatabase) CommitValue(ctx context.Context, ds datas.Dataset, v types.Value) (datas.Dataset, error) {
+	return datas.Dataset{}, db.err
+}
+
+func (db readOnlyDatabase) Tag(ctx context.Context, ds datas.Dataset, ref types.Ref, opts datas.TagOptions) (datas.Dataset, error) {
+	return datas.Dataset{}, db.err
+}
+
+func (db readOnlyDatabase) Delete(ctx context.Context, ds datas.Dataset) (datas.Dataset, error) {
+	return datas.Dataset{}, db.err
+}
+
+func (db readOnlyDatabase) SetHead(ctx context.Context, ds datas.Dataset, newHeadRef types.Ref) (datas.Dataset, error) {
+	return datas.Dataset{}, db.err
+}
+
+func (db readOnlyDatabase) FastForward(ctx context.Context, ds datas.Dataset, newHeadRef types.Ref) (datas.Dataset, error) {
+	return datas.Dataset{}, db.err
 }
 
 // LoadDoltDB will acquire a reference to the underlying noms db.  If the Location is InMemDoltDB then a reference
@@ -91,13 +129,87 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		return nil, err
 	}
 
-	return &DoltDB{db}, nil
+	return &DoltDB{db, &branchUpdateCounts{}}, nil
 }
 
 func (ddb *DoltDB) CSMetricsSummary() string {
 	return datas.GetCSStatSummaryForDB(ddb.db)
 }
 
+// CSMetrics are the metrics of the chunk store of a DoltDB which CSMetricsSummary reports.
+type CSMetrics struct {
+	// ChunkGets, ChunkHasChecks and ChunkPuts are the numbers of chunks requested from, checked for in, and written
+	// to the chunk store since it was opened.
+	ChunkGets      int32
+	ChunkHasChecks int32
+	ChunkPuts      int32
+	// ChunkCount and PhysicalBytes are the number of chunks in the table files of the chunk store and their size.
+	ChunkCount    uint32
+	PhysicalBytes uint64
+}
+
+// tableFileSizer is implemented by the chunk stores which report the number and size of their chunks.
+type tableFileSizer interface {
+	ChunkCount() (uint32, error)
+	PhysicalLen() (uint64, error)
+}
+
+// CSMetrics returns the metrics of the chunk store of the database. The returned bool is false if the chunk store
+// does not collect them, as remote chunk stores do not.
+func (ddb *DoltDB) CSMetrics() (CSMetrics, bool, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	stats, ok := cs.Stats().(chunks.CSMetrics)
+
+	if !ok {
+		return CSMetrics{}, false, nil
+	}
+
+	metrics := CSMetrics{
+		ChunkGets:      stats.TotalChunkGets,
+		ChunkHasChecks: stats.TotalChunkHasChecks,
+		ChunkPuts:      stats.TotalChunkPuts,
+	}
+
+	if sizer, ok := cs.(tableFileSizer); ok {
+		var err error
+		metrics.ChunkCount, err = sizer.ChunkCount()
+
+		if err != nil {
+			return CSMetrics{}, false, err
+		}
+
+		metrics.PhysicalBytes, err = sizer.PhysicalLen()
+
+		if err != nil {
+			return CSMetrics{}, false, err
+		}
+	}
+
+	return metrics, true, nil
+}
+
+// BranchUpdates are the numbers of commits and merges written to the branches of a DoltDB since it was loaded.
+type BranchUpdates struct {
+	// Commits is the number of commits written to branches, including merge commits. Dangling commits, which no
+	// branch points at, are not counted.
+	Commits uint64
+	// Merges is the number of merges completed on branches, either by writing a merge commit or by fast-forwarding.
+	Merges uint64
+}
+
+type branchUpdateCounts struct {
+	commits uint64
+	merges  uint64
+}
+
+// BranchUpdates returns the numbers of commits and merges written to the branches of the database.
+func (ddb *DoltDB) BranchUpdates() BranchUpdates {
+	return BranchUpdates{
+		Commits: atomic.LoadUint64(&ddb.updates.commits),
+		Merges:  atomic.LoadUint64(&ddb.updates.merges),
+	}
+}
+
 // WriteEmptyRepo will create initialize the given db with a master branch which points to a commit which has valid
 // metadata for the creation commit, and an empty RootValue.
 func (ddb *DoltDB) WriteEmptyRepo(ctx context.Context, name, email string) error {
@@ -302,6 +414,10 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 	switch cs.csType {
 	case hashCommitSpec:
 		commitSt, err = getCommitStForHash(ctx, ddb.db, cs.baseSpec)
+
+		if err == ErrHashNotFound {
+			err = ddb.shallowBoundaryErr(ctx, cs.baseSpec)
+		}
 	case refCommitSpec:
 		// For a ref in a CommitSpec, we have the following behavior.
 		// If it starts with `refs/`, we look for an exact match before
@@ -454,7 +570,15 @@ func (ddb *DoltDB) FastForward(ctx context.Context, branch ref.DoltRef, commit *
 
 	_, err = ddb.db.FastForward(ctx, ds, rf)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	if branch.GetType() == ref.BranchRefType {
+		atomic.AddUint64(&ddb.updates.merges, 1)
+	}
+
+	return nil
 }
 
 // CanFastForward returns whether the given branch can be fast-forwarded to the commit given.
@@ -587,6 +711,14 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 		return nil, errors.New("commit has no head but commit succeeded (How?!?!?)")
 	}
 
+	if dref.GetType() == ref.BranchRefType {
+		atomic.AddUint64(&ddb.updates.commits, 1)
+
+		if parents.Len() > 1 {
+			atomic.AddUint64(&ddb.updates.merges, 1)
+		}
+	}
+
 	return NewCommit(ddb.db, commitSt), nil
 }
 
@@ -729,6 +861,13 @@ func (ddb *DoltDB) GetWorkspaces(ctx context.Context) ([]ref.DoltRef, error) {
 	return ddb.GetRefsOfType(ctx, workspacesRefFilter)
 }
 
+var stashesRefFilter = map[ref.RefType]struct{}{ref.StashRefType: {}}
+
+// GetStashes returns a list of all stash entries in the database.
+func (ddb *DoltDB) GetStashes(ctx context.Context) ([]ref.DoltRef, error) {
+	return ddb.GetRefsOfType(ctx, stashesRefFilter)
+}
+
 // GetRefs returns a list of all refs in the database.
 func (ddb *DoltDB) GetRefs(ctx context.Context) ([]ref.DoltRef, error) {
 	return ddb.GetRefsOfType(ctx, ref.RefTypes)
@@ -884,6 +1023,33 @@ func (ddb *DoltDB) DeleteWorkspace(ctx context.Context, workRef ref.DoltRef) err
 	return err
 }
 
+// NewStashAtCommit creates a new stash entry pointing at the commit given.
+func (ddb *DoltDB) NewStashAtCommit(ctx context.Context, stashRef ref.DoltRef, c *Commit) error {
+	ds, err := ddb.db.GetDataset(ctx, stashRef.String())
+	if err != nil {
+		return err
+	}
+
+	r, err := types.NewRef(c.commitSt, ddb.Format())
+	if err != nil {
+		return err
+	}
+
+	_, err = ddb.db.SetHead(ctx, ds, r)
+
+	return err
+}
+
+func (ddb *DoltDB) DeleteStash(ctx context.Context, stashRef ref.DoltRef) error {
+	err := ddb.deleteRef(ctx, stashRef)
+
+	if err == ErrBranchNotFound {
+		return ErrStashNotFound
+	}
+
+	return err
+}
+
 // GC performs garbage collection on this ddb. Values passed in |uncommitedVals| will be temporarily saved during gc.
 func (ddb *DoltDB) GC(ctx context.Context, uncommitedVals ...hash.Hash) error {
 	collector, ok := ddb.db.(datas.GarbageCollector)
@@ -891,7 +1057,16 @@ func (ddb *DoltDB) GC(ctx context.Context, uncommitedVals ...hash.Hash) error {
 		return fmt.Errorf("this database does not support garbage collection")
 	}
 
-	err := ddb.pruneUnreferencedDatasets(ctx)
+	// garbage collection walks every chunk reachable from the database's refs, which includes the chunks left out of
+	// a shallow clone.
+	boundary, err := ddb.GetShallowBoundary(ctx)
+	if err != nil {
+		return err
+	} else if len(boundary) > 0 {
+		return fmt.Errorf("garbage collection is not supported for shallow clones")
+	}
+
+	err = ddb.pruneUnreferencedDatasets(ctx)
 	if err != nil {
 		return err
 	}
@@ -1034,6 +1209,167 @@ func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB
 	}
 }
 
+// PullShallowChunks pulls the chunks of |cm| into this database from the source database given, along with the chunks
+// of at most |depth| commits of its history. It returns the hashes of the commits which were left out, which should be
+// recorded with SetShallowBoundary. Progress is communicated over the provided channel.
+func (ddb *DoltDB) PullShallowChunks(ctx context.Context, tempDir string, srcDB *DoltDB, cm *Commit, depth int, pullerEventCh chan datas.PullerEvent) (hash.HashSet, error) {
+	if !datas.CanUsePuller(srcDB.db) || !datas.CanUsePuller(ddb.db) {
+		return nil, errors.New("this type of chunk store does not support shallow clones")
+	}
+
+	boundary, err := srcDB.shallowBoundary(ctx, cm, depth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stRef, err := cm.GetStRef()
+
+	if err != nil {
+		return nil, err
+	}
+
+	puller, err := datas.NewPuller(ctx, tempDir, defaultChunksPerTF, srcDB.db, ddb.db, stRef.TargetHash(), pullerEventCh)
+
+	if err == datas.ErrDBUpToDate {
+		return boundary, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	puller.ExcludeChunks(boundary)
+	err = puller.Pull(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return boundary, nil
+}
+
+// shallowBoundary returns the hashes of the commits which are more than |depth| - 1 commits away from |cm|, but which
+// are parents of commits which are not.
+func (ddb *DoltDB) shallowBoundary(ctx context.Context, cm *Commit, depth int) (hash.HashSet, error) {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	seen := hash.NewHashSet(h)
+	boundary := hash.NewHashSet()
+	level := []*Commit{cm}
+	for dist := 1; len(level) > 0; dist++ {
+		var nextLevel []*Commit
+		for _, c := range level {
+			parentHashes, err := c.ParentHashes(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for i, ph := range parentHashes {
+				if seen.Has(ph) {
+					continue
+				} else if dist >= depth {
+					boundary.Insert(ph)
+					continue
+				}
+
+				seen.Insert(ph)
+				parent, err := ddb.ResolveParent(ctx, c, i)
+
+				if err != nil {
+					return nil, err
+				}
+
+				nextLevel = append(nextLevel, parent)
+			}
+		}
+		level = nextLevel
+	}
+
+	return boundary, nil
+}
+
+// SetShallowBoundary records the hashes of the commits which were left out of this database by a shallow clone.
+func (ddb *DoltDB) SetShallowBoundary(ctx context.Context, boundary hash.HashSet, cm *CommitMeta) error {
+	vals := make([]types.Value, 0, len(boundary))
+	for h := range boundary {
+		vals = append(vals, types.String(h.String()))
+	}
+
+	set, err := types.NewSet(ctx, ddb.db, vals...)
+
+	if err != nil {
+		return err
+	}
+
+	parents, err := types.NewList(ctx, ddb.db)
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := cm.toNomsStruct(ddb.db.Format())
+
+	if err != nil {
+		return err
+	}
+
+	ds, err := ddb.db.GetDataset(ctx, datas.ShallowBoundaryDataset)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = ddb.db.Commit(ctx, ds, set, datas.CommitOptions{ParentsList: parents, Meta: meta})
+	return err
+}
+
+// GetShallowBoundary returns the hashes of the commits which were left out of this database by a shallow clone. The
+// set is empty if the database isn't a shallow clone.
+func (ddb *DoltDB) GetShallowBoundary(ctx context.Context) (hash.HashSet, error) {
+	return datas.ShallowBoundary(ctx, ddb.db)
+}
+
+// shallowBoundaryErr returns ErrShallowBoundary if the commit with the hash |hashStr| was left out of this database by
+// a shallow clone, and ErrHashNotFound otherwise.
+func (ddb *DoltDB) shallowBoundaryErr(ctx context.Context, hashStr string) error {
+	boundary, err := ddb.GetShallowBoundary(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	h, ok := hash.MaybeParse(strings.TrimPrefix(hashStr, "#"))
+
+	if ok && boundary.Has(h) {
+		return ErrShallowBoundary
+	}
+
+	return ErrHashNotFound
+}
+
+// missingCommitErr returns ErrShallowBoundary if the commit with the hash |h| was left out of the database |vrw| by a
+// shallow clone. Any other commit which can't be read means the database is missing chunks, and is reported as
+// ErrCommitMissing.
+func missingCommitErr(ctx context.Context, vrw types.ValueReadWriter, h hash.Hash) error {
+	if db, ok := vrw.(datas.Database); ok {
+		boundary, err := datas.ShallowBoundary(ctx, db)
+
+		if err != nil {
+			return err
+		}
+
+		if boundary.Has(h) {
+			return ErrShallowBoundary
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrCommitMissing, h.String())
+}
+
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return datas.Clone(ctx, ddb.db, destDB.db, eventCh)
 }
@@ -16,7 +16,9 @@ package doltdb
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/encoding"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/test"
+	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -341,3 +344,186 @@ func TestLDNoms(t *testing.T) {
 		}
 	}
 }
+
+func TestShallowClone(t *testing.T) {
+	ctx := context.Background()
+	srcDir, err := ioutil.TempDir("", "shallow_src")
+	require.NoError(t, err)
+	defer os.RemoveAll(srcDir)
+	sinkDir, err := ioutil.TempDir("", "shallow_sink")
+	require.NoError(t, err)
+	defer os.RemoveAll(sinkDir)
+	tmpDir, err := ioutil.TempDir("", "shallow_tmp")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	src, err := LoadDoltDB(ctx, types.Format_Default, "file://"+srcDir)
+	require.NoError(t, err)
+	err = src.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	masterRef := ref.NewBranchRef(MasterBranch)
+	master, err := src.ResolveRef(ctx, masterRef)
+	require.NoError(t, err)
+	root, err := master.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := src.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+
+	// init <- c1 <- c2 <- c3 on master, and c1 <- o1 on other
+	var commits []*Commit
+	for _, desc := range []string{"c1", "c2", "c3"} {
+		meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", desc)
+		require.NoError(t, err)
+		cm, err := src.Commit(ctx, valHash, masterRef, meta)
+		require.NoError(t, err)
+		commits = append(commits, cm)
+	}
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "o1")
+	require.NoError(t, err)
+	c1Spec, err := NewCommitSpec("master~2")
+	require.NoError(t, err)
+	otherRef := ref.NewBranchRef("other")
+	other, err := src.CommitWithParentSpecs(ctx, valHash, otherRef, []*CommitSpec{c1Spec}, meta)
+	require.NoError(t, err)
+
+	sink, err := LoadDoltDB(ctx, types.Format_Default, "file://"+sinkDir)
+	require.NoError(t, err)
+
+	pullShallow := func(cm *Commit, depth int) hash.HashSet {
+		eventCh := make(chan datas.PullerEvent, 128)
+		go func() {
+			for range eventCh {
+			}
+		}()
+		defer close(eventCh)
+
+		boundary, err := sink.PullShallowChunks(ctx, tmpDir, src, cm, depth, eventCh)
+		require.NoError(t, err)
+		return boundary
+	}
+
+	c1Hash, err := commits[0].HashOf()
+	require.NoError(t, err)
+	c3Hash, err := commits[2].HashOf()
+	require.NoError(t, err)
+
+	boundary := pullShallow(commits[2], 2)
+	assert.Equal(t, hash.NewHashSet(c1Hash), boundary)
+	assert.Equal(t, hash.NewHashSet(c1Hash), pullShallow(other, 1))
+
+	empty, err := sink.GetShallowBoundary(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	// until the boundary is recorded, the missing commits are an error rather than the end of the history
+	cs, err := NewCommitSpec(c3Hash.String())
+	require.NoError(t, err)
+	c3, err := sink.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	c2, err := sink.ResolveParent(ctx, c3, 0)
+	require.NoError(t, err)
+	_, err = sink.ResolveParent(ctx, c2, 0)
+	assert.True(t, errors.Is(err, ErrCommitMissing))
+
+	meta, err = NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "shallow clone boundary")
+	require.NoError(t, err)
+	err = sink.SetShallowBoundary(ctx, boundary, meta)
+	require.NoError(t, err)
+	stored, err := sink.GetShallowBoundary(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, boundary, stored)
+
+	err = sink.SetHeadToCommit(ctx, masterRef, c3)
+	require.NoError(t, err)
+
+	_, err = sink.ResolveParent(ctx, c2, 0)
+	assert.Equal(t, ErrShallowBoundary, err)
+
+	// the boundary commit is the oldest one, so setting a head to it must not check for its parents
+	err = sink.SetHeadToCommit(ctx, ref.NewBranchRef("oldest"), c2)
+	require.NoError(t, err)
+
+	cs, err = NewCommitSpec("master~2")
+	require.NoError(t, err)
+	_, err = sink.Resolve(ctx, cs, nil)
+	assert.Equal(t, ErrShallowBoundary, err)
+
+	cs, err = NewCommitSpec(c1Hash.String())
+	require.NoError(t, err)
+	_, err = sink.Resolve(ctx, cs, nil)
+	assert.Equal(t, ErrShallowBoundary, err)
+
+	otherHash, err := other.HashOf()
+	require.NoError(t, err)
+	cs, err = NewCommitSpec(otherHash.String())
+	require.NoError(t, err)
+	sinkOther, err := sink.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	_, err = GetCommitAncestor(ctx, c3, sinkOther)
+	assert.Equal(t, ErrShallowBoundary, err)
+
+	err = sink.GC(ctx)
+	assert.Error(t, err)
+}
+
+func TestCSMetrics(t *testing.T) {
+	ctx := context.Background()
+	testDir, err := test.ChangeToTestDir("TestCSMetrics")
+	require.NoError(t, err)
+	require.NoError(t, filesys.LocalFS.MkDirs(filepath.Join(testDir, dbfactory.DoltDataDir)))
+
+	ddb, err := LoadDoltDB(ctx, types.Format_7_18, LocalDirDoltDB)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse"))
+
+	metrics, ok, err := ddb.CSMetrics()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, metrics.ChunkPuts > 0)
+	assert.True(t, metrics.ChunkCount > 0)
+	assert.True(t, metrics.PhysicalBytes > 0)
+
+	memDB, err := LoadDoltDB(ctx, types.Format_7_18, InMemDoltDB)
+	require.NoError(t, err)
+	_, ok, err = memDB.CSMetrics()
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestBranchUpdates(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse"))
+	assert.Equal(t, BranchUpdates{}, ddb.BranchUpdates())
+
+	masterRef := ref.NewBranchRef(MasterBranch)
+	master, err := ddb.ResolveRef(ctx, masterRef)
+	require.NoError(t, err)
+	root, err := master.GetRootValue()
+	require.NoError(t, err)
+	valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "commit")
+	require.NoError(t, err)
+
+	featureRef := ref.NewBranchRef("feature")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, featureRef, master))
+	feature, err := ddb.Commit(ctx, valHash, featureRef, meta)
+	require.NoError(t, err)
+	assert.Equal(t, BranchUpdates{Commits: 1}, ddb.BranchUpdates())
+
+	// dangling commits are not on a branch
+	dangling, err := ddb.CommitDanglingWithParentCommits(ctx, valHash, []*Commit{master}, meta)
+	require.NoError(t, err)
+	assert.Equal(t, BranchUpdates{Commits: 1}, ddb.BranchUpdates())
+
+	require.NoError(t, ddb.FastForward(ctx, masterRef, feature))
+	assert.Equal(t, BranchUpdates{Commits: 1, Merges: 1}, ddb.BranchUpdates())
+
+	_, err = ddb.CommitWithParentCommits(ctx, valHash, masterRef, []*Commit{dangling}, meta)
+	require.NoError(t, err)
+	assert.Equal(t, BranchUpdates{Commits: 2, Merges: 2}, ddb.BranchUpdates())
+}
@@ -34,11 +34,15 @@ var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
 var ErrTagNotFound = errors.New("tag not found")
 var ErrWorkspaceNotFound = errors.New("workspace not found")
+var ErrStashNotFound = errors.New("stash not found")
 var ErrTableNotFound = errors.New("table not found")
 var ErrTableExists = errors.New("table already exists")
 var ErrAlreadyOnBranch = errors.New("Already on branch")
 var ErrAlreadyOnWorkspace = errors.New("Already on workspace")
 
+var ErrShallowBoundary = errors.New("commit history beyond the boundary of a shallow clone is not available")
+var ErrCommitMissing = errors.New("commit is missing from the database")
+
 var ErrNomsIO = errors.New("error reading from or writing to noms")
 
 var ErrNoConflicts = errors.New("no conflicts")
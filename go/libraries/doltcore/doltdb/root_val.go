@@ -40,7 +40,7 @@ type FeatureVersion int64
 
 // DoltFeatureVersion is described in feature_version.md.
 // only variable for testing.
-var DoltFeatureVersion FeatureVersion = 0
+var DoltFeatureVersion FeatureVersion = 2 // last bumped when adding schema conflicts to tables
 
 // RootValue defines the structure used inside all Dolthub noms dbs
 type RootValue struct {
@@ -470,7 +470,25 @@ func (root *RootValue) getTableMap() (types.Map, error) {
 	return tableMap, err
 }
 
+// TablesInConflict returns the names of the tables with unresolved data or schema conflicts.
 func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
+	return root.tablesWithConflicts(ctx, func(tbl *Table) (bool, error) {
+		if has, err := tbl.HasConflicts(); err != nil || has {
+			return has, err
+		}
+
+		return tbl.HasSchemaConflicts()
+	})
+}
+
+// TablesWithSchemaConflicts returns the names of the tables with unresolved schema conflicts.
+func (root *RootValue) TablesWithSchemaConflicts(ctx context.Context) ([]string, error) {
+	return root.tablesWithConflicts(ctx, func(tbl *Table) (bool, error) {
+		return tbl.HasSchemaConflicts()
+	})
+}
+
+func (root *RootValue) tablesWithConflicts(ctx context.Context, hasConflicts func(tbl *Table) (bool, error)) ([]string, error) {
 	tableMap, err := root.getTableMap()
 
 	if err != nil {
@@ -489,7 +507,7 @@ func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
 
 		tblSt := tblVal.(types.Struct)
 		tbl := &Table{root.vrw, tblSt}
-		if has, err := tbl.HasConflicts(); err != nil {
+		if has, err := hasConflicts(tbl); err != nil {
 			return false, err
 		} else if has {
 			names = append(names, string(key.(types.String)))
@@ -121,6 +121,7 @@ var writeableSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
 	ProceduresTableName,
+	MergePoliciesTableName,
 }
 
 var persistedSystemTables = []string{
@@ -128,6 +129,7 @@ var persistedSystemTables = []string{
 	DoltQueryCatalogTableName,
 	SchemasTableName,
 	ProceduresTableName,
+	MergePoliciesTableName,
 }
 
 var generatedSystemTables = []string{
@@ -137,6 +139,13 @@ var generatedSystemTables = []string{
 	CommitsTableName,
 	CommitAncestorsTableName,
 	StatusTableName,
+	TagsTableName,
+	RemotesTableName,
+	DiffTableName,
+	DiffSummaryTableName,
+	SchemaDiffTableName,
+	MergeStatusTableName,
+	SchemaConflictsTableName,
 }
 
 var generatedSystemTablePrefixes = []string{
@@ -144,6 +153,7 @@ var generatedSystemTablePrefixes = []string{
 	DoltCommitDiffTablePrefix,
 	DoltHistoryTablePrefix,
 	DoltConfTablePrefix,
+	DoltBlameTablePrefix,
 }
 
 const (
@@ -200,6 +210,8 @@ const (
 	DoltCommitDiffTablePrefix = "dolt_commit_diff_"
 	// DoltConfTablePrefix is the prefix assigned to all the generated conflict tables
 	DoltConfTablePrefix = "dolt_conflicts_"
+	// DoltBlameTablePrefix is the prefix assigned to all the generated blame tables
+	DoltBlameTablePrefix = "dolt_blame_"
 )
 
 const (
@@ -220,6 +232,27 @@ const (
 
 	// StatusTableName is the status system table name.
 	StatusTableName = "dolt_status"
+
+	// TagsTableName is the tags system table name
+	TagsTableName = "dolt_tags"
+
+	// RemotesTableName is the remotes system table name
+	RemotesTableName = "dolt_remotes"
+
+	// DiffTableName is the diff system table name
+	DiffTableName = "dolt_diff"
+
+	// DiffSummaryTableName is the diff summary system table name
+	DiffSummaryTableName = "dolt_diff_summary"
+
+	// SchemaDiffTableName is the schema diff system table name
+	SchemaDiffTableName = "dolt_schema_diff"
+
+	// MergeStatusTableName is the merge status system table name
+	MergeStatusTableName = "dolt_merge_status"
+
+	// SchemaConflictsTableName is the schema conflicts system table name
+	SchemaConflictsTableName = "dolt_schema_conflicts"
 )
 
 const (
@@ -234,3 +267,19 @@ const (
 	// ProceduresTableModifiedAtCol is the time that the stored procedure was last modified, in UTC.
 	ProceduresTableModifiedAtCol = "modified_at"
 )
+
+const (
+	// MergePoliciesTableName is the name of the dolt merge policies table.
+	MergePoliciesTableName = "dolt_merge_policies"
+	// MergePoliciesTableNameCol is the name of the table a merge policy applies to.
+	MergePoliciesTableNameCol = "table_name"
+	// MergePoliciesColumnNameCol is the name of the column a merge policy applies to, or MergePoliciesAllColumns.
+	MergePoliciesColumnNameCol = "column_name"
+	// MergePoliciesPolicyCol is the name of the merge policy, e.g. `theirs` or `sum`.
+	MergePoliciesPolicyCol = "policy"
+	// MergePoliciesPolicyColumnCol is the name of the column a merge policy compares, for policies which compare one.
+	MergePoliciesPolicyColumnCol = "policy_column"
+	// MergePoliciesAllColumns is the column name of a merge policy which applies to every column of its table that
+	// does not have a policy of its own.
+	MergePoliciesAllColumns = "*"
+)
@@ -33,6 +33,7 @@ const (
 	tableRowsKey       = "rows"
 	conflictsKey       = "conflicts"
 	conflictSchemasKey = "conflict_schemas"
+	schemaConflictsKey = "schema_conflicts"
 	indexesKey         = "indexes"
 	autoIncrementKey   = "auto_increment"
 
@@ -219,6 +220,93 @@ func (t *Table) ClearConflicts() (*Table, error) {
 	return &Table{t.vrw, tSt}, nil
 }
 
+// SetSchemaConflicts records that the schema of this table conflicts with the schema of |theirTbl|, the table being
+// merged in, with |ancTbl| as their common ancestor. Both tables are kept until the conflicts are resolved, so that
+// the merge of the table can be completed.
+func (t *Table) SetSchemaConflicts(ctx context.Context, theirTbl, ancTbl *Table) (*Table, error) {
+	theirRef, err := WriteValAndGetRef(ctx, t.vrw, theirTbl.tableStruct)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ancRef, err := WriteValAndGetRef(ctx, t.vrw, ancTbl.tableStruct)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := types.NewTuple(t.vrw.Format(), theirRef, ancRef)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(schemaConflictsKey, tpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+// GetSchemaConflicts returns the table being merged in and the common ancestor table recorded by SetSchemaConflicts.
+// Returns ErrNoConflicts if the table has no schema conflicts.
+func (t *Table) GetSchemaConflicts(ctx context.Context) (theirTbl, ancTbl *Table, err error) {
+	val, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, ErrNoConflicts
+	}
+
+	tpl := val.(types.Tuple)
+	tbls := make([]*Table, 2)
+	for i := range tbls {
+		ref, err := tpl.Get(uint64(i))
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tblVal, err := ref.(types.Ref).TargetValue(ctx, t.vrw)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tbls[i] = &Table{t.vrw, tblVal.(types.Struct)}
+	}
+
+	return tbls[0], tbls[1], nil
+}
+
+// HasSchemaConflicts returns whether the merge of this table's schema left conflicts which need to be resolved.
+func (t *Table) HasSchemaConflicts() (bool, error) {
+	if t == nil {
+		return false, nil
+	}
+
+	_, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	return ok, err
+}
+
+// ClearSchemaConflicts removes the schema conflicts of this table.
+func (t *Table) ClearSchemaConflicts() (*Table, error) {
+	tSt, err := t.tableStruct.Delete(schemaConflictsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, tSt}, nil
+}
+
 func (t *Table) GetConflictSchemas(ctx context.Context) (base, sch, mergeSch schema.Schema, err error) {
 	schemasVal, ok, err := t.tableStruct.MaybeGet(conflictSchemasKey)
 
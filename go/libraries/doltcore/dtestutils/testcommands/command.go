@@ -261,13 +261,13 @@ func (m Merge) Exec(t *testing.T, dEnv *env.DoltEnv) error {
 		mergedRoot, tblToStats, err := merge.MergeCommits(context.Background(), cm1, cm2)
 		require.NoError(t, err)
 		for _, stats := range tblToStats {
-			require.True(t, stats.Conflicts == 0)
+			require.False(t, stats.HasConflicts())
 		}
 
 		h2, err := cm2.HashOf()
 		require.NoError(t, err)
 
-		err = dEnv.RepoState.StartMerge(h2.String(), dEnv.FS)
+		err = dEnv.RepoState.StartMerge(h2.String(), m.BranchName, dEnv.FS)
 		if err != nil {
 			return err
 		}
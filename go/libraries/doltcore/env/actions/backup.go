@@ -0,0 +1,231 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var ErrNotABackup = errors.New("database is not a dolt backup")
+
+// backupWorkingSetRef points at a commit which holds the working set of the repository a backup was made from. The
+// commit's value is the working root, its first parent is HEAD, its second parent is a commit holding the staged root,
+// and its description is the JSON encoded backupRepoState.
+var backupWorkingSetRef = ref.NewInternalRef("backup/working-set")
+
+// backupRepoState is the part of the repo state that is saved with a backup, other than the working and staged roots.
+// The backups of the repository are local configuration and are not saved.
+type backupRepoState struct {
+	Head     ref.MarshalableRef          `json:"head"`
+	Merge    *env.MergeState             `json:"merge,omitempty"`
+	Rebase   *env.RebaseState            `json:"rebase,omitempty"`
+	Remotes  map[string]env.Remote       `json:"remotes"`
+	Branches map[string]env.BranchConfig `json:"branches"`
+}
+
+// SyncBackup makes |backupDB| a copy of the database of |dEnv|. The table files of the database are copied as they
+// are, so every branch, tag, workspace and remote ref is backed up, and the working set is saved alongside them so that
+// it can be restored with RestoreBackup. Table files which were copied by an earlier sync are not copied again.
+func SyncBackup(ctx context.Context, dEnv *env.DoltEnv, backupDB *doltdb.DoltDB, eventCh chan<- datas.TableFileEvent) error {
+	err := Clone(ctx, dEnv.DoltDB, backupDB, eventCh)
+
+	if err != nil {
+		return err
+	}
+
+	wsCm, err := commitWorkingSet(ctx, dEnv, backupDB)
+
+	if err != nil {
+		return err
+	}
+
+	return backupDB.SetHeadToCommit(ctx, backupWorkingSetRef, wsCm)
+}
+
+// commitWorkingSet writes a dangling commit holding the working set of |dEnv| to |backupDB|, which must already hold
+// the table files of |dEnv|'s database. Nothing is written to the database of |dEnv|. See backupWorkingSetRef.
+func commitWorkingSet(ctx context.Context, dEnv *env.DoltEnv, backupDB *doltdb.DoltDB) (*doltdb.Commit, error) {
+	rs := dEnv.RepoState
+
+	srcHeadCm, err := dEnv.DoltDB.ResolveRef(ctx, rs.CWBHeadRef())
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := srcHeadCm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := doltdb.NewCommitSpec(h.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	headCm, err := backupDB.Resolve(ctx, cs, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headMeta, err := headCm.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := json.Marshal(backupRepoState{
+		Head:     rs.Head,
+		Merge:    rs.Merge,
+		Rebase:   rs.Rebase,
+		Remotes:  rs.Remotes,
+		Branches: rs.Branches,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedMeta, err := doltdb.NewCommitMeta(headMeta.Name, headMeta.Email, "staged root of "+rs.CWBHeadRef().String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedCm, err := backupDB.CommitDanglingWithParentCommits(ctx, rs.StagedHash(), []*doltdb.Commit{headCm}, stagedMeta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := doltdb.NewCommitMeta(headMeta.Name, headMeta.Email, string(desc))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return backupDB.CommitDanglingWithParentCommits(ctx, rs.WorkingHash(), []*doltdb.Commit{headCm, stagedCm}, meta)
+}
+
+// RestoreBackup copies the table files of |backupDB| to the database of |dEnv|, which must be empty, and restores the
+// working set and repo state which were saved with the backup.
+func RestoreBackup(ctx context.Context, backupDB *doltdb.DoltDB, dEnv *env.DoltEnv, eventCh chan<- datas.TableFileEvent) error {
+	ok, err := backupDB.HasRef(ctx, backupWorkingSetRef)
+
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrNotABackup
+	}
+
+	err = Clone(ctx, backupDB, dEnv.DoltDB, eventCh)
+
+	if err != nil {
+		return err
+	}
+
+	ddb := dEnv.DoltDB
+	wsCm, err := ddb.ResolveRef(ctx, backupWorkingSetRef)
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := wsCm.GetCommitMeta()
+
+	if err != nil {
+		return err
+	}
+
+	var saved backupRepoState
+	err = json.Unmarshal([]byte(meta.Description), &saved)
+
+	if err != nil {
+		return err
+	}
+
+	working, err := wsCm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	stagedCm, err := ddb.ResolveParent(ctx, wsCm, 1)
+
+	if err != nil {
+		return err
+	}
+
+	staged, err := stagedCm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	workingHash, err := ddb.WriteRootValue(ctx, working)
+
+	if err != nil {
+		return err
+	}
+
+	stagedHash, err := ddb.WriteRootValue(ctx, staged)
+
+	if err != nil {
+		return err
+	}
+
+	err = ddb.DeleteBranch(ctx, backupWorkingSetRef)
+
+	if err != nil {
+		return err
+	}
+
+	rs, err := env.CreateRepoState(dEnv.FS, saved.Head.Ref.String(), workingHash)
+
+	if err != nil {
+		return err
+	}
+
+	rs.Staged = stagedHash.String()
+	rs.Merge = saved.Merge
+	rs.Rebase = saved.Rebase
+	if saved.Remotes != nil {
+		rs.Remotes = saved.Remotes
+	}
+	if saved.Branches != nil {
+		rs.Branches = saved.Branches
+	}
+
+	err = rs.Save(dEnv.FS)
+
+	if err != nil {
+		return err
+	}
+
+	dEnv.RepoState = rs
+	dEnv.RSLoadErr = nil
+
+	return SaveDocsFromRoot(ctx, working, dEnv)
+}
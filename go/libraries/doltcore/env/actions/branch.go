@@ -123,36 +123,47 @@ func DeleteBranchOnDB(ctx context.Context, ddb *doltdb.DoltDB, dref ref.DoltRef,
 	}
 
 	if !opts.Force && !opts.Remote {
-		ms, err := doltdb.NewCommitSpec("master")
+		err = CheckBranchIsMerged(ctx, ddb, dref)
 		if err != nil {
 			return err
 		}
+	}
 
-		master, err := ddb.Resolve(ctx, ms, nil)
-		if err != nil {
-			return err
-		}
+	return ddb.DeleteBranch(ctx, dref)
+}
 
-		cs, err := doltdb.NewCommitSpec(dref.String())
-		if err != nil {
-			return err
-		}
+// CheckBranchIsMerged returns ErrUnmergedBranchDelete if the branch |dref| is not fully merged into master, which is
+// required to delete it without forcing.
+func CheckBranchIsMerged(ctx context.Context, ddb *doltdb.DoltDB, dref ref.DoltRef) error {
+	ms, err := doltdb.NewCommitSpec("master")
+	if err != nil {
+		return err
+	}
 
-		cm, err := ddb.Resolve(ctx, cs, nil)
-		if err != nil {
-			return err
-		}
+	master, err := ddb.Resolve(ctx, ms, nil)
+	if err != nil {
+		return err
+	}
 
-		isMerged, _ := master.CanFastReverseTo(ctx, cm)
-		if err != nil && err != doltdb.ErrUpToDate {
-			return err
-		}
-		if !isMerged {
-			return ErrUnmergedBranchDelete
-		}
+	cs, err := doltdb.NewCommitSpec(dref.String())
+	if err != nil {
+		return err
 	}
 
-	return ddb.DeleteBranch(ctx, dref)
+	cm, err := ddb.Resolve(ctx, cs, nil)
+	if err != nil {
+		return err
+	}
+
+	isMerged, _ := master.CanFastReverseTo(ctx, cm)
+	if err != nil && err != doltdb.ErrUpToDate {
+		return err
+	}
+	if !isMerged {
+		return ErrUnmergedBranchDelete
+	}
+
+	return nil
 }
 
 func CreateBranchWithStartPt(ctx context.Context, dbData env.DbData, newBranch, startPt string, force bool) error {
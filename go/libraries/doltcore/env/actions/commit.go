@@ -338,7 +338,9 @@ func AddCommits(ctx context.Context, ddb *doltdb.DoltDB, commit *doltdb.Commit,
 	for i := 0; i < numParents && len(hashToCommit) != n; i++ {
 		parentCommit, err := ddb.ResolveParent(ctx, commit, i)
 
-		if err != nil {
+		if err == doltdb.ErrShallowBoundary {
+			continue
+		} else if err != nil {
 			return err
 		}
 
@@ -141,14 +141,18 @@ func newQueue() *q {
 // GetDotDotRevisions returns the commits reachable from commit at hash
 // `includedHead` that are not reachable from hash `excludedHead`.
 // `includedHead` and `excludedHead` must be commits in `ddb`. Returns up
-// to `num` commits, in reverse topological order starting at `includedHead`,
+// to `num` commits (If `num` < 0 then all commits), in reverse topological order starting at `includedHead`,
 // with tie breaking based on the height of commit graph between
 // concurrent commits --- higher commits appear first. Remaining
 // ties are broken by timestamp; newer commits appear first.
 //
 // Roughly mimics `git log master..feature`.
 func GetDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, includedHead hash.Hash, excludedDB *doltdb.DoltDB, excludedHead hash.Hash, num int) ([]*doltdb.Commit, error) {
-	commitList := make([]*doltdb.Commit, 0, num)
+	var commitList []*doltdb.Commit
+	if num > 0 {
+		commitList = make([]*doltdb.Commit, 0, num)
+	}
+
 	q := newQueue()
 	if err := q.SetInvisible(ctx, excludedDB, excludedHead); err != nil {
 		return nil, err
@@ -167,11 +171,13 @@ func GetDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, included
 		}
 		for _, parentID := range parents {
 			if nextC.invisible {
-				if err := q.SetInvisible(ctx, nextC.ddb, parentID); err != nil {
+				if err := q.SetInvisible(ctx, nextC.ddb, parentID); err == doltdb.ErrShallowBoundary {
+					continue
+				} else if err != nil {
 					return nil, err
 				}
 			}
-			if err := q.AddPendingIfUnseen(ctx, nextC.ddb, parentID); err != nil {
+			if err := q.AddPendingIfUnseen(ctx, nextC.ddb, parentID); err != nil && err != doltdb.ErrShallowBoundary {
 				return nil, err
 			}
 		}
@@ -230,7 +236,8 @@ func (i *commiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, err
 		}
 
 		for _, parentID := range parents {
-			if err := i.q.AddPendingIfUnseen(ctx, nextC.ddb, parentID); err != nil {
+			// The history of a shallow clone ends at the commits whose parents were left out.
+			if err := i.q.AddPendingIfUnseen(ctx, nextC.ddb, parentID); err != nil && err != doltdb.ErrShallowBoundary {
 				return hash.Hash{}, nil, err
 			}
 		}
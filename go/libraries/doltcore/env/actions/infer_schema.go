@@ -55,6 +55,10 @@ type InferenceArgs interface {
 
 // InferColumnTypesFromTableReader will infer a data types from a table reader.
 func InferColumnTypesFromTableReader(ctx context.Context, root *doltdb.RootValue, rd table.TableReadCloser, args InferenceArgs) (*schema.ColCollection, error) {
+	if _, ok := rd.(table.TypedTableReader); ok {
+		return storedColumnTypes(rd.GetSchema(), args), nil
+	}
+
 	inferrer := newInferrer(rd.GetSchema(), args)
 
 	var rowFailure *pipeline.TransformRowFailure
@@ -80,6 +84,22 @@ func InferColumnTypesFromTableReader(ctx context.Context, root *doltdb.RootValue
 	return inferrer.inferColumnTypes(ctx, root)
 }
 
+// storedColumnTypes returns the columns of |readerSch|, the schema of a reader for a file format that stores column
+// types, with the types kept as they are.
+func storedColumnTypes(readerSch schema.Schema, args InferenceArgs) *schema.ColCollection {
+	mapper := args.ColNameMapper()
+
+	var cols []schema.Column
+	_ = readerSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		col.Name = mapper.Map(col.Name)
+		col.Tag = schema.ReservedTagMin + tag
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	return schema.NewColCollection(cols...)
+}
+
 type inferrer struct {
 	readerSch      schema.Schema
 	inferSets      map[uint64]typeInfoSet
@@ -144,3 +144,60 @@ func FetchTag(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.Dolt
 func Clone(ctx context.Context, srcDB, destDB *doltdb.DoltDB, eventCh chan<- datas.TableFileEvent) error {
 	return srcDB.Clone(ctx, destDB, eventCh)
 }
+
+// ShallowClone pulls |branch| from a remote source database to a local destination database, along with at most
+// |depth| commits of its history. The commits which were left out are recorded as the shallow boundary of the
+// destination database.
+func ShallowClone(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, branch ref.BranchRef, depth int, pullerEventCh chan datas.PullerEvent) error {
+	srcCm, err := srcDB.ResolveRef(ctx, branch)
+
+	if err != nil {
+		return err
+	}
+
+	boundary, err := destDB.PullShallowChunks(ctx, dEnv.TempTableFilesDir(), srcDB, srcCm, depth, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	if len(boundary) > 0 {
+		srcMeta, err := srcCm.GetCommitMeta()
+
+		if err != nil {
+			return err
+		}
+
+		meta, err := doltdb.NewCommitMeta(srcMeta.Name, srcMeta.Email, "shallow clone boundary")
+
+		if err != nil {
+			return err
+		}
+
+		err = destDB.SetShallowBoundary(ctx, boundary, meta)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	h, err := srcCm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	cs, err := doltdb.NewCommitSpec(h.String())
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := destDB.Resolve(ctx, cs, nil)
+
+	if err != nil {
+		return err
+	}
+
+	return destDB.SetHeadToCommit(ctx, branch, cm)
+}
@@ -16,6 +16,7 @@ package actions
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
@@ -66,6 +67,13 @@ func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue,
 			return doltdb.ErrTableNotFound
 		}
 
+		// the data of a table with schema conflicts has not been merged yet
+		if has, err := tbl.HasSchemaConflicts(); err != nil {
+			return err
+		} else if has {
+			return fmt.Errorf("table %s has schema conflicts which must be resolved first", tblName)
+		}
+
 		err = merge.ResolveTable(ctx, root.VRW(), tblName, tbl, autoResolver, tableEditSession)
 
 		if err != nil {
@@ -80,3 +88,36 @@ func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue,
 
 	return dEnv.UpdateWorkingRoot(ctx, newRoot)
 }
+
+// ResolveSchemaConflicts resolves the schema conflicts of the columns and indexes named in |names| of the table
+// |tblName| in the working root, or all of its schema conflicts if |names| is empty, by taking our definitions if
+// |ours| is true or their definitions otherwise.
+func ResolveSchemaConflicts(ctx context.Context, dEnv *env.DoltEnv, tblName string, ours bool, names []string) error {
+	root, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tbl, ok, err := root.GetTable(ctx, tblName)
+
+	if err != nil {
+		return err
+	} else if !ok {
+		return doltdb.ErrTableNotFound
+	}
+
+	if has, err := tbl.HasSchemaConflicts(); err != nil {
+		return err
+	} else if !has {
+		return doltdb.ErrNoConflicts
+	}
+
+	newRoot, err := merge.ResolveSchemaConflicts(ctx, root, tblName, ours, names)
+
+	if err != nil {
+		return err
+	}
+
+	return dEnv.UpdateWorkingRoot(ctx, newRoot)
+}
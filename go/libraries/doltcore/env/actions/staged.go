@@ -136,6 +136,13 @@ func checkTablesForConflicts(ctx context.Context, tbls []string, working *doltdb
 			return nil, err
 		}
 
+		if has, err := tbl.HasSchemaConflicts(); err != nil {
+			return nil, err
+		} else if has {
+			inConflict = append(inConflict, tblName)
+			continue
+		}
+
 		has, err := tbl.HasConflicts()
 		if err != nil {
 			return nil, err
@@ -0,0 +1,299 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+var ErrNoLocalChanges = errors.New("no local changes to save")
+var ErrStashConflicts = errors.New("applying the stash results in conflicts")
+
+// Stash is an entry of the stash list. Its commit holds the stashed working root and has two parents: the commit
+// HEAD pointed to when the changes were stashed, and a commit holding the stashed staged root.
+type Stash struct {
+	Ref    ref.DoltRef
+	Commit *doltdb.Commit
+	Meta   *doltdb.CommitMeta
+}
+
+// GetStashes returns the stash entries of the database ordered from the most recently stashed to the oldest.
+func GetStashes(ctx context.Context, ddb *doltdb.DoltDB) ([]*Stash, error) {
+	refs, err := ddb.GetStashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return stashIndex(refs[i]) > stashIndex(refs[j])
+	})
+
+	stashes := make([]*Stash, len(refs))
+	for i, r := range refs {
+		cm, err := ddb.ResolveRef(ctx, r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		meta, err := cm.GetCommitMeta()
+
+		if err != nil {
+			return nil, err
+		}
+
+		stashes[i] = &Stash{Ref: r, Commit: cm, Meta: meta}
+	}
+
+	return stashes, nil
+}
+
+// StashChanges saves the working and staged roots as a new stash entry and resets them to the root of HEAD. Tables
+// which have not been added are stashed as well.
+func StashChanges(ctx context.Context, dbData env.DbData, name, email string) (*Stash, error) {
+	ddb := dbData.Ddb
+	rsr := dbData.Rsr
+	rsw := dbData.Rsw
+
+	working, staged, head, err := env.GetRoots(ctx, ddb, rsr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	headHash, err := head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	workingHash, err := ddb.WriteRootValue(ctx, working)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedHash, err := ddb.WriteRootValue(ctx, staged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if workingHash == headHash && stagedHash == headHash {
+		return nil, ErrNoLocalChanges
+	}
+
+	headCm, err := ddb.ResolveRef(ctx, rsr.CWBHeadRef())
+
+	if err != nil {
+		return nil, err
+	}
+
+	headCmHash, err := headCm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	headMeta, err := headCm.GetCommitMeta()
+
+	if err != nil {
+		return nil, err
+	}
+
+	on := fmt.Sprintf("%s: %s %s", rsr.CWBHeadRef().GetPath(), headCmHash.String(), headMeta.Description)
+
+	stagedMeta, err := doltdb.NewCommitMeta(name, email, "index on "+on)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stagedCm, err := ddb.CommitDanglingWithParentCommits(ctx, stagedHash, []*doltdb.Commit{headCm}, stagedMeta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := doltdb.NewCommitMeta(name, email, "WIP on "+on)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashCm, err := ddb.CommitDanglingWithParentCommits(ctx, workingHash, []*doltdb.Commit{headCm, stagedCm}, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashRef, err := nextStashRef(ctx, ddb)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ddb.NewStashAtCommit(ctx, stashRef, stashCm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = env.UpdateStagedRoot(ctx, ddb, rsw, head)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = env.UpdateWorkingRoot(ctx, ddb, rsw, head)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stash{Ref: stashRef, Commit: stashCm, Meta: meta}, nil
+}
+
+// ApplyStash applies the changes of |stash| to the working and staged roots. The changes are applied using three-way
+// merges in which the commit HEAD pointed to when the changes were stashed is used as the common ancestor, so a stash
+// can be applied on top of a different commit or branch. If applying either the stashed working changes or the
+// stashed staged changes results in conflicts, neither root is modified and ErrStashConflicts is returned along with
+// the stats of the merge which conflicted.
+func ApplyStash(ctx context.Context, dbData env.DbData, stash *Stash) (map[string]*merge.MergeStats, error) {
+	ddb := dbData.Ddb
+	rsw := dbData.Rsw
+
+	working, staged, _, err := env.GetRoots(ctx, ddb, dbData.Rsr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	baseRoot, err := getStashParentRoot(ctx, ddb, stash, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashedStaged, err := getStashParentRoot(ctx, ddb, stash, 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stashedWorking, err := stash.Commit.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergedWorking, tblToStats, err := merge.MergeRoots(ctx, working, stashedWorking, baseRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMergeConflicts(tblToStats) {
+		return tblToStats, ErrStashConflicts
+	}
+
+	mergedStaged, stagedStats, err := merge.MergeRoots(ctx, staged, stashedStaged, baseRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMergeConflicts(stagedStats) {
+		return stagedStats, ErrStashConflicts
+	}
+
+	_, err = env.UpdateStagedRoot(ctx, ddb, rsw, mergedStaged)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = env.UpdateWorkingRoot(ctx, ddb, rsw, mergedWorking)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tblToStats, nil
+}
+
+// DropStash removes |stash| from the stash list.
+func DropStash(ctx context.Context, ddb *doltdb.DoltDB, stash *Stash) error {
+	return ddb.DeleteStash(ctx, stash.Ref)
+}
+
+func getStashParentRoot(ctx context.Context, ddb *doltdb.DoltDB, stash *Stash, idx int) (*doltdb.RootValue, error) {
+	parent, err := ddb.ResolveParent(ctx, stash.Commit, idx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parent.GetRootValue()
+}
+
+func hasMergeConflicts(tblToStats map[string]*merge.MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.HasConflicts() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextStashRef returns a ref for a new stash entry. Stash entries are named using increasing integers so that they
+// can be ordered by the time they were created.
+func nextStashRef(ctx context.Context, ddb *doltdb.DoltDB) (ref.DoltRef, error) {
+	refs, err := ddb.GetStashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	next := 0
+	for _, r := range refs {
+		if idx := stashIndex(r); idx >= next {
+			next = idx + 1
+		}
+	}
+
+	return ref.NewStashRef(strconv.Itoa(next)), nil
+}
+
+func stashIndex(r ref.DoltRef) int {
+	idx, err := strconv.Atoi(r.GetPath())
+
+	if err != nil {
+		return -1
+	}
+
+	return idx
+}
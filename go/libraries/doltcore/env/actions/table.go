@@ -40,6 +40,7 @@ func MoveTablesBetweenRoots(ctx context.Context, tbls []string, src, dest *doltd
 	}
 
 	tblsToDrop := set.NewStrSet(nil)
+	tblsToPut := set.NewStrSet(nil)
 
 	for _, td := range tblDeltas {
 		if td.IsDrop() {
@@ -66,6 +67,7 @@ func MoveTablesBetweenRoots(ctx context.Context, tbls []string, src, dest *doltd
 			if err != nil {
 				return nil, err
 			}
+			tblsToPut.Add(td.ToName)
 
 			stagedFKs.RemoveKeys(td.FromFks...)
 			err = stagedFKs.AddKeys(td.ToFks...)
@@ -90,6 +92,9 @@ func MoveTablesBetweenRoots(ctx context.Context, tbls []string, src, dest *doltd
 		return nil, err
 	}
 
+	// a table dropped and recreated with the same name, such as to change its primary key, has both a drop and an add
+	tblsToDrop.Remove(tblsToPut.AsSlice()...)
+
 	// RemoveTables also removes that table's ForeignKeys
 	dest, err = dest.RemoveTables(ctx, tblsToDrop.AsSlice()...)
 	if err != nil {
@@ -386,10 +386,26 @@ func (r *repoStateReader) GetMergeCommit() string {
 	return r.dEnv.RepoState.Merge.Commit
 }
 
+func (r *repoStateReader) GetMergeCommitSpec() string {
+	return r.dEnv.RepoState.GetMergeCommitSpec()
+}
+
 func (r *repoStateReader) GetPreMergeWorking() string {
 	return r.dEnv.RepoState.Merge.PreMergeWorking
 }
 
+func (r *repoStateReader) IsRebaseActive() bool {
+	return r.dEnv.RepoState.Rebase != nil
+}
+
+func (r *repoStateReader) GetRebaseOrigHead() string {
+	return r.dEnv.RepoState.Rebase.OrigHead
+}
+
+func (r *repoStateReader) GetRemotes() (map[string]Remote, error) {
+	return r.dEnv.GetRemotes()
+}
+
 func (dEnv *DoltEnv) RepoStateReader() RepoStateReader {
 	return &repoStateReader{dEnv}
 }
@@ -439,8 +455,49 @@ func (r *repoStateWriter) ClearMerge() error {
 	return r.dEnv.RepoState.ClearMerge(r.dEnv.FS)
 }
 
-func (r *repoStateWriter) StartMerge(commitStr string) error {
-	return r.dEnv.RepoState.StartMerge(commitStr, r.dEnv.FS)
+func (r *repoStateWriter) StartMerge(commitStr, commitSpecStr string) error {
+	return r.dEnv.RepoState.StartMerge(commitStr, commitSpecStr, r.dEnv.FS)
+}
+
+func (r *repoStateWriter) AddRemote(remote Remote) error {
+	r.dEnv.RepoState.AddRemote(remote)
+	err := r.dEnv.RepoState.Save(r.dEnv.FS)
+
+	if err != nil {
+		return ErrStateUpdate
+	}
+
+	return nil
+}
+
+func (r *repoStateWriter) RemoveRemote(name string) error {
+	delete(r.dEnv.RepoState.Remotes, name)
+	err := r.dEnv.RepoState.Save(r.dEnv.FS)
+
+	if err != nil {
+		return ErrStateUpdate
+	}
+
+	return nil
+}
+
+// RenameBranchConfig moves the config of the branch |oldName|, such as its upstream, to the branch |newName|.
+func (r *repoStateWriter) RenameBranchConfig(oldName, newName string) error {
+	cfg, ok := r.dEnv.RepoState.Branches[oldName]
+
+	if !ok {
+		return nil
+	}
+
+	delete(r.dEnv.RepoState.Branches, oldName)
+	r.dEnv.RepoState.Branches[newName] = cfg
+	err := r.dEnv.RepoState.Save(r.dEnv.FS)
+
+	if err != nil {
+		return ErrStateUpdate
+	}
+
+	return nil
 }
 
 func (dEnv *DoltEnv) RepoStateWriter() RepoStateWriter {
@@ -565,6 +622,10 @@ func (dEnv *DoltEnv) IsMergeActive() bool {
 	return dEnv.RepoState.Merge != nil
 }
 
+func (dEnv *DoltEnv) IsRebaseActive() bool {
+	return dEnv.RepoState.Rebase != nil
+}
+
 func (dEnv *DoltEnv) GetTablesWithConflicts(ctx context.Context) ([]string, error) {
 	root, err := dEnv.WorkingRoot(ctx)
 
@@ -673,6 +734,15 @@ func (dEnv *DoltEnv) GetRemotes() (map[string]Remote, error) {
 	return dEnv.RepoState.Remotes, nil
 }
 
+// GetBackups returns the backups configured for the repository, keyed by name.
+func (dEnv *DoltEnv) GetBackups() (map[string]Remote, error) {
+	if dEnv.RSLoadErr != nil {
+		return nil, dEnv.RSLoadErr
+	}
+
+	return dEnv.RepoState.Backups, nil
+}
+
 var ErrNotACred = errors.New("not a valid credential key id or public key")
 
 func (dEnv *DoltEnv) FindCreds(credsDir, pubKeyOrId string) (string, error) {
@@ -16,6 +16,7 @@ package env
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/store/types"
@@ -23,6 +24,11 @@ import (
 
 var NoRemote = Remote{}
 
+// IsValidRemoteName returns whether |name| can be used as the name of a remote or backup.
+func IsValidRemoteName(name string) bool {
+	return len(name) > 0 && strings.IndexAny(name, " \t\n\r./\\!@#$%^&*(){}[],.<>'\"?=+|") == -1
+}
+
 func IsEmptyRemote(r Remote) bool {
 	return len(r.Name) == 0 && len(r.Url) == 0 && r.FetchSpecs == nil && r.Params == nil
 }
@@ -35,7 +35,11 @@ type RepoStateReader interface {
 	StagedHash() hash.Hash
 	IsMergeActive() bool
 	GetMergeCommit() string
+	GetMergeCommitSpec() string
 	GetPreMergeWorking() string
+	IsRebaseActive() bool
+	GetRebaseOrigHead() string
+	GetRemotes() (map[string]Remote, error)
 }
 
 type RepoStateWriter interface {
@@ -45,7 +49,10 @@ type RepoStateWriter interface {
 	SetCWBHeadRef(context.Context, ref.MarshalableRef) error
 	AbortMerge() error
 	ClearMerge() error
-	StartMerge(commitStr string) error
+	StartMerge(commitStr, commitSpecStr string) error
+	AddRemote(r Remote) error
+	RemoveRemote(name string) error
+	RenameBranchConfig(oldName, newName string) error
 }
 
 type DocsReadWriter interface {
@@ -70,6 +77,20 @@ type BranchConfig struct {
 type MergeState struct {
 	Commit          string `json:"commit"`
 	PreMergeWorking string `json:"working_pre_merge"`
+	// CommitSpecStr is the commit spec the merged commit was resolved from, such as a branch name.
+	CommitSpecStr string `json:"commit_spec,omitempty"`
+}
+
+// RebaseState records the progress of a rebase which stopped because replaying a commit resulted in conflicts.
+type RebaseState struct {
+	// Onto is the hash of the commit the branch is being rebased onto.
+	Onto string `json:"onto"`
+	// OrigHead is the hash of the commit the branch pointed to before the rebase started.
+	OrigHead string `json:"orig_head"`
+	// Current is the hash of the commit that is currently being replayed.
+	Current string `json:"current"`
+	// Remaining holds the hashes of the commits left to replay, oldest first.
+	Remaining []string `json:"remaining"`
 }
 
 type RepoState struct {
@@ -79,6 +100,8 @@ type RepoState struct {
 	Merge    *MergeState             `json:"merge"`
 	Remotes  map[string]Remote       `json:"remotes"`
 	Branches map[string]BranchConfig `json:"branches"`
+	Rebase   *RebaseState            `json:"rebase,omitempty"`
+	Backups  map[string]Remote       `json:"backups,omitempty"`
 }
 
 func LoadRepoState(fs filesys.ReadWriteFS) (*RepoState, error) {
@@ -109,6 +132,8 @@ func CloneRepoState(fs filesys.ReadWriteFS, r Remote) (*RepoState, error) {
 		nil,
 		map[string]Remote{r.Name: r},
 		make(map[string]BranchConfig),
+		nil,
+		nil,
 	}
 
 	err := rs.Save(fs)
@@ -135,6 +160,8 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 		nil,
 		make(map[string]Remote),
 		make(map[string]BranchConfig),
+		nil,
+		nil,
 	}
 
 	err = rs.Save(fs)
@@ -167,8 +194,8 @@ func (rs *RepoState) CWBHeadSpec() *doltdb.CommitSpec {
 	return spec
 }
 
-func (rs *RepoState) StartMerge(commit string, fs filesys.Filesys) error {
-	rs.Merge = &MergeState{commit, rs.Working}
+func (rs *RepoState) StartMerge(commit, commitSpecStr string, fs filesys.Filesys) error {
+	rs.Merge = &MergeState{commit, rs.Working, commitSpecStr}
 	return rs.Save(fs)
 }
 
@@ -182,10 +209,45 @@ func (rs *RepoState) ClearMerge(fs filesys.Filesys) error {
 	return rs.Save(fs)
 }
 
+func (rs *RepoState) StartRebase(onto, origHead string, commits []string, fs filesys.Filesys) error {
+	rs.Rebase = &RebaseState{Onto: onto, OrigHead: origHead, Remaining: commits}
+	return rs.Save(fs)
+}
+
+// NextRebaseCommit removes the oldest remaining commit from the rebase state, marks it as the commit currently being
+// replayed and returns its hash.
+func (rs *RepoState) NextRebaseCommit(fs filesys.Filesys) (string, error) {
+	rs.Rebase.Current = rs.Rebase.Remaining[0]
+	rs.Rebase.Remaining = rs.Rebase.Remaining[1:]
+	return rs.Rebase.Current, rs.Save(fs)
+}
+
+func (rs *RepoState) ClearRebase(fs filesys.Filesys) error {
+	rs.Rebase = nil
+	return rs.Save(fs)
+}
+
+func (rs *RepoState) IsRebaseActive() bool {
+	return rs.Rebase != nil
+}
+
 func (rs *RepoState) AddRemote(r Remote) {
 	rs.Remotes[r.Name] = r
 }
 
+// AddBackup adds the backup |r| to the repo state, replacing any backup with the same name.
+func (rs *RepoState) AddBackup(r Remote) {
+	if rs.Backups == nil {
+		rs.Backups = make(map[string]Remote)
+	}
+	rs.Backups[r.Name] = r
+}
+
+// RemoveBackup removes the backup named |name| from the repo state.
+func (rs *RepoState) RemoveBackup(name string) {
+	delete(rs.Backups, name)
+}
+
 func (rs *RepoState) WorkingHash() hash.Hash {
 	return hash.Parse(rs.Working)
 }
@@ -202,6 +264,14 @@ func (rs *RepoState) GetMergeCommit() string {
 	return rs.Merge.Commit
 }
 
+// GetMergeCommitSpec returns the commit spec of the commit being merged, or its hash if the spec was not recorded.
+func (rs *RepoState) GetMergeCommitSpec() string {
+	if rs.Merge.CommitSpecStr == "" {
+		return rs.Merge.Commit
+	}
+	return rs.Merge.CommitSpecStr
+}
+
 // Returns the working root.
 func WorkingRoot(ctx context.Context, ddb *doltdb.DoltDB, rsr RepoStateReader) (*doltdb.RootValue, error) {
 	return ddb.ReadRootValue(ctx, rsr.WorkingHash())
@@ -378,6 +448,11 @@ func GetGCKeepers(ctx context.Context, rsr RepoStateReader, ddb *doltdb.DoltDB)
 		keepers = append(keepers, ch, pmw)
 	}
 
+	if rsr.IsRebaseActive() {
+		// the commits which have not been replayed yet are only reachable from the original head of the branch
+		keepers = append(keepers, hash.Parse(rsr.GetRebaseOrigHead()))
+	}
+
 	return keepers, nil
 }
 
@@ -0,0 +1,65 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var ErrCommitHasNoParent = errors.New("commit has no parent")
+var ErrCommitIsMerge = errors.New("commit is a merge commit")
+
+// CherryPick applies the changes introduced by |cm|, relative to its parent, to |ourRoot|. The commit's parent is
+// used as the ancestor of a three-way merge between |ourRoot| and the commit's root, so any rows changed in both
+// are recorded as conflicts on the resulting root.
+func CherryPick(ctx context.Context, ddb *doltdb.DoltDB, ourRoot *doltdb.RootValue, cm *doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	parentRoot, err := getSingleParentRoot(ctx, ddb, cm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	theirRoot, err := cm.GetRootValue()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return MergeRoots(ctx, ourRoot, theirRoot, parentRoot)
+}
+
+// getSingleParentRoot returns the root value of the only parent of |cm|. Root commits and merge commits are rejected
+// as there is no single set of changes to apply for them.
+func getSingleParentRoot(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) (*doltdb.RootValue, error) {
+	numParents, err := cm.NumParents()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case numParents == 0:
+		return nil, ErrCommitHasNoParent
+	case numParents > 1:
+		return nil, ErrCommitIsMerge
+	}
+
+	parent, err := ddb.ResolveParent(ctx, cm, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return parent.GetRootValue()
+}
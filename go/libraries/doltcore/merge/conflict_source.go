@@ -17,6 +17,7 @@ package merge
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -133,8 +134,8 @@ func (cr *ConflictReader) NextConflict(ctx context.Context) (row.Row, pipeline.I
 		}
 	}
 
-	if !types.IsNull(conflict.Value) {
-		namedRows[oursStr], err = row.FromNoms(cr.joiner.SchemaForName(oursStr), keyTpl, conflict.Value.(types.Tuple))
+	if ourVal := conflict.OurValue(); !types.IsNull(ourVal) {
+		namedRows[oursStr], err = row.FromNoms(cr.joiner.SchemaForName(oursStr), keyTpl, ourVal.(types.Tuple))
 
 		if err != nil {
 			return nil, pipeline.NoProps, err
@@ -179,6 +180,56 @@ func (cr *ConflictReader) GetKeyForConflict(ctx context.Context, r row.Row) (typ
 	return nil, errors.New("could not determine key")
 }
 
+// GetOurValueForConflict returns our value for the conflict row |r| with the primary key |key|. Values of the our_
+// columns which are NULL in |r| are NULL in the returned value, even if our side of the conflict deleted the row.
+func (cr *ConflictReader) GetOurValueForConflict(ctx context.Context, key types.Value, r row.Row) (types.Value, error) {
+	ourSch := cr.joiner.SchemaForName(oursStr)
+	taggedVals, err := row.ParseTaggedValues(key.(types.Tuple))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cr.joiner.Split(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ourRow, ok := rows[oursStr]; ok {
+		err = ourSch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
+			if val, ok := ourRow.GetColVal(tag); ok {
+				taggedVals[tag] = val
+			}
+			return false, nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ourRow, err := row.New(cr.nbf, ourSch, taggedVals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if isValid, err := row.IsValid(ourRow, ourSch); err != nil {
+		return nil, err
+	} else if !isValid {
+		col, err := row.GetInvalidCol(ourRow, ourSch)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("invalid value for column %s%s", oursStr+"_", col.Name)
+	}
+
+	return ourRow.NomsMapValue(ourSch).Value(ctx)
+}
+
 // Close should release resources being held
 func (cr *ConflictReader) Close() error {
 	return nil
@@ -81,6 +81,26 @@ func TestMerge(t *testing.T) {
 				{int32(22), int32(22)},
 			},
 		},
+		{
+			name: "three-way merge of an index added on other",
+			setup: []testCommand{
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (11,11),(22,22);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "CREATE INDEX c0_idx ON test (c0);"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,2);"}},
+				{cmd.CommitCmd{}, args{"-am", "added index and rows on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM test WHERE c0 > 1 ORDER BY pk",
+			expected: []sql.Row{
+				{int32(2), int32(2)},
+				{int32(11), int32(11)},
+				{int32(22), int32(22)},
+			},
+		},
 		{
 			name: "create the same table schema, with different row data, on two branches",
 			setup: []testCommand{
@@ -244,3 +264,216 @@ func TestMergeConflicts(t *testing.T) {
 		})
 	}
 }
+
+func TestCherryPick(t *testing.T) {
+
+	setupCommon := []testCommand{
+		{cmd.SqlCmd{}, args{"-q", "CREATE TABLE test (pk int PRIMARY KEY, c0 int);"}},
+		{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,2);"}},
+		{cmd.CommitCmd{}, args{"-am", "created table test"}},
+		{cmd.CheckoutCmd{}, args{"-b", "other"}},
+		{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (3,3);"}},
+		{cmd.CommitCmd{}, args{"-am", "added row 3 on other"}},
+		{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 22 WHERE pk = 2;"}},
+		{cmd.CommitCmd{}, args{"-am", "updated row 2 on other"}},
+		{cmd.CheckoutCmd{}, args{"master"}},
+	}
+
+	tests := []struct {
+		name  string
+		setup []testCommand
+
+		query    string
+		expected []sql.Row
+	}{
+		{
+			name: "cherry-pick the tip of a branch",
+			setup: []testCommand{
+				{cmd.CherryPickCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(22)},
+			},
+		},
+		{
+			name: "cherry-pick an ancestor of the tip of a branch",
+			setup: []testCommand{
+				{cmd.CherryPickCmd{}, args{"other~1"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(2)},
+				{int32(3), int32(3)},
+			},
+		},
+		{
+			name: "cherry-pick creates a commit",
+			setup: []testCommand{
+				{cmd.CherryPickCmd{}, args{"other"}},
+			},
+			query: "SELECT COUNT(*) FROM dolt_log WHERE message = 'updated row 2 on other'",
+			expected: []sql.Row{
+				{int64(1)},
+			},
+		},
+		{
+			name: "cherry-pick with conflicts",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 222 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row 2 on master"}},
+				{cmd.CherryPickCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM dolt_conflicts",
+			expected: []sql.Row{
+				{"test", uint64(1)},
+			},
+		},
+		{
+			name: "cherry-pick with conflicts, resolve with theirs",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 222 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row 2 on master"}},
+				{cmd.CherryPickCmd{}, args{"other"}},
+				{cnfcmds.ResolveCmd{}, args{"--theirs", "test"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(22)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := dtu.CreateTestEnv()
+
+			for _, tc := range setupCommon {
+				tc.exec(t, ctx, dEnv)
+			}
+			for _, tc := range test.setup {
+				tc.exec(t, ctx, dEnv)
+			}
+
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			actRows, err := sqle.ExecuteSelect(dEnv, dEnv.DoltDB, root, test.query)
+			require.NoError(t, err)
+
+			require.Equal(t, len(test.expected), len(actRows))
+			for i := range test.expected {
+				assert.Equal(t, test.expected[i], actRows[i])
+			}
+		})
+	}
+}
+
+func TestRevert(t *testing.T) {
+
+	setupCommon := []testCommand{
+		{cmd.SqlCmd{}, args{"-q", "CREATE TABLE test (pk int PRIMARY KEY, c0 int);"}},
+		{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,2);"}},
+		{cmd.CommitCmd{}, args{"-am", "created table test"}},
+		{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (3,3);"}},
+		{cmd.CommitCmd{}, args{"-am", "added row 3"}},
+		{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 22 WHERE pk = 2;"}},
+		{cmd.CommitCmd{}, args{"-am", "updated row 2"}},
+	}
+
+	tests := []struct {
+		name  string
+		setup []testCommand
+
+		query    string
+		expected []sql.Row
+	}{
+		{
+			name: "revert HEAD",
+			setup: []testCommand{
+				{cmd.RevertCmd{}, args{"HEAD"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(2)},
+				{int32(3), int32(3)},
+			},
+		},
+		{
+			name: "revert an older commit",
+			setup: []testCommand{
+				{cmd.RevertCmd{}, args{"HEAD~1"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(22)},
+			},
+		},
+		{
+			name: "revert multiple commits",
+			setup: []testCommand{
+				{cmd.RevertCmd{}, args{"HEAD", "HEAD~1"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(2)},
+			},
+		},
+		{
+			name: "revert a schema change",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "ALTER TABLE test ADD COLUMN c1 int;"}},
+				{cmd.CommitCmd{}, args{"-am", "added column c1"}},
+				{cmd.RevertCmd{}, args{"HEAD"}},
+			},
+			query: "SELECT * FROM test",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(22)},
+				{int32(3), int32(3)},
+			},
+		},
+		{
+			name: "revert with conflicts",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "UPDATE test SET c0 = 222 WHERE pk = 2;"}},
+				{cmd.CommitCmd{}, args{"-am", "updated row 2 again"}},
+				{cmd.RevertCmd{}, args{"HEAD~1"}},
+			},
+			query: "SELECT * FROM dolt_conflicts",
+			expected: []sql.Row{
+				{"test", uint64(1)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := dtu.CreateTestEnv()
+
+			for _, tc := range setupCommon {
+				tc.exec(t, ctx, dEnv)
+			}
+			for _, tc := range test.setup {
+				tc.exec(t, ctx, dEnv)
+			}
+
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			actRows, err := sqle.ExecuteSelect(dEnv, dEnv.DoltDB, root, test.query)
+			require.NoError(t, err)
+
+			require.Equal(t, len(test.expected), len(actRows))
+			for i := range test.expected {
+				assert.Equal(t, test.expected[i], actRows[i])
+			}
+		})
+	}
+}
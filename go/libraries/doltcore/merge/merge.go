@@ -139,6 +139,11 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		}
 
 		if h == anch {
+			if !mergeOk {
+				// table was deleted in the merge root and left unmodified in ours
+				return nil, &MergeStats{Operation: TableRemoved}, nil
+			}
+
 			// fast-forward
 			ms := MergeStats{Operation: TableModified}
 			if h != mh {
@@ -159,13 +164,46 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		}
 	}
 
+	if schema.IsKeyless(tblSchema) != schema.IsKeyless(ancTblSchema) || schema.IsKeyless(mergeTblSchema) != schema.IsKeyless(ancTblSchema) {
+		return nil, nil, ErrKeylessPkChange
+	}
+
+	tbl, mergeTbl, ancTbl, retagged, err := retagForPkChange(ctx, tbl, mergeTbl, ancTbl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if retagged {
+		tblSchema, err = tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		mergeTblSchema, err = mergeTbl.GetSchema(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ancTblSchema, err = ancTbl.GetSchema(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ancRows, err = ancTbl.GetRowData(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	postMergeSchema, schConflicts, err := SchemaMerge(tblSchema, mergeTblSchema, ancTblSchema, tblName)
 	if err != nil {
 		return nil, nil, err
 	}
 	if schConflicts.Count() != 0 {
-		// error on schema conflicts for now
-		return nil, nil, schConflicts.AsError()
+		// the table is left as it is on our side until its schema conflicts are resolved
+		resultTbl, err := tbl.SetSchemaConflicts(ctx, mergeTbl, ancTbl)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resultTbl, &MergeStats{Operation: TableModified, SchemaConflicts: schConflicts.Count()}, nil
 	}
 
 	rows, err := tbl.GetRowData(ctx)
@@ -178,11 +216,62 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
+	// rows keyed by a primary key other than the merged one are re-keyed, and the rows whose new keys are ambiguous are
+	// left out of the merge and recorded as conflicts
+	var oursAmbiguous, theirsAmbiguous, ancAmbiguous ambiguousRows
+	pkTags := postMergeSchema.GetPKCols().Tags
+	oursRekeyed := !pkTagsEqual(tblSchema, postMergeSchema)
+	if oursRekeyed {
+		rows, oursAmbiguous, err = remapRows(ctx, merger.vrw, rows, nil, pkTags)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	theirsRekeyed := !pkTagsEqual(mergeTblSchema, postMergeSchema)
+	if theirsRekeyed {
+		mergeRows, theirsAmbiguous, err = remapRows(ctx, merger.vrw, mergeRows, nil, pkTags)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ancRekeyed := !pkTagsEqual(ancTblSchema, postMergeSchema)
+	if ancRekeyed {
+		ancRows, ancAmbiguous, err = remapRows(ctx, merger.vrw, ancRows, nil, pkTags)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var ambiguousConflicts types.Map
+	hasAmbiguous := len(oursAmbiguous)+len(theirsAmbiguous)+len(ancAmbiguous) > 0
+	if hasAmbiguous {
+		rows, mergeRows, ancRows, ambiguousConflicts, err = setAsideAmbiguousRows(ctx, merger.vrw, rows, mergeRows, ancRows, oursAmbiguous, theirsAmbiguous, ancAmbiguous)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	updatedTbl, err := tbl.UpdateSchema(ctx, postMergeSchema)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if oursRekeyed {
+		updatedTbl, err = updatedTbl.UpdateRows(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		updatedTbl, err = editor.RebuildAllIndexes(ctx, updatedTbl)
+	} else {
+		updatedTbl, err = buildMissingIndexes(ctx, updatedTbl, postMergeSchema)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
 	err = sess.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
 		return root.PutTable(ctx, tblName, updatedTbl)
 	})
@@ -195,11 +284,33 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 
-	resultTbl, conflicts, stats, err := mergeTableData(ctx, merger.vrw, tblName, postMergeSchema, rows, mergeRows, ancRows, updatedTblEditor, sess)
+	policies, err := getMergePolicies(ctx, merger.root, tblName, postMergeSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resultTbl, conflicts, stats, err := mergeTableData(ctx, merger.vrw, tblName, postMergeSchema, rows, mergeRows, ancRows, policies, updatedTblEditor, sess)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if hasAmbiguous && ambiguousConflicts.Len() > 0 {
+		stats.Conflicts += int(ambiguousConflicts.Len())
+		cnfEd := conflicts.Edit()
+		err = ambiguousConflicts.IterAll(ctx, func(key, value types.Value) error {
+			cnfEd.Set(key, value)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		conflicts, err = cnfEd.Map(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if conflicts.Len() > 0 {
 
 		asr, err := ancTbl.GetSchemaRef()
@@ -217,6 +328,21 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 			return nil, nil, err
 		}
 
+		// the conflicting rows of the re-keyed tables are keyed by the merged primary key
+		psr, err := updatedTbl.GetSchemaRef()
+		if err != nil {
+			return nil, nil, err
+		}
+		if ancRekeyed {
+			asr = psr
+		}
+		if oursRekeyed {
+			sr = psr
+		}
+		if theirsRekeyed {
+			msr = psr
+		}
+
 		schemas := doltdb.NewConflict(asr, sr, msr)
 		resultTbl, err = resultTbl.SetConflicts(ctx, schemas, conflicts)
 		if err != nil {
@@ -232,6 +358,35 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 	return resultTbl, stats, nil
 }
 
+// buildMissingIndexes builds the data of the indexes of |sch| which |tbl| has no data for, such as indexes which were
+// added on their side of the merge.
+func buildMissingIndexes(ctx context.Context, tbl *doltdb.Table, sch schema.Schema) (*doltdb.Table, error) {
+	indexData, err := tbl.GetIndexData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range sch.Indexes().AllIndexes() {
+		if has, err := indexData.Has(ctx, types.String(index.Name())); err != nil {
+			return nil, err
+		} else if has {
+			continue
+		}
+
+		indexRowData, err := editor.RebuildIndex(ctx, tbl, index.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, err = tbl.SetIndexRowData(ctx, index.Name(), indexRowData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tbl, nil
+}
+
 func calcTableMergeStats(ctx context.Context, tbl *doltdb.Table, mergeTbl *doltdb.Table) (MergeStats, error) {
 	rows, err := tbl.GetRowData(ctx)
 
@@ -276,12 +431,17 @@ type rowMerger func(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Sc
 
 type applicator func(ctx context.Context, sch schema.Schema, tableEditor editor.TableEditor, rowData types.Map, stats *MergeStats, change types.ValueChanged) error
 
-func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, tblEdit editor.TableEditor, sess *editor.TableEditSession) (*doltdb.Table, types.Map, *MergeStats, error) {
+// mergeTableData merges the rows of a table. Columns changed to different values on both sides of the merge are
+// resolved by their merge policies in |policies| before they are recorded as conflicts.
+func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName string, sch schema.Schema, rows, mergeRows, ancRows types.Map, policies mergePolicies, tblEdit editor.TableEditor, sess *editor.TableEditSession) (*doltdb.Table, types.Map, *MergeStats, error) {
 	var rowMerge rowMerger
 	var applyChange applicator
 	if schema.IsKeyless(sch) {
 		rowMerge = keylessRowMerge
 		applyChange = applyKeylessChange
+	} else if len(policies) > 0 {
+		rowMerge = pkRowMergeWithPolicies(policies)
+		applyChange = applyPkChange
 	} else {
 		rowMerge = pkRowMerge
 		applyChange = applyPkChange
@@ -606,6 +766,17 @@ func convertValueChanged(vc types.ValueChanged) (types.ValueChanged, uint64, err
 }
 
 func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+	return mergePkRow(ctx, nbf, sch, r, mergeRow, baseRow, nil)
+}
+
+// pkRowMergeWithPolicies returns a rowMerger which resolves columns modified on both sides of a merge with |policies|.
+func pkRowMergeWithPolicies(policies mergePolicies) rowMerger {
+	return func(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+		return mergePkRow(ctx, nbf, sch, r, mergeRow, baseRow, policies)
+	}
+}
+
+func mergePkRow(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value, policies mergePolicies) (types.Value, bool, error) {
 	var baseVals row.TaggedValues
 	if baseRow == nil {
 		if r.Equals(mergeRow) {
@@ -637,23 +808,32 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 		return nil, false, err
 	}
 
-	processTagFunc := func(tag uint64) (resultVal types.Value, isConflict bool) {
+	processTagFunc := func(tag uint64, col schema.Column) (resultVal types.Value, isConflict bool, err error) {
 		baseVal, _ := baseVals.Get(tag)
 		val, _ := rowVals.Get(tag)
 		mergeVal, _ := mergeVals.Get(tag)
 
 		if valutil.NilSafeEqCheck(val, mergeVal) {
-			return val, false
+			if policy, ok := policies[tag]; ok && policy.policy == PolicySum && !valutil.NilSafeEqCheck(val, baseVal) {
+				// both sides made the same change to the value, and both changes are applied
+				resolved, ok, err := policy.resolve(nbf, col, baseVal, val, mergeVal, rowVals, mergeVals)
+				return resolved, !ok, err
+			}
+			return val, false, nil
 		} else {
 			modified := !valutil.NilSafeEqCheck(val, baseVal)
 			mergeModified := !valutil.NilSafeEqCheck(mergeVal, baseVal)
 			switch {
 			case modified && mergeModified:
-				return nil, true
+				if policy, ok := policies[tag]; ok {
+					resolved, ok, err := policy.resolve(nbf, col, baseVal, val, mergeVal, rowVals, mergeVals)
+					return resolved, !ok, err
+				}
+				return nil, true, nil
 			case modified:
-				return val, false
+				return val, false, nil
 			default:
-				return mergeVal, false
+				return mergeVal, false, nil
 			}
 		}
 
@@ -662,12 +842,12 @@ func pkRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema
 	resultVals := make(row.TaggedValues)
 
 	var isConflict bool
-	err = sch.GetNonPKCols().Iter(func(tag uint64, _ schema.Column) (stop bool, err error) {
+	err = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		var val types.Value
-		val, isConflict = processTagFunc(tag)
+		val, isConflict, err = processTagFunc(tag, col)
 		resultVals[tag] = val
 
-		return isConflict, nil
+		return isConflict || err != nil, err
 	})
 
 	if err != nil {
@@ -0,0 +1,332 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// Merge policies resolve a column which was changed to different values on both sides of a merge, which would
+// otherwise be a conflict. They are configured per table and column in the dolt_merge_policies table.
+const (
+	// PolicyOurs takes the value from our side of the merge
+	PolicyOurs = "ours"
+	// PolicyTheirs takes the value from their side of the merge
+	PolicyTheirs = "theirs"
+	// PolicySum applies the changes made by both sides to a numeric value, i.e. ours + theirs - ancestor
+	PolicySum = "sum"
+	// PolicyPreferNonNull takes the value from the side which did not set it to NULL
+	PolicyPreferNonNull = "prefer_non_null"
+	// PolicyMax takes the greater value. NULLs are ignored.
+	PolicyMax = "max"
+	// PolicyMin takes the lesser value. NULLs are ignored.
+	PolicyMin = "min"
+	// PolicyLatest takes the value from the side with the greater value in the policy column, e.g. a last modified
+	// timestamp. NULLs are ignored.
+	PolicyLatest = "latest"
+)
+
+// columnPolicy is the merge policy of a single column
+type columnPolicy struct {
+	policy string
+	// policyTag is the tag of the column compared by PolicyLatest
+	policyTag uint64
+}
+
+// mergePolicies maps the tags of the columns of a table to their merge policies
+type mergePolicies map[uint64]columnPolicy
+
+// getMergePolicies returns the merge policies for the non primary key columns of |sch|, the schema of the table named
+// |tblName|, read from the dolt_merge_policies table in |root|. Policies for columns which are not in |sch| are
+// ignored, as the columns may have been dropped.
+func getMergePolicies(ctx context.Context, root *doltdb.RootValue, tblName string, sch schema.Schema) (mergePolicies, error) {
+	policyTbl, ok, err := root.GetTable(ctx, doltdb.MergePoliciesTableName)
+
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	policySch, err := policyTbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := policyTbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var allColsPolicy *columnPolicy
+	policies := make(mergePolicies)
+	err = m.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(policySch, key.(types.Tuple), value.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(policyRowString(r, schema.DoltMergePoliciesTableNameTag), tblName) {
+			return nil
+		}
+
+		colName := policyRowString(r, schema.DoltMergePoliciesColumnNameTag)
+		cp, err := newColumnPolicy(tblName, colName, r, sch)
+
+		if err != nil {
+			return err
+		}
+
+		if colName == doltdb.MergePoliciesAllColumns {
+			allColsPolicy = &cp
+		} else if col, ok := sch.GetNonPKCols().GetByNameCaseInsensitive(colName); ok {
+			if cp.policy == PolicySum && !isNumericKind(col.Kind) {
+				return fmt.Errorf("%s: merge policy '%s' for %s.%s requires a numeric column", doltdb.MergePoliciesTableName, PolicySum, tblName, colName)
+			}
+
+			policies[col.Tag] = cp
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if allColsPolicy != nil {
+		_ = sch.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+			_, ok := policies[tag]
+
+			// a sum policy for every column only applies to the numeric ones
+			if !ok && (allColsPolicy.policy != PolicySum || isNumericKind(col.Kind)) {
+				policies[tag] = *allColsPolicy
+			}
+
+			return false, nil
+		})
+	}
+
+	return policies, nil
+}
+
+// newColumnPolicy validates the merge policy in the dolt_merge_policies row |r| for the column |colName| of the table
+// |tblName| with the schema |sch|.
+func newColumnPolicy(tblName, colName string, r row.Row, sch schema.Schema) (columnPolicy, error) {
+	policy := strings.ToLower(policyRowString(r, schema.DoltMergePoliciesPolicyTag))
+
+	switch policy {
+	case PolicyOurs, PolicyTheirs, PolicySum, PolicyPreferNonNull, PolicyMax, PolicyMin:
+		return columnPolicy{policy: policy}, nil
+	case PolicyLatest:
+		policyColName := policyRowString(r, schema.DoltMergePoliciesPolicyColumnTag)
+		policyCol, ok := sch.GetAllCols().GetByNameCaseInsensitive(policyColName)
+
+		if !ok {
+			return columnPolicy{}, fmt.Errorf("%s: merge policy '%s' for %s.%s requires a %s in table %s, found '%s'",
+				doltdb.MergePoliciesTableName, PolicyLatest, tblName, colName, doltdb.MergePoliciesPolicyColumnCol, tblName, policyColName)
+		}
+
+		return columnPolicy{policy: policy, policyTag: policyCol.Tag}, nil
+	default:
+		return columnPolicy{}, fmt.Errorf("%s: unknown merge policy '%s' for %s.%s", doltdb.MergePoliciesTableName, policy, tblName, colName)
+	}
+}
+
+func policyRowString(r row.Row, tag uint64) string {
+	val, ok := r.GetColVal(tag)
+
+	if !ok || types.IsNull(val) {
+		return ""
+	}
+
+	return string(val.(types.String))
+}
+
+func isNumericKind(kind types.NomsKind) bool {
+	switch kind {
+	case types.IntKind, types.UintKind, types.FloatKind, types.DecimalKind:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolve resolves a column which was changed from |baseVal| to |val| on our side of a merge, and to |mergeVal| on
+// their side. |rowVals| and |mergeVals| are the values of both rows. It returns false if the policy can't resolve the
+// change and it remains a conflict.
+func (cp columnPolicy) resolve(nbf *types.NomsBinFormat, col schema.Column, baseVal, val, mergeVal types.Value, rowVals, mergeVals row.TaggedValues) (types.Value, bool, error) {
+	var resolved types.Value
+	switch cp.policy {
+	case PolicyOurs:
+		return val, true, nil
+	case PolicyTheirs:
+		return mergeVal, true, nil
+	case PolicyPreferNonNull:
+		if types.IsNull(val) {
+			return mergeVal, true, nil
+		} else if types.IsNull(mergeVal) {
+			return val, true, nil
+		}
+
+		return nil, false, nil
+	case PolicyMax, PolicyMin:
+		takeMerge, ok, err := compareForPolicy(nbf, val, mergeVal, cp.policy == PolicyMax)
+
+		if err != nil || !ok {
+			return nil, false, err
+		} else if takeMerge {
+			return mergeVal, true, nil
+		}
+
+		return val, true, nil
+	case PolicyLatest:
+		ts, _ := rowVals.Get(cp.policyTag)
+		mergeTs, _ := mergeVals.Get(cp.policyTag)
+		takeMerge, ok, err := compareForPolicy(nbf, ts, mergeTs, true)
+
+		if err != nil || !ok {
+			return nil, false, err
+		} else if takeMerge {
+			return mergeVal, true, nil
+		}
+
+		return val, true, nil
+	case PolicySum:
+		var ok bool
+		resolved, ok = sumDeltas(baseVal, val, mergeVal)
+
+		if !ok {
+			return nil, false, nil
+		}
+	default:
+		panic("unknown merge policy " + cp.policy)
+	}
+
+	// the result of a sum may not fit in the column's type, in which case it is left as a conflict
+	if col.TypeInfo != nil && !col.TypeInfo.IsValid(resolved) {
+		return nil, false, nil
+	}
+
+	return resolved, true, nil
+}
+
+// compareForPolicy returns whether |mergeVal| should be taken over |val|, by taking the greater value if |greater| is
+// true, or the lesser one if it is false. A NULL value loses to a non-NULL value. It returns false for |ok| if the
+// values are equal, or both are NULL.
+func compareForPolicy(nbf *types.NomsBinFormat, val, mergeVal types.Value, greater bool) (takeMerge bool, ok bool, err error) {
+	if types.IsNull(val) && types.IsNull(mergeVal) {
+		return false, false, nil
+	} else if types.IsNull(val) {
+		return true, true, nil
+	} else if types.IsNull(mergeVal) {
+		return false, true, nil
+	} else if val.Equals(mergeVal) {
+		return false, false, nil
+	}
+
+	less, err := val.Less(nbf, mergeVal)
+
+	if err != nil {
+		return false, false, err
+	}
+
+	return less == greater, true, nil
+}
+
+// sumDeltas returns |val| + |mergeVal| - |baseVal|, treating a NULL |baseVal| as zero. It returns false if either side
+// is NULL, the values are not numeric, or the result overflows.
+func sumDeltas(baseVal, val, mergeVal types.Value) (types.Value, bool) {
+	if types.IsNull(val) || types.IsNull(mergeVal) {
+		return nil, false
+	}
+
+	if types.IsNull(baseVal) {
+		baseVal = types.KindToType[val.Kind()]
+	}
+
+	switch v := val.(type) {
+	case types.Int:
+		mv, mok := mergeVal.(types.Int)
+		bv, bok := baseVal.(types.Int)
+		if !mok || !bok {
+			return nil, false
+		}
+
+		delta := int64(mv) - int64(bv)
+		if (int64(mv) < int64(bv)) != (delta < 0) {
+			return nil, false
+		}
+
+		sum := int64(v) + delta
+		if (delta > 0 && sum < int64(v)) || (delta < 0 && sum > int64(v)) {
+			return nil, false
+		}
+
+		return types.Int(sum), true
+	case types.Uint:
+		mv, mok := mergeVal.(types.Uint)
+		bv, bok := baseVal.(types.Uint)
+		if !mok || !bok {
+			return nil, false
+		}
+
+		if mv >= bv {
+			delta := uint64(mv) - uint64(bv)
+			if uint64(v) > math.MaxUint64-delta {
+				return nil, false
+			}
+
+			return types.Uint(uint64(v) + delta), true
+		}
+
+		delta := uint64(bv) - uint64(mv)
+		if uint64(v) < delta {
+			return nil, false
+		}
+
+		return types.Uint(uint64(v) - delta), true
+	case types.Float:
+		mv, mok := mergeVal.(types.Float)
+		bv, bok := baseVal.(types.Float)
+		if !mok || !bok {
+			return nil, false
+		}
+
+		return types.Float(float64(v) + float64(mv) - float64(bv)), true
+	case types.Decimal:
+		mv, mok := mergeVal.(types.Decimal)
+		bv, bok := baseVal.(types.Decimal)
+		if !mok || !bok {
+			return nil, false
+		}
+
+		sum := decimal.Decimal(v).Add(decimal.Decimal(mv)).Sub(decimal.Decimal(bv))
+		return types.Decimal(sum), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestRowMergeWithPolicies(t *testing.T) {
+	tests := []struct {
+		RowMergeTest
+		policies mergePolicies
+	}{
+		{
+			createRowMergeStruct(
+				"no policy",
+				[]types.Value{types.String("two"), types.Int(2)},
+				[]types.Value{types.String("three"), types.Int(2)},
+				[]types.Value{types.String("one"), types.Int(2)},
+				nil,
+				true,
+			),
+			mergePolicies{2: {policy: PolicyOurs}},
+		},
+		{
+			createRowMergeStruct(
+				"ours",
+				[]types.Value{types.String("two"), types.Int(2)},
+				[]types.Value{types.String("three"), types.Int(3)},
+				[]types.Value{types.String("one"), types.Int(1)},
+				[]types.Value{types.String("two"), types.Int(2)},
+				false,
+			),
+			mergePolicies{1: {policy: PolicyOurs}, 2: {policy: PolicyOurs}},
+		},
+		{
+			createRowMergeStruct(
+				"theirs",
+				[]types.Value{types.String("two"), types.Int(2)},
+				[]types.Value{types.String("three"), types.Int(2)},
+				[]types.Value{types.String("one"), types.Int(1)},
+				[]types.Value{types.String("three"), types.Int(2)},
+				false,
+			),
+			mergePolicies{1: {policy: PolicyTheirs}},
+		},
+		{
+			createRowMergeStruct(
+				"sum",
+				[]types.Value{types.String("one"), types.Int(12)},
+				[]types.Value{types.String("one"), types.Int(15)},
+				[]types.Value{types.String("one"), types.Int(10)},
+				[]types.Value{types.String("one"), types.Int(17)},
+				false,
+			),
+			mergePolicies{2: {policy: PolicySum}},
+		},
+		{
+			createRowMergeStruct(
+				"sum of the same change",
+				[]types.Value{types.String("one"), types.Uint(12)},
+				[]types.Value{types.String("one"), types.Uint(12)},
+				[]types.Value{types.String("one"), types.Uint(10)},
+				[]types.Value{types.String("one"), types.Uint(14)},
+				false,
+			),
+			mergePolicies{2: {policy: PolicySum}},
+		},
+		{
+			createRowMergeStruct(
+				"sum with null",
+				[]types.Value{types.String("one"), types.NullValue},
+				[]types.Value{types.String("one"), types.Float(15)},
+				[]types.Value{types.String("one"), types.Float(10)},
+				nil,
+				true,
+			),
+			mergePolicies{2: {policy: PolicySum}},
+		},
+		{
+			createRowMergeStruct(
+				"prefer non-null",
+				[]types.Value{types.String("one"), types.NullValue},
+				[]types.Value{types.String("one"), types.Int(3)},
+				[]types.Value{types.String("one"), types.Int(2)},
+				[]types.Value{types.String("one"), types.Int(3)},
+				false,
+			),
+			mergePolicies{2: {policy: PolicyPreferNonNull}},
+		},
+		{
+			createRowMergeStruct(
+				"prefer non-null with two values",
+				[]types.Value{types.String("one"), types.Int(4)},
+				[]types.Value{types.String("one"), types.Int(3)},
+				[]types.Value{types.String("one"), types.Int(2)},
+				nil,
+				true,
+			),
+			mergePolicies{2: {policy: PolicyPreferNonNull}},
+		},
+		{
+			createRowMergeStruct(
+				"max and min",
+				[]types.Value{types.String("b"), types.Int(4)},
+				[]types.Value{types.String("c"), types.Int(3)},
+				[]types.Value{types.String("a"), types.Int(2)},
+				[]types.Value{types.String("c"), types.Int(3)},
+				false,
+			),
+			mergePolicies{1: {policy: PolicyMax}, 2: {policy: PolicyMin}},
+		},
+		{
+			createRowMergeStruct(
+				"latest",
+				[]types.Value{types.String("two"), types.Int(200)},
+				[]types.Value{types.String("three"), types.Int(300)},
+				[]types.Value{types.String("one"), types.Int(100)},
+				[]types.Value{types.String("three"), types.Int(300)},
+				false,
+			),
+			mergePolicies{1: {policy: PolicyLatest, policyTag: 2}, 2: {policy: PolicyLatest, policyTag: 2}},
+		},
+		{
+			createRowMergeStruct(
+				"latest with equal timestamps",
+				[]types.Value{types.String("two"), types.Int(200)},
+				[]types.Value{types.String("three"), types.Int(200)},
+				[]types.Value{types.String("one"), types.Int(100)},
+				nil,
+				true,
+			),
+			mergePolicies{1: {policy: PolicyLatest, policyTag: 2}},
+		},
+		{
+			createRowMergeStruct(
+				"one delete one modify",
+				nil,
+				[]types.Value{types.String("two"), types.Int(2)},
+				[]types.Value{types.String("one"), types.Int(2)},
+				nil,
+				true,
+			),
+			mergePolicies{1: {policy: PolicyTheirs}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rowMerge := pkRowMergeWithPolicies(test.policies)
+			actualResult, isConflict, err := rowMerge(context.Background(), types.Format_7_18, test.sch, test.row, test.mergeRow, test.ancRow)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResult, actualResult)
+			assert.Equal(t, test.expectConflict, isConflict)
+		})
+	}
+}
+
+func TestSumDeltas(t *testing.T) {
+	tests := []struct {
+		name                   string
+		baseVal, val, mergeVal types.Value
+		expected               types.Value
+		expectedOk             bool
+	}{
+		{"int", types.Int(10), types.Int(8), types.Int(15), types.Int(13), true},
+		{"null base", types.NullValue, types.Int(8), types.Int(15), types.Int(23), true},
+		{"int overflow", types.Int(0), types.Int(math.MaxInt64), types.Int(1), nil, false},
+		{"int underflow", types.Int(1), types.Int(math.MinInt64), types.Int(0), nil, false},
+		{"uint", types.Uint(10), types.Uint(8), types.Uint(5), types.Uint(3), true},
+		{"uint underflow", types.Uint(10), types.Uint(4), types.Uint(5), nil, false},
+		{"uint overflow", types.Uint(0), types.Uint(math.MaxUint64), types.Uint(1), nil, false},
+		{"float", types.Float(1), types.Float(1.5), types.Float(2.5), types.Float(3), true},
+		{"string", types.String("a"), types.String("b"), types.String("c"), nil, false},
+		{"null", types.Int(10), types.NullValue, types.Int(15), nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, ok := sumDeltas(test.baseVal, test.val, test.mergeVal)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
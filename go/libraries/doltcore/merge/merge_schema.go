@@ -43,6 +43,10 @@ func (sc SchemaConflict) Count() int {
 }
 
 func (sc SchemaConflict) AsError() error {
+	return fmt.Errorf(sc.String())
+}
+
+func (sc SchemaConflict) String() string {
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("schema conflicts for table %s:\n", sc.TableName))
 	for _, c := range sc.ColConflicts {
@@ -51,7 +55,7 @@ func (sc SchemaConflict) AsError() error {
 	for _, c := range sc.IdxConflicts {
 		b.WriteString(fmt.Sprintf("\t%s\n", c.String()))
 	}
-	return fmt.Errorf(b.String())
+	return b.String()
 }
 
 type ColConflict struct {
@@ -75,6 +79,12 @@ type IdxConflict struct {
 }
 
 func (c IdxConflict) String() string {
+	switch c.Kind {
+	case NameCollision:
+		return fmt.Sprintf("two indexes with the name '%s'", c.Ours.Name())
+	case TagCollision:
+		return fmt.Sprintf("different index definitions for our index %s and their index %s", c.Ours.Name(), c.Theirs.Name())
+	}
 	return ""
 }
 
@@ -201,8 +211,9 @@ func mergeColumns(ourCC, theirCC, ancCC *schema.ColCollection) (merged *schema.C
 	var common *schema.ColCollection
 	common, conflicts = columnsInCommon(ourCC, theirCC, ancCC)
 
-	ourNewCols := schema.ColCollectionSetDifference(ourCC, ancCC)
-	theirNewCols := schema.ColCollectionSetDifference(theirCC, ancCC)
+	// columns added identically on both branches are already in common
+	ourNewCols := schema.ColCollectionSetDifference(schema.ColCollectionSetDifference(ourCC, ancCC), common)
+	theirNewCols := schema.ColCollectionSetDifference(schema.ColCollectionSetDifference(theirCC, ancCC), common)
 
 	// check for name conflicts between columns added on each branch since the ancestor
 	_ = ourNewCols.Iter(func(tag uint64, ourCol schema.Column) (stop bool, err error) {
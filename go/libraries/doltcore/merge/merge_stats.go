@@ -29,4 +29,12 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+	// SchemaConflicts is the number of conflicting columns and indexes in the schema of the table. The data of the
+	// table is only merged once they are resolved.
+	SchemaConflicts int
+}
+
+// HasConflicts returns whether the merge of the table left data or schema conflicts.
+func (ms *MergeStats) HasConflicts() bool {
+	return ms.Conflicts > 0 || ms.SchemaConflicts > 0
 }
@@ -0,0 +1,435 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrKeylessPkChange = errors.New("a table whose primary key was added or removed can't be merged")
+
+// pkColsEqual returns whether the primary keys of |sch| and |other| are made up of columns with the same names in the
+// same order.
+func pkColsEqual(sch, other schema.Schema) bool {
+	pkCols, otherPkCols := sch.GetPKCols().GetColumns(), other.GetPKCols().GetColumns()
+
+	if len(pkCols) != len(otherPkCols) {
+		return false
+	}
+
+	for i := range pkCols {
+		if !strings.EqualFold(pkCols[i].Name, otherPkCols[i].Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pkTagsEqual returns whether the primary keys of |sch| and |other| are made up of the same columns in the same order.
+func pkTagsEqual(sch, other schema.Schema) bool {
+	tags, otherTags := sch.GetPKCols().Tags, other.GetPKCols().Tags
+
+	if len(tags) != len(otherTags) {
+		return false
+	}
+
+	for i := range tags {
+		if tags[i] != otherTags[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// retagForPkChange prepares the tables being merged for a change to the primary key of the table on either side of the
+// merge. Changing the primary key of a table requires recreating it, which gives its columns new tags. The columns of
+// the other tables are given the tags of the columns with the same name and kind in the recreated table so that their
+// schemas and rows can be merged. The returned bool is true if any table was changed.
+func retagForPkChange(ctx context.Context, tbl, mergeTbl, ancTbl *doltdb.Table) (*doltdb.Table, *doltdb.Table, *doltdb.Table, bool, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	mergeSch, err := mergeTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	ancSch, err := ancTbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	var target schema.Schema
+	if !pkColsEqual(sch, ancSch) {
+		target = sch
+	} else if !pkColsEqual(mergeSch, ancSch) {
+		target = mergeSch
+	} else {
+		return tbl, mergeTbl, ancTbl, false, nil
+	}
+
+	retagged := false
+	retag := func(tbl *doltdb.Table, sch schema.Schema) (*doltdb.Table, error) {
+		tags := tagMapping(sch, target)
+		if len(tags) == 0 {
+			return tbl, nil
+		}
+
+		retagged = true
+		return retagTable(ctx, tbl, sch, tags)
+	}
+
+	tbl, err = retag(tbl, sch)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	mergeTbl, err = retag(mergeTbl, mergeSch)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	ancTbl, err = retag(ancTbl, ancSch)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	return tbl, mergeTbl, ancTbl, retagged, nil
+}
+
+// tagMapping maps the tags of the columns of |sch| which aren't in |target| to the tags of the columns in |target| with
+// the same name and kind.
+func tagMapping(sch, target schema.Schema) map[uint64]uint64 {
+	tags := make(map[uint64]uint64)
+	cols, targetCols := sch.GetAllCols(), target.GetAllCols()
+	_ = cols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if _, ok := targetCols.GetByTag(tag); ok {
+			return false, nil
+		}
+
+		targetCol, ok := targetCols.GetByNameCaseInsensitive(col.Name)
+		if !ok || targetCol.Kind != col.Kind {
+			return false, nil
+		}
+
+		if _, ok := cols.GetByTag(targetCol.Tag); !ok {
+			tags[tag] = targetCol.Tag
+		}
+
+		return false, nil
+	})
+
+	return tags
+}
+
+// retagTable changes the tags of the columns of |tbl| according to |tags|, rewriting its rows and indexes.
+func retagTable(ctx context.Context, tbl *doltdb.Table, sch schema.Schema, tags map[uint64]uint64) (*doltdb.Table, error) {
+	var cols []schema.Column
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if newTag, ok := tags[tag]; ok {
+			col.Tag = newTag
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	newSch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		idxTags := make([]uint64, 0, len(idx.IndexedColumnTags()))
+		for _, tag := range idx.IndexedColumnTags() {
+			if newTag, ok := tags[tag]; ok {
+				tag = newTag
+			}
+			idxTags = append(idxTags, tag)
+		}
+
+		_, err = newSch.Indexes().AddIndexByColTags(idx.Name(), idxTags, schema.IndexProperties{
+			IsUnique:      idx.IsUnique(),
+			IsUserDefined: idx.IsUserDefined(),
+			Comment:       idx.Comment(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, _, err = remapRows(ctx, tbl.ValueReadWriter(), rows, tags, newSch.GetPKCols().Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.UpdateSchema(ctx, newSch)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.UpdateRows(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return editor.RebuildAllIndexes(ctx, tbl)
+}
+
+// ambiguousRow holds the rows which were re-keyed to the same key, or to a key which is missing a value.
+type ambiguousRow struct {
+	key  types.Tuple
+	vals []types.Value
+}
+
+// ambiguousRows holds the ambiguous rows of a table by the hash of their key.
+type ambiguousRows map[hash.Hash]*ambiguousRow
+
+// remapRows changes the tags of the values of |rows| according to |tags| and keys the rows by the columns |pkTags|.
+// Only the first row for each key is kept in the returned map. The rows of the keys which more than one row maps to, or
+// which are missing a value for one of the columns, are returned as ambiguous rows.
+func remapRows(ctx context.Context, vrw types.ValueReadWriter, rows types.Map, tags map[uint64]uint64, pkTags []uint64) (types.Map, ambiguousRows, error) {
+	nbf := vrw.Format()
+	isPk := make(map[uint64]bool)
+	for _, tag := range pkTags {
+		isPk[tag] = true
+	}
+
+	remapped, err := types.NewMap(ctx, vrw)
+	if err != nil {
+		return types.EmptyMap, nil, err
+	}
+
+	seen := make(map[hash.Hash]bool)
+	ambiguous := make(ambiguousRows)
+	ed := remapped.Edit()
+	err = rows.IterAll(ctx, func(k, v types.Value) error {
+		tv, err := row.TaggedValuesFromTupleKeyAndValue(k.(types.Tuple), v.(types.Tuple))
+		if err != nil {
+			return err
+		}
+
+		if len(tags) > 0 {
+			retagged := make(row.TaggedValues, len(tv))
+			for tag, val := range tv {
+				if newTag, ok := tags[tag]; ok {
+					tag = newTag
+				}
+				retagged[tag] = val
+			}
+			tv = retagged
+		}
+
+		keyVals := make([]types.Value, 0, 2*len(pkTags))
+		missing := false
+		for _, tag := range pkTags {
+			val, ok := tv[tag]
+			if !ok || types.IsNull(val) {
+				val = types.NullValue
+				missing = true
+			}
+			keyVals = append(keyVals, types.Uint(tag), val)
+		}
+
+		key, err := types.NewTuple(nbf, keyVals...)
+		if err != nil {
+			return err
+		}
+
+		var valTags []uint64
+		for tag, val := range tv {
+			if !isPk[tag] && !types.IsNull(val) {
+				valTags = append(valTags, tag)
+			}
+		}
+		sort.Slice(valTags, func(i, j int) bool { return valTags[i] < valTags[j] })
+
+		valVals := make([]types.Value, 0, 2*len(valTags))
+		for _, tag := range valTags {
+			valVals = append(valVals, types.Uint(tag), tv[tag])
+		}
+
+		val, err := types.NewTuple(nbf, valVals...)
+		if err != nil {
+			return err
+		}
+
+		h, err := key.Hash(nbf)
+		if err != nil {
+			return err
+		}
+
+		if seen[h] {
+			if _, ok := ambiguous[h]; !ok {
+				ambiguous[h] = &ambiguousRow{key: key}
+			}
+			ambiguous[h].vals = append(ambiguous[h].vals, val)
+			return nil
+		}
+
+		seen[h] = true
+		if missing {
+			ambiguous[h] = &ambiguousRow{key: key}
+		}
+
+		ed.Set(key, val)
+		return nil
+	})
+	if err != nil {
+		return types.EmptyMap, nil, err
+	}
+
+	remapped, err = ed.Map(ctx)
+	if err != nil {
+		return types.EmptyMap, nil, err
+	}
+
+	// the first row for each ambiguous key is the one which was kept
+	for _, amb := range ambiguous {
+		first, _, err := remapped.MaybeGet(ctx, amb.key)
+		if err != nil {
+			return types.EmptyMap, nil, err
+		}
+		amb.vals = append([]types.Value{first}, amb.vals...)
+	}
+
+	return remapped, ambiguous, nil
+}
+
+// setAsideAmbiguousRows keeps the ambiguous rows of |rows|, |mergeRows| and |ancRows| out of their merge by making all
+// three match at the ambiguous keys. It returns conflicts for the ambiguous keys at which either side of the merge has
+// a row. Of several rows for the same key, the row of each side which differs from the ancestor's rows is used.
+func setAsideAmbiguousRows(ctx context.Context, vrw types.ValueReadWriter, rows, mergeRows, ancRows types.Map, ours, theirs, anc ambiguousRows) (types.Map, types.Map, types.Map, types.Map, error) {
+	keys := make(map[hash.Hash]types.Tuple)
+	for _, ambiguous := range []ambiguousRows{ours, theirs, anc} {
+		for h, amb := range ambiguous {
+			keys[h] = amb.key
+		}
+	}
+
+	conflicts, err := types.NewMap(ctx, vrw)
+	if err != nil {
+		return types.EmptyMap, types.EmptyMap, types.EmptyMap, types.EmptyMap, err
+	}
+
+	cnfEd, ed, mergeEd, ancEd := conflicts.Edit(), rows.Edit(), mergeRows.Edit(), ancRows.Edit()
+	for h, key := range keys {
+		ancVals, err := rowsAtKey(ctx, anc, ancRows, h, key)
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, types.EmptyMap, types.EmptyMap, err
+		}
+
+		ourVals, err := rowsAtKey(ctx, ours, rows, h, key)
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, types.EmptyMap, types.EmptyMap, err
+		}
+
+		theirVals, err := rowsAtKey(ctx, theirs, mergeRows, h, key)
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, types.EmptyMap, types.EmptyMap, err
+		}
+
+		var ancVal types.Value
+		if len(ancVals) > 0 {
+			ancVal = ancVals[0]
+		}
+		val, mergeVal := changedRow(ourVals, ancVals), changedRow(theirVals, ancVals)
+
+		if val != nil {
+			ed.Set(key, val)
+			mergeEd.Set(key, val)
+			ancEd.Set(key, val)
+		} else {
+			mergeEd.Remove(key)
+			ancEd.Remove(key)
+		}
+
+		if val == nil && mergeVal == nil {
+			continue
+		}
+
+		cnf, err := doltdb.NewConflict(ancVal, val, mergeVal).ToNomsList(vrw)
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, types.EmptyMap, types.EmptyMap, err
+		}
+
+		cnfEd.Set(key, cnf)
+	}
+
+	var maps [4]types.Map
+	for i, ed := range []*types.MapEditor{ed, mergeEd, ancEd, cnfEd} {
+		maps[i], err = ed.Map(ctx)
+		if err != nil {
+			return types.EmptyMap, types.EmptyMap, types.EmptyMap, types.EmptyMap, err
+		}
+	}
+
+	return maps[0], maps[1], maps[2], maps[3], nil
+}
+
+// rowsAtKey returns the rows of a table at |key|, which are either its ambiguous rows or its row in |rows|.
+func rowsAtKey(ctx context.Context, ambiguous ambiguousRows, rows types.Map, h hash.Hash, key types.Tuple) ([]types.Value, error) {
+	if amb, ok := ambiguous[h]; ok {
+		return amb.vals, nil
+	}
+
+	val, ok, err := rows.MaybeGet(ctx, key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return []types.Value{val}, nil
+}
+
+// changedRow returns the first of |vals| which isn't one of |ancVals|, or the first of |vals| if they all are.
+func changedRow(vals, ancVals []types.Value) types.Value {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	for _, val := range vals {
+		changed := true
+		for _, ancVal := range ancVals {
+			if val.Equals(ancVal) {
+				changed = false
+				break
+			}
+		}
+
+		if changed {
+			return val
+		}
+	}
+
+	return vals[0]
+}
@@ -0,0 +1,161 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var setupPkChange = []testCommand{
+	{commands.SqlCmd{}, []string{"-q", "create table t (pk int not null, c1 int not null, c2 int, primary key (pk));"}},
+	{commands.SqlCmd{}, []string{"-q", "insert into t values (1,10,100),(2,20,200),(3,30,300);"}},
+	{commands.AddCmd{}, []string{"."}},
+	{commands.CommitCmd{}, []string{"-m", "setup pk change"}},
+	{commands.BranchCmd{}, []string{"other"}},
+}
+
+// changing the primary key of a table requires recreating it
+var changePkToC1 = []testCommand{
+	{commands.SqlCmd{}, []string{"-q", "create table tmp (pk int not null, c1 int not null, c2 int, primary key (pk));"}},
+	{commands.SqlCmd{}, []string{"-q", "insert into tmp select * from t;"}},
+	{commands.SqlCmd{}, []string{"-q", "drop table t;"}},
+	{commands.SqlCmd{}, []string{"-q", "create table t (pk int not null, c1 int not null, c2 int, primary key (c1));"}},
+	{commands.SqlCmd{}, []string{"-q", "insert into t select * from tmp;"}},
+	{commands.SqlCmd{}, []string{"-q", "drop table tmp;"}},
+	{commands.AddCmd{}, []string{"."}},
+	{commands.CommitCmd{}, []string{"-m", "changed primary key to c1"}},
+}
+
+func withCommands(cmds ...[]testCommand) []testCommand {
+	var all []testCommand
+	for _, c := range cmds {
+		all = append(all, c...)
+	}
+	return all
+}
+
+func TestMergePkChange(t *testing.T) {
+	changeRows := []testCommand{
+		{commands.SqlCmd{}, []string{"-q", "update t set c2 = 201 where pk = 2;"}},
+		{commands.SqlCmd{}, []string{"-q", "insert into t values (4,40,400);"}},
+	}
+	expected := [][]int64{{1, 10, 100}, {2, 20, 201}, {3, 30, 300}, {4, 40, 400}}
+
+	tests := []struct {
+		name         string
+		setup        []testCommand
+		expected     [][]int64
+		expConflicts uint64
+	}{
+		{
+			name: "primary key changed on our branch",
+			setup: withCommands(
+				changePkToC1,
+				[]testCommand{{commands.CheckoutCmd{}, []string{"other"}}},
+				changeRows,
+				[]testCommand{
+					{commands.CommitCmd{}, []string{"-am", "changed rows on other"}},
+					{commands.CheckoutCmd{}, []string{"master"}},
+				},
+			),
+			expected: expected,
+		},
+		{
+			name: "primary key changed on their branch",
+			setup: withCommands(
+				[]testCommand{{commands.CheckoutCmd{}, []string{"other"}}},
+				changePkToC1,
+				[]testCommand{{commands.CheckoutCmd{}, []string{"master"}}},
+				changeRows,
+				[]testCommand{{commands.CommitCmd{}, []string{"-am", "changed rows on master"}}},
+			),
+			expected: expected,
+		},
+		{
+			name: "rows with the same new key conflict",
+			setup: withCommands(
+				changePkToC1,
+				[]testCommand{{commands.CheckoutCmd{}, []string{"other"}}},
+				changeRows,
+				[]testCommand{
+					{commands.SqlCmd{}, []string{"-q", "insert into t values (5,10,500);"}},
+					{commands.CommitCmd{}, []string{"-am", "changed rows on other"}},
+					{commands.CheckoutCmd{}, []string{"master"}},
+				},
+			),
+			expected:     expected,
+			expConflicts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := dtestutils.CreateTestEnv()
+			for _, c := range withCommands(setupPkChange, test.setup) {
+				c.exec(t, ctx, dEnv)
+			}
+
+			commands.MergeCmd{}.Exec(ctx, "merge", []string{"other"}, dEnv)
+
+			root, err := dEnv.WorkingRoot(ctx)
+			require.NoError(t, err)
+			tbl, ok, err := root.GetTable(ctx, "t")
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			sch, err := tbl.GetSchema(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"c1"}, sch.GetPKCols().GetColumnNames())
+
+			rowData, err := tbl.GetRowData(ctx)
+			require.NoError(t, err)
+
+			var actual [][]int64
+			err = rowData.IterAll(ctx, func(k, v types.Value) error {
+				r, err := row.FromNoms(sch, k.(types.Tuple), v.(types.Tuple))
+				if err != nil {
+					return err
+				}
+
+				var vals []int64
+				for _, name := range []string{"pk", "c1", "c2"} {
+					val, _ := row.GetFieldByName(name, r, sch)
+					vals = append(vals, int64(val.(types.Int)))
+				}
+				actual = append(actual, vals)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+
+			numConflicts := uint64(0)
+			if has, err := tbl.HasConflicts(); err == nil && has {
+				numConflicts, err = tbl.NumRowsInConflict(ctx)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expConflicts, numConflicts)
+		})
+	}
+}
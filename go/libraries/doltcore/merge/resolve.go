@@ -29,7 +29,7 @@ import (
 type AutoResolver func(key types.Value, conflict doltdb.Conflict) (types.Value, error)
 
 func Ours(key types.Value, cnf doltdb.Conflict) (types.Value, error) {
-	return cnf.Value, nil
+	return cnf.OurValue(), nil
 }
 
 func Theirs(key types.Value, cnf doltdb.Conflict) (types.Value, error) {
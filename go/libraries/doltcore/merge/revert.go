@@ -0,0 +1,124 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// ErrRevertConflicts is returned when reverting one of several commits results in conflicts.
+var ErrRevertConflicts = errors.New("reverting multiple commits resulted in conflicts")
+
+// Revert undoes the changes introduced by each of |commits| on top of |root|. Each commit is reverted by merging the
+// root of its first parent into |root|, using the commit itself as the common ancestor. If a single commit is reverted
+// and that results in conflicts, the conflicted root is returned along with its merge stats. If one of several commits
+// results in conflicts, ErrRevertConflicts is returned instead, as the remaining commits couldn't be reverted on top of
+// the conflicts. The returned message describes the reverted commits and is suitable for use as a commit message.
+func Revert(ctx context.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, commits []*doltdb.Commit) (*doltdb.RootValue, map[string]*MergeStats, string, error) {
+	var descs []string
+	var hashes []string
+	tblToStats := make(map[string]*MergeStats)
+
+	for _, cm := range commits {
+		numParents, err := cm.NumParents()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		if numParents == 0 {
+			return nil, nil, "", ErrCommitHasNoParent
+		}
+
+		parent, err := ddb.ResolveParent(ctx, cm, 0)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		parentRoot, err := parent.GetRootValue()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		baseRoot, err := cm.GetRootValue()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		meta, err := cm.GetCommitMeta()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		descs = append(descs, fmt.Sprintf(`"%s"`, meta.Description))
+		hashes = append(hashes, h.String())
+
+		var revertStats map[string]*MergeStats
+		root, revertStats, err = MergeRoots(ctx, root, parentRoot, baseRoot)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		accumulateStats(tblToStats, revertStats)
+
+		if len(commits) > 1 && hasConflicts(revertStats) {
+			return nil, nil, "", fmt.Errorf("%w: %s", ErrRevertConflicts, h.String())
+		}
+	}
+
+	msg := fmt.Sprintf("Revert %s\n\nThis reverts commit %s.", strings.Join(descs, " and "), strings.Join(hashes, ", "))
+
+	return root, tblToStats, msg, nil
+}
+
+// accumulateStats adds the stats in |other| to those in |tblToStats|.
+func accumulateStats(tblToStats, other map[string]*MergeStats) {
+	for tblName, stats := range other {
+		acc, ok := tblToStats[tblName]
+		if !ok || acc.Operation == TableUnmodified {
+			s := *stats
+			tblToStats[tblName] = &s
+			continue
+		}
+
+		if stats.Operation != TableUnmodified {
+			acc.Operation = stats.Operation
+		}
+		acc.Adds += stats.Adds
+		acc.Deletes += stats.Deletes
+		acc.Modifications += stats.Modifications
+		acc.Conflicts += stats.Conflicts
+		acc.SchemaConflicts += stats.SchemaConflicts
+	}
+}
+
+func hasConflicts(tblToStats map[string]*MergeStats) bool {
+	for _, stats := range tblToStats {
+		if stats.Operation == TableModified && stats.HasConflicts() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,360 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/alterschema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+// GetSchemaConflicts returns the unresolved schema conflicts of the table |tblName|. They are computed from the
+// current schema of |tbl| and the schemas of the table being merged in and the ancestor, which were recorded by the
+// merge.
+func GetSchemaConflicts(ctx context.Context, tblName string, tbl *doltdb.Table) (SchemaConflict, error) {
+	theirTbl, ancTbl, err := tbl.GetSchemaConflicts(ctx)
+
+	if err != nil {
+		return EmptySchConflicts, err
+	}
+
+	return schemaConflictsOf(ctx, tblName, tbl, theirTbl, ancTbl)
+}
+
+func schemaConflictsOf(ctx context.Context, tblName string, tbl, theirTbl, ancTbl *doltdb.Table) (SchemaConflict, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return EmptySchConflicts, err
+	}
+
+	theirSch, err := theirTbl.GetSchema(ctx)
+
+	if err != nil {
+		return EmptySchConflicts, err
+	}
+
+	ancSch, err := ancTbl.GetSchema(ctx)
+
+	if err != nil {
+		return EmptySchConflicts, err
+	}
+
+	_, sc, err := SchemaMerge(sch, theirSch, ancSch, tblName)
+
+	if err != nil {
+		return EmptySchConflicts, err
+	}
+
+	return sc, nil
+}
+
+// ResolveSchemaConflicts resolves the schema conflicts of the table |tblName| in |root| by taking our definitions of
+// the conflicting columns and indexes if |ours| is true, or their definitions otherwise. Only the conflicts of the
+// columns and indexes named in |names| are resolved, or all of them if |names| is empty. Once the last schema conflict
+// of the table is resolved the data of the table is merged, which may result in data conflicts.
+func ResolveSchemaConflicts(ctx context.Context, root *doltdb.RootValue, tblName string, ours bool, names []string) (*doltdb.RootValue, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
+
+	theirTbl, ancTbl, err := tbl.GetSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := schemaConflictsOf(ctx, tblName, tbl, theirTbl, ancTbl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toResolve := make(map[string]bool)
+	for _, name := range names {
+		if !sc.hasConflict(name) {
+			return nil, fmt.Errorf("table %s has no schema conflict for a column or index named '%s'", tblName, name)
+		}
+		toResolve[strings.ToLower(name)] = true
+	}
+
+	matches := func(ourName, theirName string) bool {
+		return len(toResolve) == 0 || toResolve[strings.ToLower(ourName)] || toResolve[strings.ToLower(theirName)]
+	}
+
+	fkColl, err := root.GetForeignKeyCollection(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	declaredFks, referencedByFks := fkColl.KeysForTable(tblName)
+	ourFks := append(declaredFks, referencedByFks...)
+
+	ancSch, err := ancTbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// conflicts are resolved by changing the losing side of the merge to match the definition taken from the winning side
+	for _, c := range sc.ColConflicts {
+		if !matches(c.Ours.Name, c.Theirs.Name) {
+			continue
+		}
+
+		if ours {
+			theirTbl, err = resolveColConflict(ctx, theirTbl, c.Theirs, c.Ours, c.Kind, ancSch, nil)
+		} else {
+			tbl, err = resolveColConflict(ctx, tbl, c.Ours, c.Theirs, c.Kind, ancSch, ourFks)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range sc.IdxConflicts {
+		if !matches(c.Ours.Name(), c.Theirs.Name()) {
+			continue
+		}
+
+		if ours {
+			theirTbl, err = resolveIdxConflict(ctx, theirTbl, c.Theirs, c.Ours, c.Kind, ancSch)
+		} else {
+			tbl, err = resolveIdxConflict(ctx, tbl, c.Ours, c.Theirs, c.Kind, ancSch)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// resolving conflicts of columns may surface conflicts of the indexes over them
+	sc, err = schemaConflictsOf(ctx, tblName, tbl, theirTbl, ancTbl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.Count() > 0 {
+		tbl, err = tbl.SetSchemaConflicts(ctx, theirTbl, ancTbl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return root.PutTable(ctx, tblName, tbl)
+	}
+
+	return mergeResolvedTable(ctx, root, tblName, tbl, theirTbl, ancTbl)
+}
+
+func (sc SchemaConflict) hasConflict(name string) bool {
+	for _, c := range sc.ColConflicts {
+		if strings.EqualFold(name, c.Ours.Name) || strings.EqualFold(name, c.Theirs.Name) {
+			return true
+		}
+	}
+
+	for _, c := range sc.IdxConflicts {
+		if strings.EqualFold(name, c.Ours.Name()) || strings.EqualFold(name, c.Theirs.Name()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveColConflict changes the column |col| of |tbl| so that it no longer conflicts with the column |winner|. A
+// column with a different definition is modified to match, converting its values to the new type. A different column
+// with the same name is reverted to its definition in the ancestor schema |ancSch|, or dropped if it was added since.
+func resolveColConflict(ctx context.Context, tbl *doltdb.Table, col, winner schema.Column, kind conflictKind, ancSch schema.Schema, fks []doltdb.ForeignKey) (*doltdb.Table, error) {
+	switch kind {
+	case TagCollision:
+		tbl, err := alterschema.ModifyColumn(ctx, tbl, col, winner, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// ModifyColumn keeps the existing name when the names only differ by case
+		if col.Name != winner.Name && strings.EqualFold(col.Name, winner.Name) {
+			return renameColumn(ctx, tbl, col.Tag, winner.Name)
+		}
+
+		return tbl, nil
+	case NameCollision:
+		if ancCol, ok := ancSch.GetAllCols().GetByTag(col.Tag); ok {
+			return alterschema.ModifyColumn(ctx, tbl, col, ancCol, nil)
+		}
+		return alterschema.DropColumn(ctx, tbl, col.Name, fks)
+	}
+
+	panic("unknown conflict kind")
+}
+
+// renameColumn sets the name of the column with the tag |tag| in |tbl| to |name|.
+func renameColumn(ctx context.Context, tbl *doltdb.Table, tag uint64, name string) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var cols []schema.Column
+	_ = sch.GetAllCols().Iter(func(t uint64, col schema.Column) (stop bool, err error) {
+		if t == tag {
+			col.Name = name
+		}
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	newSch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		_, err = newSch.Indexes().AddIndexByColTags(idx.Name(), idx.IndexedColumnTags(), schema.IndexProperties{
+			IsUnique:      idx.IsUnique(),
+			IsUserDefined: idx.IsUserDefined(),
+			Comment:       idx.Comment(),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tbl.UpdateSchema(ctx, newSch)
+}
+
+// resolveIdxConflict changes the index |idx| of |tbl| so that it no longer conflicts with the index |winner|. An index
+// over the same columns is replaced by |winner|. A different index with the same name is reverted to the index over
+// its columns in the ancestor schema |ancSch|, or dropped if there is none.
+func resolveIdxConflict(ctx context.Context, tbl *doltdb.Table, idx, winner schema.Index, kind conflictKind, ancSch schema.Schema) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sch.Indexes().RemoveIndex(idx.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.DeleteIndexRowData(ctx, idx.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := winner
+	if kind == NameCollision {
+		var ok bool
+		replacement, ok = ancSch.Indexes().GetIndexByTags(idx.IndexedColumnTags()...)
+
+		if !ok {
+			return tbl.UpdateSchema(ctx, sch)
+		}
+	}
+
+	_, err = sch.Indexes().AddIndexByColTags(replacement.Name(), replacement.IndexedColumnTags(), schema.IndexProperties{
+		IsUnique:      replacement.IsUnique(),
+		IsUserDefined: replacement.IsUserDefined(),
+		Comment:       replacement.Comment(),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.UpdateSchema(ctx, sch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	indexData, err := editor.RebuildIndex(ctx, tbl, replacement.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tbl.SetIndexRowData(ctx, replacement.Name(), indexData)
+}
+
+// mergeResolvedTable merges |theirTbl| into |tbl|, the table |tblName| in |root|, once their schema conflicts have been
+// resolved.
+func mergeResolvedTable(ctx context.Context, root *doltdb.RootValue, tblName string, tbl, theirTbl, ancTbl *doltdb.Table) (*doltdb.RootValue, error) {
+	tbl, err := tbl.ClearSchemaConflicts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err = root.PutTable(ctx, tblName, tbl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	theirRoot, err := root.PutTable(ctx, tblName, theirTbl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ancRoot, err := root.PutTable(ctx, tblName, ancTbl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	merger := NewMerger(ctx, root, theirRoot, ancRoot, root.VRW())
+	tableEditSession := editor.CreateTableEditSession(root, editor.TableEditSessionProps{
+		ForeignKeyChecksDisabled: true,
+	})
+
+	mergedTbl, _, err := merger.MergeTable(ctx, tblName, tableEditSession)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = tableEditSession.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
+		return root.PutTable(ctx, tblName, mergedTbl)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tableEditSession.Flush(ctx)
+}
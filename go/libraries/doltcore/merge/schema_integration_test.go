@@ -16,6 +16,7 @@ package merge_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,19 @@ func TestMergeSchemas(t *testing.T) {
 	}
 }
 
+func TestResolveSchemaConflicts(t *testing.T) {
+	for _, test := range mergeSchemaConflictTests {
+		if test.expConflict.Count() == 0 {
+			continue
+		}
+		for _, ours := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s ours=%t", test.name, ours), func(t *testing.T) {
+				testResolveSchemaConflicts(t, test, ours)
+			})
+		}
+	}
+}
+
 type mergeSchemaTest struct {
 	name  string
 	setup []testCommand
@@ -230,6 +244,30 @@ var mergeSchemaTests = []mergeSchemaTest{
 			schema.NewIndex("c1_idx", []uint64{8201}, []uint64{8201, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
 		),
 	},
+	{
+		name: "add same column and rows on both branches, merge",
+		setup: []testCommand{
+			{commands.SqlCmd{}, []string{"-q", "alter table test add column c4 int;"}},
+			{commands.SqlCmd{}, []string{"-q", "insert into test values (1, 1, 1, 1, 1);"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch master"}},
+			{commands.CheckoutCmd{}, []string{"other"}},
+			{commands.SqlCmd{}, []string{"-q", "alter table test add column c4 int;"}},
+			{commands.SqlCmd{}, []string{"-q", "insert into test values (2, 2, 2, 2, 2);"}},
+			{commands.AddCmd{}, []string{"."}},
+			{commands.CommitCmd{}, []string{"-m", "modified branch other"}},
+			{commands.CheckoutCmd{}, []string{"master"}},
+		},
+		sch: schemaFromColsAndIdxs(
+			colCollection(
+				newColTypeInfo("pk", uint64(3228), typeinfo.Int32Type, true, schema.NotNullConstraint{}),
+				newColTypeInfo("c1", uint64(8201), typeinfo.Int32Type, false, schema.NotNullConstraint{}),
+				newColTypeInfo("c2", uint64(8539), typeinfo.Int32Type, false),
+				newColTypeInfo("c3", uint64(4696), typeinfo.Int32Type, false),
+				newColTypeInfo("c4", uint64(1716), typeinfo.Int32Type, false)),
+			schema.NewIndex("c1_idx", []uint64{8201}, []uint64{8201, 3228}, nil, schema.IndexProperties{IsUserDefined: true}),
+		),
+	},
 	{
 		name: "add same index on both branches, merge",
 		setup: []testCommand{
@@ -581,3 +619,65 @@ func testMergeForeignKeys(t *testing.T, test mergeForeignKeyTest) {
 	})
 	assert.NoError(t, err)
 }
+
+func testResolveSchemaConflicts(t *testing.T, test mergeSchemaConflictTest, ours bool) {
+	dEnv := dtestutils.CreateTestEnv()
+	ctx := context.Background()
+	for _, c := range setupCommon {
+		c.exec(t, ctx, dEnv)
+	}
+	for _, c := range test.setup {
+		c.exec(t, ctx, dEnv)
+	}
+
+	exitCode := commands.MergeCmd{}.Exec(ctx, "merge", []string{"other"}, dEnv)
+	require.Equal(t, 0, exitCode)
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	tbl, _, err := root.GetTable(ctx, "test")
+	require.NoError(t, err)
+	sc, err := merge.GetSchemaConflicts(ctx, "test", tbl)
+	require.NoError(t, err)
+	assert.Equal(t, test.expConflict.Count(), sc.Count())
+
+	// resolving column conflicts may surface index conflicts
+	for i := 0; i < 2; i++ {
+		tbl, _, err = root.GetTable(ctx, "test")
+		require.NoError(t, err)
+		has, err := tbl.HasSchemaConflicts()
+		require.NoError(t, err)
+		if !has {
+			break
+		}
+		root, err = merge.ResolveSchemaConflicts(ctx, root, "test", ours, nil)
+		require.NoError(t, err)
+	}
+
+	tbl, _, err = root.GetTable(ctx, "test")
+	require.NoError(t, err)
+	has, err := tbl.HasSchemaConflicts()
+	require.NoError(t, err)
+	require.False(t, has)
+
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	for _, c := range test.expConflict.ColConflicts {
+		winner := c.Theirs
+		if ours {
+			winner = c.Ours
+		}
+		col, ok := sch.GetAllCols().GetByName(winner.Name)
+		require.True(t, ok)
+		assert.True(t, winner.TypeInfo.Equals(col.TypeInfo))
+	}
+	for _, c := range test.expConflict.IdxConflicts {
+		winner := c.Theirs
+		if ours {
+			winner = c.Ours
+		}
+		idx := sch.Indexes().GetByName(winner.Name())
+		require.NotNil(t, idx)
+		assert.Equal(t, winner.IndexedColumnTags(), idx.IndexedColumnTags())
+	}
+}
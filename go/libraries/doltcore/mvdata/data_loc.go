@@ -61,8 +61,14 @@ const (
 	// JsonFile is the format of a data location that is a json file
 	JsonFile DataFormat = ".json"
 
+	// JsonlFile is the format of a data location that is a newline delimited json file, with one row per line
+	JsonlFile DataFormat = ".jsonl"
+
 	// SqlFile is the format of a data location that is a .sql file
 	SqlFile DataFormat = ".sql"
+
+	// ParquetFile is the format of a data location that is a .parquet file
+	ParquetFile DataFormat = ".parquet"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -78,8 +84,12 @@ func (df DataFormat) ReadableStr() string {
 		return "xlsx file"
 	case JsonFile:
 		return "json file"
+	case JsonlFile:
+		return "jsonl file"
 	case SqlFile:
 		return "sql file"
+	case ParquetFile:
+		return "parquet file"
 	default:
 		return "invalid"
 	}
@@ -130,8 +140,12 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 				dataFmt = XlsxFile
 			case string(JsonFile):
 				dataFmt = JsonFile
+			case string(JsonlFile), ".ndjson":
+				dataFmt = JsonlFile
 			case string(SqlFile):
 				dataFmt = SqlFile
+			case string(ParquetFile):
+				dataFmt = ParquetFile
 			}
 		}
 	}
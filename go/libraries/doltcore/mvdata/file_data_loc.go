@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/sqlexport"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/xlsx"
@@ -45,8 +46,12 @@ func DFFromString(dfStr string) DataFormat {
 		return XlsxFile
 	case "json", ".json":
 		return JsonFile
+	case "jsonl", ".jsonl", "ndjson", ".ndjson":
+		return JsonlFile
 	case "sql", ".sql":
 		return SqlFile
+	case "parquet", ".parquet":
+		return ParquetFile
 	default:
 		return InvalidDataFormat
 	}
@@ -108,36 +113,26 @@ func (dl FileDataLocation) NewReader(ctx context.Context, root *doltdb.RootValue
 		return rd, false, err
 
 	case JsonFile:
-		var sch schema.Schema
-		jsonOpts, _ := opts.(JSONOptions)
-		if jsonOpts.SchFile != "" {
-			tn, s, err := SchAndTableNameFromFile(ctx, jsonOpts.SchFile, fs, root)
-			if err != nil {
-				return nil, false, err
-			}
-			if tn != jsonOpts.TableName {
-				return nil, false, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, jsonOpts.SchFile, jsonOpts.TableName)
-			}
-			sch = s
-		} else {
-			if opts == nil {
-				return nil, false, errors.New("Unable to determine table name on JSON import")
-			}
-			tbl, exists, err := root.GetTable(context.TODO(), jsonOpts.TableName)
-			if !exists {
-				return nil, false, errors.New(fmt.Sprintf("The following table could not be found:\n%v", jsonOpts.TableName))
-			}
-			if err != nil {
-				return nil, false, errors.New(fmt.Sprintf("An error occurred attempting to read the table:\n%v", err.Error()))
-			}
-			sch, err = tbl.GetSchema(context.TODO())
-			if err != nil {
-				return nil, false, errors.New(fmt.Sprintf("An error occurred attempting to read the table schema:\n%v", err.Error()))
-			}
+		sch, err := jsonImportSchema(ctx, root, fs, opts)
+		if err != nil {
+			return nil, false, err
 		}
 
 		rd, err := json.OpenJSONReader(root.VRW(), dl.Path, fs, sch)
 		return rd, false, err
+
+	case JsonlFile:
+		sch, err := jsonImportSchema(ctx, root, fs, opts)
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := json.OpenJSONLReader(root.VRW(), dl.Path, fs, sch)
+		return rd, false, err
+
+	case ParquetFile:
+		rd, err := parquet.OpenParquetReader(root.VRW(), dl.Path, fs)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New("unsupported format")
@@ -155,8 +150,12 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 		panic("writing to xlsx files is not supported yet")
 	case JsonFile:
 		return json.OpenJSONWriter(dl.Path, dEnv.FS, outSch)
+	case JsonlFile:
+		return json.OpenJSONLWriter(dl.Path, dEnv.FS, outSch)
 	case SqlFile:
 		return sqlexport.OpenSQLExportWriter(ctx, dl.Path, dEnv.FS, root, mvOpts.SrcName(), outSch)
+	case ParquetFile:
+		return parquet.OpenParquetWriter(dl.Path, dEnv.FS, outSch)
 	}
 
 	panic("Invalid Data Format." + string(dl.Format))
@@ -173,3 +172,35 @@ func (dl FileDataLocation) NewUpdatingWriter(_ context.Context, _ DataMoverOptio
 func (dl FileDataLocation) NewReplacingWriter(_ context.Context, _ DataMoverOptions, _ *env.DoltEnv, _ *doltdb.RootValue, _ bool, _ schema.Schema, _ noms.StatsCB, _ bool) (table.TableWriteCloser, error) {
 	panic("Replacing files is not supported")
 }
+
+// jsonImportSchema returns the schema of the rows of a json or jsonl import. Json files don't have a schema of their
+// own, so it comes from the schema file given in |opts|, or from the existing table being imported to.
+func jsonImportSchema(ctx context.Context, root *doltdb.RootValue, fs filesys.ReadableFS, opts interface{}) (schema.Schema, error) {
+	jsonOpts, _ := opts.(JSONOptions)
+	if jsonOpts.SchFile != "" {
+		tn, sch, err := SchAndTableNameFromFile(ctx, jsonOpts.SchFile, fs, root)
+		if err != nil {
+			return nil, err
+		}
+		if tn != jsonOpts.TableName {
+			return nil, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, jsonOpts.SchFile, jsonOpts.TableName)
+		}
+		return sch, nil
+	}
+
+	if opts == nil {
+		return nil, errors.New("Unable to determine table name on JSON import")
+	}
+	tbl, exists, err := root.GetTable(ctx, jsonOpts.TableName)
+	if !exists {
+		return nil, errors.New(fmt.Sprintf("The following table could not be found:\n%v", jsonOpts.TableName))
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("An error occurred attempting to read the table:\n%v", err.Error()))
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("An error occurred attempting to read the table schema:\n%v", err.Error()))
+	}
+	return sch, nil
+}
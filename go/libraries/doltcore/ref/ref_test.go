@@ -44,6 +44,10 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewWorkspaceRef("newworkspace"),
 			`{"test":"refs/workspaces/newworkspace"}`,
 		},
+		{
+			NewStashRef("0"),
+			`{"test":"refs/stashes/0"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -158,6 +162,21 @@ func TestEqualsStr(t *testing.T) {
 			"refs/remotes/origin/newworkspace",
 			false,
 		},
+		{
+			NewStashRef("0"),
+			"refs/stashes/0",
+			true,
+		},
+		{
+			NewStashRef("refs/stashes/0"),
+			"refs/stashes/0",
+			true,
+		},
+		{
+			NewStashRef("0"),
+			"refs/stashes/1",
+			false,
+		},
 	}
 
 	for _, test := range tests {
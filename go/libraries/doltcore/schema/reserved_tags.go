@@ -90,3 +90,11 @@ const (
 	DoltProceduresCreatedAtTag
 	DoltProceduresModifiedAtTag
 )
+
+// Tags for the dolt_merge_policies table
+const (
+	DoltMergePoliciesTableNameTag = iota + SystemTableReservedMin + uint64(7000)
+	DoltMergePoliciesColumnNameTag
+	DoltMergePoliciesPolicyTag
+	DoltMergePoliciesPolicyColumnTag
+)
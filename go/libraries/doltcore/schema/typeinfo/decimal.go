@@ -257,6 +257,8 @@ func decimalTypeConverter(ctx context.Context, src *decimalType, destTi TypeInfo
 			}
 			return dest.ConvertValueToNomsValue(ctx, vrw, decimal.Decimal(val).Round(0))
 		}, true, nil
+	case *jsonType:
+		return wrapConvertValueToNomsValue(dest.ConvertValueToNomsValue)
 	case *setType:
 		return func(ctx context.Context, vrw types.ValueReadWriter, v types.Value) (types.Value, error) {
 			s, err := src.ConvertNomsValueToValue(v)
@@ -0,0 +1,500 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// This is a dolt implementation of the MySQL type JSON. Documents are stored as types.JSON values, which hold the
+// document as a tree of noms values, so documents are validated when they are written and compare equal regardless of
+// their whitespace and the order of their keys. Numbers keep the text they were written with.
+type jsonType struct {
+	jsonType sql.JsonType
+}
+
+var _ TypeInfo = (*jsonType)(nil)
+
+var JSONType = &jsonType{jsonSqlType{sql.JSON}}
+
+// ConvertNomsValueToValue implements TypeInfo interface.
+func (ti *jsonType) ConvertNomsValueToValue(v types.Value) (interface{}, error) {
+	if val, ok := v.(types.JSON); ok {
+		return fromJSONDoc(context.Background(), val)
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), v.Kind())
+}
+
+// ReadFrom reads a go value from a noms types.CodecReader directly
+func (ti *jsonType) ReadFrom(_ *types.NomsBinFormat, reader types.CodecReader) (interface{}, error) {
+	k := reader.PeekKind()
+	switch k {
+	case types.JSONKind:
+		val, err := reader.ReadJSON()
+		if err != nil {
+			return nil, err
+		}
+		return fromJSONDoc(context.Background(), val)
+	case types.NullKind:
+		_ = reader.ReadKind()
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a value`, ti.String(), k)
+}
+
+// ConvertValueToNomsValue implements TypeInfo interface.
+func (ti *jsonType) ConvertValueToNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case string:
+		return toJSONDoc(ctx, vrw, []byte(val))
+	case []byte:
+		return toJSONDoc(ctx, vrw, val)
+	default:
+		doc, err := ti.jsonType.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		return toJSONDoc(ctx, vrw, doc.([]byte))
+	}
+}
+
+// Equals implements TypeInfo interface.
+func (ti *jsonType) Equals(other TypeInfo) bool {
+	if other == nil {
+		return false
+	}
+	_, ok := other.(*jsonType)
+	return ok
+}
+
+// FormatValue implements TypeInfo interface.
+func (ti *jsonType) FormatValue(v types.Value) (*string, error) {
+	if val, ok := v.(types.JSON); ok {
+		doc, err := fromJSONDoc(context.Background(), val)
+		if err != nil {
+			return nil, err
+		}
+		res := string(doc)
+		return &res, nil
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(`"%v" cannot convert NomsKind "%v" to a string`, ti.String(), v.Kind())
+}
+
+// GetTypeIdentifier implements TypeInfo interface.
+func (ti *jsonType) GetTypeIdentifier() Identifier {
+	return JSONTypeIdentifier
+}
+
+// GetTypeParams implements TypeInfo interface.
+func (ti *jsonType) GetTypeParams() map[string]string {
+	return nil
+}
+
+// IsValid implements TypeInfo interface.
+func (ti *jsonType) IsValid(v types.Value) bool {
+	if _, ok := v.(types.JSON); ok {
+		return true
+	}
+	if _, ok := v.(types.Null); ok || v == nil {
+		return true
+	}
+	return false
+}
+
+// NomsKind implements TypeInfo interface.
+func (ti *jsonType) NomsKind() types.NomsKind {
+	return types.JSONKind
+}
+
+// ParseValue implements TypeInfo interface.
+func (ti *jsonType) ParseValue(ctx context.Context, vrw types.ValueReadWriter, str *string) (types.Value, error) {
+	if str == nil || *str == "" {
+		return types.NullValue, nil
+	}
+	return toJSONDoc(ctx, vrw, []byte(*str))
+}
+
+// Promote implements TypeInfo interface.
+func (ti *jsonType) Promote() TypeInfo {
+	return ti
+}
+
+// String implements TypeInfo interface.
+func (ti *jsonType) String() string {
+	return "JSON"
+}
+
+// ToSqlType implements TypeInfo interface.
+func (ti *jsonType) ToSqlType() sql.Type {
+	return ti.jsonType
+}
+
+// jsonTypeConverter is an internal function for GetTypeConverter that handles the specific type as the source TypeInfo.
+func jsonTypeConverter(ctx context.Context, src *jsonType, destTi TypeInfo) (tc TypeConverter, needsConversion bool, err error) {
+	switch dest := destTi.(type) {
+	case *bitType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *boolType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *datetimeType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *decimalType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *enumType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *floatType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *inlineBlobType:
+		return wrapConvertValueToNomsValue(dest.ConvertValueToNomsValue)
+	case *intType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *jsonType:
+		return wrapIsValid(dest.IsValid, src, dest)
+	case *setType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *timeType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *uintType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *uuidType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	case *varBinaryType:
+		return wrapConvertValueToNomsValue(dest.ConvertValueToNomsValue)
+	case *varStringType:
+		return wrapConvertValueToNomsValue(dest.ConvertValueToNomsValue)
+	case *yearType:
+		return nil, false, IncompatibleTypeConversion.New(src.String(), dest.String())
+	default:
+		return nil, false, UnhandledTypeConversion.New(src.String(), destTi.String())
+	}
+}
+
+// toJSONDoc parses the JSON document |doc| and stores it as a types.JSON value. Returns an error if |doc| is not valid
+// JSON.
+func toJSONDoc(ctx context.Context, vrw types.ValueReadWriter, doc []byte) (types.JSON, error) {
+	val, err := parseJSON(doc)
+	if err != nil {
+		return types.JSON{}, err
+	}
+
+	nomsVal, err := toJSONNomsValue(ctx, vrw, val)
+	if err != nil {
+		return types.JSON{}, err
+	}
+	return types.NewJSONDoc(vrw.Format(), vrw, nomsVal)
+}
+
+// toJSONNomsValue converts a go value produced by decoding a JSON document into a tree of noms values.
+func toJSONNomsValue(ctx context.Context, vrw types.ValueReadWriter, v interface{}) (types.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return types.NullValue, nil
+	case bool:
+		return types.Bool(val), nil
+	case string:
+		return types.String(val), nil
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return types.Int(i), nil
+		}
+		if u, err := strconv.ParseUint(val.String(), 10, 64); err == nil {
+			return types.Uint(u), nil
+		}
+		// other numbers are stored as decimals, which keep the digits and exponent of the number, so that 1.0 is not
+		// written back out as 1
+		d, err := decimal.NewFromString(val.String())
+		if err != nil {
+			return nil, err
+		}
+		return types.Decimal(d), nil
+	case []interface{}:
+		vals := make([]types.Value, len(val))
+		for i, elem := range val {
+			nomsElem, err := toJSONNomsValue(ctx, vrw, elem)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = nomsElem
+		}
+		return types.NewList(ctx, vrw, vals...)
+	case map[string]interface{}:
+		kvs := make([]types.Value, 0, 2*len(val))
+		for k, elem := range val {
+			nomsElem, err := toJSONNomsValue(ctx, vrw, elem)
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, types.String(k), nomsElem)
+		}
+		return types.NewMap(ctx, vrw, kvs...)
+	default:
+		return nil, fmt.Errorf(`unexpected type "%T" in JSON document`, v)
+	}
+}
+
+// fromJSONDoc returns the serialized form of the JSON document |doc|.
+func fromJSONDoc(ctx context.Context, doc types.JSON) ([]byte, error) {
+	nomsVal, err := doc.Inner()
+	if err != nil {
+		return nil, err
+	}
+	val, err := fromJSONNomsValue(ctx, nomsVal)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(val)
+}
+
+// parseJSON parses the JSON document |doc| into a go value, with numbers as json.Numbers. Returns an error if |doc| is
+// not valid JSON.
+func parseJSON(doc []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var val interface{}
+	if err := dec.Decode(&val); err != nil {
+		return nil, fmt.Errorf(`invalid JSON text: %v`, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf(`invalid JSON text: unexpected data after the end of the document`)
+	}
+	return val, nil
+}
+
+// encodeJSON serializes the go value |val| as JSON. Unlike json.Marshal, <, > and & are not escaped, as MySQL doesn't
+// escape them either.
+func encodeJSON(val interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(val); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// fromJSONNomsValue converts a tree of noms values stored in a JSON document into the equivalent go value.
+func fromJSONNomsValue(ctx context.Context, v types.Value) (interface{}, error) {
+	switch val := v.(type) {
+	case types.Null:
+		return nil, nil
+	case types.Bool:
+		return bool(val), nil
+	case types.String:
+		return string(val), nil
+	case types.Int:
+		return int64(val), nil
+	case types.Uint:
+		return uint64(val), nil
+	case types.Float:
+		return float64(val), nil
+	case types.Decimal:
+		return decimalJSONNumber(decimal.Decimal(val)), nil
+	case types.List:
+		res := make([]interface{}, 0, val.Len())
+		err := val.IterAll(ctx, func(elem types.Value, _ uint64) error {
+			goElem, err := fromJSONNomsValue(ctx, elem)
+			if err != nil {
+				return err
+			}
+			res = append(res, goElem)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	case types.Map:
+		res := make(map[string]interface{}, val.Len())
+		err := val.Iter(ctx, func(k, elem types.Value) (stop bool, err error) {
+			key, ok := k.(types.String)
+			if !ok {
+				return true, fmt.Errorf(`unexpected key of NomsKind "%v" in JSON object`, k.Kind())
+			}
+			goElem, err := fromJSONNomsValue(ctx, elem)
+			if err != nil {
+				return true, err
+			}
+			res[string(key)] = goElem
+			return false, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf(`unexpected NomsKind "%v" in JSON document`, v.Kind())
+	}
+}
+
+// decimalJSONNumber returns the JSON number for |d|. Numbers written with a fractional part keep all of their digits.
+// Numbers written with a positive exponent, like 1e2, are written out in full with a fractional part, like MySQL
+// writes them.
+func decimalJSONNumber(d decimal.Decimal) json.Number {
+	switch {
+	case d.Exponent() < 0:
+		return json.Number(d.StringFixed(-d.Exponent()))
+	case d.Exponent() > 0:
+		return json.Number(d.String() + ".0")
+	default:
+		return json.Number(d.String())
+	}
+}
+
+// jsonSqlType is the sql.Type of JSONType. sql.JSON converts documents with json.Marshal, which escapes <, > and &, and
+// reads numbers as float64s, so that 1.0 becomes 1. This type converts documents the same way they are stored instead.
+type jsonSqlType struct {
+	sql.JsonType
+}
+
+var _ sql.JsonType = jsonSqlType{}
+
+// Compare implements sql.Type interface.
+func (t jsonSqlType) Compare(a interface{}, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0, nil
+		case a == nil:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+	aDoc, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+	bDoc, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Compare(aDoc.([]byte), bDoc.([]byte)), nil
+}
+
+// Convert implements sql.Type interface. Strings and byte slices must hold a valid JSON document. Other values are
+// converted to the JSON document that represents them.
+func (t jsonSqlType) Convert(v interface{}) (interface{}, error) {
+	var doc []byte
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		doc = []byte(val)
+	case []byte:
+		doc = val
+	default:
+		res, err := t.JsonType.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		doc = res.([]byte)
+	}
+
+	val, err := parseJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	val, err = normalizeJSONNumbers(val)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(val)
+}
+
+// MustConvert implements sql.Type interface.
+func (t jsonSqlType) MustConvert(v interface{}) interface{} {
+	value, err := t.Convert(v)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Promote implements sql.Type interface.
+func (t jsonSqlType) Promote() sql.Type {
+	return t
+}
+
+// SQL implements sql.Type interface.
+func (t jsonSqlType) SQL(v interface{}) (sqltypes.Value, error) {
+	if v == nil {
+		return sqltypes.NULL, nil
+	}
+	doc, err := t.Convert(v)
+	if err != nil {
+		return sqltypes.Value{}, err
+	}
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, doc.([]byte)), nil
+}
+
+// normalizeJSONNumbers replaces the json.Numbers in the parsed JSON document |v| with the text they are written with
+// once the document is stored.
+func normalizeJSONNumbers(v interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return json.Number(strconv.FormatInt(i, 10)), nil
+		}
+		if u, err := strconv.ParseUint(val.String(), 10, 64); err == nil {
+			return json.Number(strconv.FormatUint(u, 10)), nil
+		}
+		d, err := decimal.NewFromString(val.String())
+		if err != nil {
+			return nil, err
+		}
+		return decimalJSONNumber(d), nil
+	case []interface{}:
+		for i, elem := range val {
+			norm, err := normalizeJSONNumbers(elem)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = norm
+		}
+		return val, nil
+	case map[string]interface{}:
+		for k, elem := range val {
+			norm, err := normalizeJSONNumbers(elem)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = norm
+		}
+		return val, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typeinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func mustJSONDoc(t *testing.T, doc string) types.JSON {
+	vrw := types.NewMemoryValueStore()
+	val, err := JSONType.ParseValue(context.Background(), vrw, &doc)
+	require.NoError(t, err)
+	return val.(types.JSON)
+}
+
+func TestJSONConvertNomsValueToValue(t *testing.T) {
+	tests := []struct {
+		input  types.JSON
+		output string
+	}{
+		{
+			mustJSONDoc(t, `null`),
+			`null`,
+		},
+		{
+			mustJSONDoc(t, `  "abc"  `),
+			`"abc"`,
+		},
+		{
+			mustJSONDoc(t, `[1, -2, 18446744073709551615, 2.5, true, null]`),
+			`[1,-2,18446744073709551615,2.5,true,null]`,
+		},
+		{
+			mustJSONDoc(t, `{"b": {"c": [1, 2]}, "a": "x"}`),
+			`{"a":"x","b":{"c":[1,2]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, JSONType.String(), test.output), func(t *testing.T) {
+			output, err := JSONType.ConvertNomsValueToValue(test.input)
+			require.NoError(t, err)
+			require.Equal(t, []byte(test.output), output)
+		})
+	}
+}
+
+func TestJSONConvertValueToNomsValue(t *testing.T) {
+	tests := []struct {
+		input       interface{}
+		output      types.JSON
+		expectedErr bool
+	}{
+		{
+			`{"a": 1, "b": [true, "c"]}`,
+			mustJSONDoc(t, `{"b":[true,"c"],"a":1}`),
+			false,
+		},
+		{
+			[]byte(`[1, 2, 3]`),
+			mustJSONDoc(t, `[1,2,3]`),
+			false,
+		},
+		{
+			map[string]interface{}{"a": int64(1)},
+			mustJSONDoc(t, `{"a":1}`),
+			false,
+		},
+		{
+			int64(7),
+			mustJSONDoc(t, `7`),
+			false,
+		},
+		{
+			`{"a": 1`,
+			types.JSON{},
+			true,
+		},
+		{
+			`not json`,
+			types.JSON{},
+			true,
+		},
+		{
+			`[1] [2]`,
+			types.JSON{},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, JSONType.String(), test.input), func(t *testing.T) {
+			vrw := types.NewMemoryValueStore()
+			output, err := JSONType.ConvertValueToNomsValue(context.Background(), vrw, test.input)
+			if !test.expectedErr {
+				require.NoError(t, err)
+				assert.True(t, test.output.Equals(output), "%v\n%v", test.output.HumanReadableString(), output.HumanReadableString())
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestJSONFormatValue(t *testing.T) {
+	tests := []struct {
+		input  types.JSON
+		output string
+	}{
+		{
+			mustJSONDoc(t, `"abc"`),
+			`"abc"`,
+		},
+		{
+			mustJSONDoc(t, `{ "z": 1.25, "a": [{}, []] }`),
+			`{"a":[{},[]],"z":1.25}`,
+		},
+		{
+			mustJSONDoc(t, `[1.0, 1.50, -0.25, 1e2, 12345678901234567890123]`),
+			`[1.0,1.50,-0.25,100.0,12345678901234567890123]`,
+		},
+		{
+			mustJSONDoc(t, `{"html": "<b>&amp;</b>"}`),
+			`{"html":"<b>&amp;</b>"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, JSONType.String(), test.output), func(t *testing.T) {
+			output, err := JSONType.FormatValue(test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.output, *output)
+		})
+	}
+}
+
+func TestJSONParseValue(t *testing.T) {
+	tests := []struct {
+		input       string
+		output      types.JSON
+		expectedErr bool
+	}{
+		{
+			`{"a": [1, 2]}`,
+			mustJSONDoc(t, `{"a":[1,2]}`),
+			false,
+		},
+		{
+			`{"a": [1, 2]`,
+			types.JSON{},
+			true,
+		},
+		{
+			`abc`,
+			types.JSON{},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v %v`, JSONType.String(), test.input), func(t *testing.T) {
+			vrw := types.NewMemoryValueStore()
+			output, err := JSONType.ParseValue(context.Background(), vrw, &test.input)
+			if !test.expectedErr {
+				require.NoError(t, err)
+				assert.True(t, test.output.Equals(output), "%v\n%v", test.output.HumanReadableString(), output.HumanReadableString())
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestJSONSqlTypeConvert(t *testing.T) {
+	tests := []struct {
+		input       interface{}
+		output      interface{}
+		expectedErr bool
+	}{
+		{nil, nil, false},
+		{`{"b": [1.0, 1.50, 1e2], "a": "<a&b>"}`, []byte(`{"a":"<a&b>","b":[1.0,1.50,100.0]}`), false},
+		{[]byte(`[-0, 18446744073709551615]`), []byte(`[0,18446744073709551615]`), false},
+		{map[string]interface{}{"a": "<b>"}, []byte(`{"a":"<b>"}`), false},
+		{`{"a": 1`, nil, true},
+		{`abc`, nil, true},
+	}
+
+	sqlType := JSONType.ToSqlType()
+	for _, test := range tests {
+		t.Run(fmt.Sprintf(`%v`, test.input), func(t *testing.T) {
+			output, err := sqlType.Convert(test.input)
+			if !test.expectedErr {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, output)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -65,6 +65,8 @@ func GetTypeConverter(ctx context.Context, srcTi TypeInfo, destTi TypeInfo) (tc
 		return inlineBlobTypeConverter(ctx, src, destTi)
 	case *intType:
 		return intTypeConverter(ctx, src, destTi)
+	case *jsonType:
+		return jsonTypeConverter(ctx, src, destTi)
 	case *setType:
 		return setTypeConverter(ctx, src, destTi)
 	case *timeType:
@@ -119,6 +121,12 @@ func wrapConvertValueToNomsValue(
 			vInt = *(*string)(unsafe.Pointer(&val))
 		case types.Int:
 			vInt = int64(val)
+		case types.JSON:
+			doc, err := fromJSONDoc(ctx, val)
+			if err != nil {
+				return nil, err
+			}
+			vInt = string(doc)
 		case types.String:
 			vInt = string(val)
 		case types.Timestamp:
@@ -167,6 +167,10 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 
 	// NOTE: system tables are not suitable for caching
 	switch {
+	case lwrName == doltdb.DiffSummaryTableName:
+		// matched before the dolt_diff_ prefix, which it shares
+		found = true
+		dt = dtables.NewDiffSummaryTable(ctx, db.ddb, root, head, db.rsr)
 	case strings.HasPrefix(lwrName, doltdb.DoltDiffTablePrefix):
 		suffix := tblName[len(doltdb.DoltDiffTablePrefix):]
 		found = true
@@ -183,6 +187,10 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		suffix := tblName[len(doltdb.DoltConfTablePrefix):]
 		found = true
 		dt, err = dtables.NewConflictsTable(ctx, suffix, root, dtables.RootSetter(db))
+	case strings.HasPrefix(lwrName, doltdb.DoltBlameTablePrefix):
+		suffix := tblName[len(doltdb.DoltBlameTablePrefix):]
+		found = true
+		dt, err = dtables.NewBlameTable(ctx, suffix, db.ddb, head)
 	}
 	if err != nil {
 		return nil, false, err
@@ -198,19 +206,45 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 	case doltdb.TableOfTablesInConflictName:
 		dt, found = dtables.NewTableOfTablesInConflict(ctx, db.ddb, root), true
 	case doltdb.BranchesTableName:
-		dt, found = dtables.NewBranchesTable(ctx, db.ddb), true
+		dt, found = dtables.NewBranchesTable(ctx, db.ddb, db.rsr, db.rsw), true
 	case doltdb.CommitsTableName:
 		dt, found = dtables.NewCommitsTable(ctx, db.ddb), true
 	case doltdb.CommitAncestorsTableName:
 		dt, found = dtables.NewCommitAncestorsTable(ctx, db.ddb), true
 	case doltdb.StatusTableName:
 		dt, found = dtables.NewStatusTable(ctx, db.ddb, db.rsr, db.drw), true
+	case doltdb.TagsTableName:
+		dSess := DSessFromSess(ctx.Session)
+		dt, found = dtables.NewTagsTable(ctx, db.ddb, db.rsr, dSess.Username, dSess.Email), true
+	case doltdb.RemotesTableName:
+		dt, found = dtables.NewRemotesTable(ctx, db.ddb, db.rsr, db.rsw), true
+	case doltdb.DiffTableName:
+		dt, found = dtables.NewRevisionDiffTable(ctx, db.ddb, root, head, db.rsr), true
+	case doltdb.SchemaDiffTableName:
+		dt, found = dtables.NewSchemaDiffTable(ctx, db.ddb, root, head, db.rsr), true
+	case doltdb.MergeStatusTableName:
+		dt, found = dtables.NewMergeStatusTable(ctx, db.rsr, root), true
+	case doltdb.SchemaConflictsTableName:
+		dt, found = dtables.NewSchemaConflictsTable(ctx, root, dtables.RootSetter(db)), true
 	}
 	if found {
 		return dt, found, nil
 	}
 
-	return db.getTable(ctx, root, tblName)
+	dt, found, err = db.getTable(ctx, root, tblName)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found && lwrName == doltdb.MergePoliciesTableName {
+		// the merge policies table is created by the first insert into it
+		dt, err = newEmptyMergePoliciesTable(db)
+		if err != nil {
+			return nil, false, err
+		}
+		return dt, true, nil
+	}
+
+	return dt, found, nil
 }
 
 // GetTableInsensitiveAsOf implements sql.VersionedDatabase
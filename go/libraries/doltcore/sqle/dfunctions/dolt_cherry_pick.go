@@ -0,0 +1,182 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const DoltCherryPickFuncName = "dolt_cherry_pick"
+
+type DoltCherryPickFunc struct {
+	expression.NaryExpression
+}
+
+// Eval applies the changes introduced by the given commit to the session's HEAD and commits the result, modeling the
+// behavior of `dolt cherry-pick`. Returns the hash of the new commit.
+func (d DoltCherryPickFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+
+	sess := sqle.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(dbName)
+
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ap := cli.CreateCherryPickArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return nil, err
+	}
+
+	apr := cli.ParseArgs(ap, args, nil)
+
+	if apr.NArg() != 1 {
+		return nil, errors.New("error: dolt_cherry_pick requires exactly one commit")
+	}
+
+	root, ok := sess.GetRoot(dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	hasConflicts, err := root.HasConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasConflicts {
+		return nil, errors.New("error: cherry-pick is not possible because you have unresolved conflicts")
+	}
+
+	if dbData.Rsr.IsMergeActive() {
+		return nil, errors.New("error: cherry-pick is not possible because you have not committed an active merge")
+	}
+
+	_, _, headRoot, err := getParent(ctx, err, sess, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForUncommittedChanges(root, headRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := resolveCommitSpec(ctx, dbData, apr.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	mergedRoot, mergeStats, err := merge.CherryPick(ctx, dbData.Ddb, headRoot, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	return commitAppliedRoot(ctx, dbData, mergedRoot, mergeStats, meta.Name, meta.Email, meta.Description)
+}
+
+// resolveCommitSpec resolves a commit spec string relative to the current working branch of |dbData|.
+func resolveCommitSpec(ctx *sql.Context, dbData env.DbData, cSpecStr string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(cSpecStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbData.Ddb.Resolve(ctx, cs, dbData.Rsr.CWBHeadRef())
+}
+
+// commitAppliedRoot writes |appliedRoot| to the working set and, if it has no conflicts, stages and commits it with
+// the message given. Returns the hash of the new commit.
+func commitAppliedRoot(ctx *sql.Context, dbData env.DbData, appliedRoot *doltdb.RootValue, mergeStats map[string]*merge.MergeStats, name, email, msg string) (interface{}, error) {
+	_, err := env.UpdateWorkingRoot(ctx, dbData.Ddb, dbData.Rsw, appliedRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkForConflicts(mergeStats) {
+		return nil, errors.New("merge has conflicts. use the dolt_conflicts table to resolve.")
+	}
+
+	_, err = env.UpdateStagedRoot(ctx, dbData.Ddb, dbData.Rsw, appliedRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := actions.CommitStaged(ctx, dbData, actions.CommitStagedProps{
+		Message:          msg,
+		Date:             ctx.QueryTime(),
+		AllowEmpty:       false,
+		CheckForeignKeys: true,
+		Name:             name,
+		Email:            email,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setHeadAndWorkingSessionRoot(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (d DoltCherryPickFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_CHERRY_PICK(%s)", strings.Join(childrenStrings, ","))
+}
+
+func (d DoltCherryPickFunc) Type() sql.Type {
+	return sql.Text
+}
+
+func (d DoltCherryPickFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltCherryPickFunc(children...)
+}
+
+func NewDoltCherryPickFunc(args ...sql.Expression) (sql.Expression, error) {
+	return &DoltCherryPickFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
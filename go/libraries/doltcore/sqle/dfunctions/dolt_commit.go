@@ -130,6 +130,10 @@ func (d DoltCommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		Email:            email,
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
 	if allFlag {
 		err = setHeadAndWorkingSessionRoot(ctx, h)
 	} else {
@@ -127,7 +127,7 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 
 	if canFF {
 		if apr.Contains(cli.NoFFParam) {
-			err = executeNoFFMerge(ctx, sess, apr, dbData, parent, cm)
+			err = executeNoFFMerge(ctx, sess, apr, dbData, branchName, parent, cm)
 		} else {
 			err = executeFFMerge(ctx, apr.Contains(cli.SquashParam), dbData, cm)
 		}
@@ -138,7 +138,7 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return cmh.String(), err
 	}
 
-	err = executeMerge(ctx, apr.Contains(cli.SquashParam), parent, cm, dbData)
+	err = executeMerge(ctx, apr.Contains(cli.SquashParam), branchName, parent, cm, dbData)
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +168,7 @@ func abortMerge(ctx *sql.Context, dbData env.DbData) error {
 	return setHeadAndWorkingSessionRoot(ctx, hh.String())
 }
 
-func executeMerge(ctx *sql.Context, squash bool, parent, cm *doltdb.Commit, dbData env.DbData) error {
+func executeMerge(ctx *sql.Context, squash bool, commitSpecStr string, parent, cm *doltdb.Commit, dbData env.DbData) error {
 	mergeRoot, mergeStats, err := merge.MergeCommits(ctx, parent, cm)
 
 	if err != nil {
@@ -182,7 +182,7 @@ func executeMerge(ctx *sql.Context, squash bool, parent, cm *doltdb.Commit, dbDa
 		}
 	}
 
-	return mergeRootToWorking(ctx, squash, dbData, mergeRoot, cm, mergeStats)
+	return mergeRootToWorking(ctx, squash, dbData, mergeRoot, commitSpecStr, cm, mergeStats)
 }
 
 func executeFFMerge(ctx *sql.Context, squash bool, dbData env.DbData, cm2 *doltdb.Commit) error {
@@ -229,13 +229,13 @@ func executeFFMerge(ctx *sql.Context, squash bool, dbData env.DbData, cm2 *doltd
 	}
 }
 
-func executeNoFFMerge(ctx *sql.Context, dSess *sqle.DoltSession, apr *argparser.ArgParseResults, dbData env.DbData, pr, cm2 *doltdb.Commit) error {
+func executeNoFFMerge(ctx *sql.Context, dSess *sqle.DoltSession, apr *argparser.ArgParseResults, dbData env.DbData, commitSpecStr string, pr, cm2 *doltdb.Commit) error {
 	mergedRoot, err := cm2.GetRootValue()
 	if err != nil {
 		return errors.New("Failed to return root value.")
 	}
 
-	err = mergeRootToWorking(ctx, false, dbData, mergedRoot, cm2, map[string]*merge.MergeStats{})
+	err = mergeRootToWorking(ctx, false, dbData, mergedRoot, commitSpecStr, cm2, map[string]*merge.MergeStats{})
 	if err != nil {
 		return err
 	}
@@ -292,7 +292,7 @@ func executeNoFFMerge(ctx *sql.Context, dSess *sqle.DoltSession, apr *argparser.
 	return setHeadAndWorkingSessionRoot(ctx, h)
 }
 
-func mergeRootToWorking(ctx *sql.Context, squash bool, dbData env.DbData, mergedRoot *doltdb.RootValue, cm2 *doltdb.Commit, mergeStats map[string]*merge.MergeStats) error {
+func mergeRootToWorking(ctx *sql.Context, squash bool, dbData env.DbData, mergedRoot *doltdb.RootValue, commitSpecStr string, cm2 *doltdb.Commit, mergeStats map[string]*merge.MergeStats) error {
 	h2, err := cm2.HashOf()
 	if err != nil {
 		return err
@@ -300,7 +300,7 @@ func mergeRootToWorking(ctx *sql.Context, squash bool, dbData env.DbData, merged
 
 	workingRoot := mergedRoot
 	if !squash {
-		err = dbData.Rsw.StartMerge(h2.String())
+		err = dbData.Rsw.StartMerge(h2.String(), commitSpecStr)
 
 		if err != nil {
 			return err
@@ -328,7 +328,7 @@ func mergeRootToWorking(ctx *sql.Context, squash bool, dbData env.DbData, merged
 
 func checkForConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	for _, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
+		if stats.Operation == merge.TableModified && stats.HasConflicts() {
 			return true
 		}
 	}
@@ -103,12 +103,19 @@ func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return cmh.String(), nil
 	}
 
-	mergeRoot, _, err := merge.MergeCommits(ctx, parent, cm)
+	mergeRoot, tblToStats, err := merge.MergeCommits(ctx, parent, cm)
 
 	if err != nil {
 		return nil, err
 	}
 
+	// the merge is committed directly, so there is no working set to resolve schema conflicts in
+	for tblName, stats := range tblToStats {
+		if stats.SchemaConflicts > 0 {
+			return nil, schemaConflictsError(ctx, mergeRoot, tblName)
+		}
+	}
+
 	h, err := ddb.WriteRootValue(ctx, mergeRoot)
 	if err != nil {
 		return nil, err
@@ -234,3 +241,17 @@ func (cf *MergeFunc) WithChildren(children ...sql.Expression) (sql.Expression, e
 func (cf *MergeFunc) Type() sql.Type {
 	return sql.Text
 }
+
+func schemaConflictsError(ctx *sql.Context, root *doltdb.RootValue, tblName string) error {
+	tbl, _, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return err
+	}
+
+	sc, err := merge.GetSchemaConflicts(ctx, tblName, tbl)
+	if err != nil {
+		return err
+	}
+
+	return sc.AsError()
+}
@@ -26,7 +26,7 @@ import (
 )
 
 const (
-	resetFuncName = "reset"
+	ResetFuncName = "reset"
 
 	resetHardParameter = "hard"
 )
@@ -56,7 +56,7 @@ func (rf ResetFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 
 	var h hash.Hash
 	if strings.ToLower(arg) != resetHardParameter {
-		return nil, fmt.Errorf("invalid arugument to %s(): %s", resetFuncName, arg)
+		return nil, fmt.Errorf("invalid arugument to %s(): %s", ResetFuncName, arg)
 	}
 
 	parent, _, err := dSess.GetParentCommit(ctx, dbName)
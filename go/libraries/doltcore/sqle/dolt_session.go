@@ -263,59 +263,18 @@ func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, valu
 			return sql.ErrDatabaseNotFound.New(dbName)
 		}
 
-		valStr, isStr := value.(string)
-
-		if !isStr || !hash.IsValid(valStr) {
-			return doltdb.ErrInvalidHash
-		}
-
-		cs, err := doltdb.NewCommitSpec(valStr)
-
-		if err != nil {
-			return err
-		}
-
-		cm, err := dbd.Ddb.Resolve(ctx, cs, nil)
-
-		if err != nil {
-			return err
-		}
-
-		root, err := cm.GetRootValue()
-
-		if err != nil {
-			return err
-		}
-
-		h, err := root.HashOf()
-
-		if err != nil {
-			return err
+		// the head of a revision database only moves when the database is refreshed
+		if rs, ok := dbd.Rsw.(*revisionRepoState); ok {
+			return rs.readOnlyErr()
 		}
 
-		err = sess.Session.Set(ctx, key, typ, value)
-
-		if err != nil {
-			return err
-		}
-
-		hashStr := h.String()
-		err = sess.Session.Set(ctx, dbName+WorkingKeySuffix, sql.Text, hashStr)
-
-		if err != nil {
-			return err
-		}
-
-		sess.dbRoots[dbName] = dbRoot{hashStr, root}
+		valStr, isStr := value.(string)
 
-		err = sess.dbEditors[dbName].SetRoot(ctx, root)
-		if err != nil {
-			return err
+		if !isStr {
+			return doltdb.ErrInvalidHash
 		}
 
-		sess.caches[dbName].Clear()
-
-		return nil
+		return sess.setHead(ctx, key, typ, dbName, valStr)
 	}
 
 	if key == "foreign_key_checks" {
@@ -340,6 +299,64 @@ func (sess *DoltSession) Set(ctx context.Context, key string, typ sql.Type, valu
 	return sess.Session.Set(ctx, key, typ, value)
 }
 
+// setHead sets the head of the database |dbName|, which the session variable |key| holds, to the commit |valStr|, and
+// its working root to the root of that commit.
+func (sess *DoltSession) setHead(ctx context.Context, key string, typ sql.Type, dbName, valStr string) error {
+	dbd := sess.dbDatas[dbName]
+
+	if !hash.IsValid(valStr) {
+		return doltdb.ErrInvalidHash
+	}
+
+	cs, err := doltdb.NewCommitSpec(valStr)
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := dbd.Ddb.Resolve(ctx, cs, nil)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	h, err := root.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	err = sess.Session.Set(ctx, key, typ, valStr)
+
+	if err != nil {
+		return err
+	}
+
+	hashStr := h.String()
+	err = sess.Session.Set(ctx, dbName+WorkingKeySuffix, sql.Text, hashStr)
+
+	if err != nil {
+		return err
+	}
+
+	sess.dbRoots[dbName] = dbRoot{hashStr, root}
+
+	err = sess.dbEditors[dbName].SetRoot(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	sess.caches[dbName].Clear()
+
+	return nil
+}
+
 // SetSessionVarDirectly directly updates sess.Session. This is useful in the context of the sql shell where
 // the working and head session variable may be updated at different times.
 func (sess *DoltSession) SetSessionVarDirectly(ctx context.Context, key string, typ sql.Type, value interface{}) error {
@@ -379,7 +396,7 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 		return err
 	}
 
-	return sess.Set(ctx, name+HeadKeySuffix, sql.Text, h.String())
+	return sess.setHead(ctx, name+HeadKeySuffix, sql.Text, name, h.String())
 }
 
 func newTableCache() TableCache {
@@ -0,0 +1,400 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// BlameEmailCol is the name of the column containing the committer email in the blame table
+	BlameEmailCol = "email"
+
+	// BlameMessageCol is the name of the column containing the commit message in the blame table
+	BlameMessageCol = "message"
+)
+
+var _ sql.Table = (*BlameTable)(nil)
+
+// BlameTable is a system table which shows, for each row of a table at the session head, the commit which last
+// modified the row. Rows are identified by their primary key columns.
+//
+// Blame is computed by walking the history of the head commit in reverse topological order and comparing each commit
+// to its first parent. A row is blamed on the first commit in which it changed, and is returned as soon as its blame
+// is found, so the walk only goes as far back as the oldest change to a row that is read.
+type BlameTable struct {
+	name   string
+	ddb    *doltdb.DoltDB
+	head   *doltdb.Commit
+	tbl    *doltdb.Table
+	pkSch  schema.Schema
+	sqlSch sql.Schema
+}
+
+// NewBlameTable creates a BlameTable for the table named |tblName| at the |head| commit.
+func NewBlameTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, head *doltdb.Commit) (sql.Table, error) {
+	blameTblName := doltdb.DoltBlameTablePrefix + tblName
+
+	if head == nil {
+		return nil, sql.ErrTableNotFound.New(blameTblName)
+	}
+
+	root, err := head.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, name, ok, err := root.GetTableInsensitive(ctx, tblName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(blameTblName)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.IsKeyless(sch) {
+		return nil, fmt.Errorf("%s cannot be queried because table %s has no primary key", blameTblName, name)
+	}
+
+	pkSch, err := schema.SchemaFromCols(sch.GetPKCols())
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSch, err := sqlutil.FromDoltSchema(blameTblName, pkSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSch = append(sqlSch,
+		&sql.Column{Name: CommitHashCol, Type: sql.Text, Source: blameTblName, PrimaryKey: false, Nullable: false},
+		&sql.Column{Name: CommitterCol, Type: sql.Text, Source: blameTblName, PrimaryKey: false, Nullable: false},
+		&sql.Column{Name: BlameEmailCol, Type: sql.Text, Source: blameTblName, PrimaryKey: false, Nullable: false},
+		&sql.Column{Name: CommitDateCol, Type: sql.Datetime, Source: blameTblName, PrimaryKey: false, Nullable: false},
+		&sql.Column{Name: BlameMessageCol, Type: sql.Text, Source: blameTblName, PrimaryKey: false, Nullable: false},
+	)
+
+	return &BlameTable{
+		name:   name,
+		ddb:    ddb,
+		head:   head,
+		tbl:    tbl,
+		pkSch:  pkSch,
+		sqlSch: sqlSch,
+	}, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (bt *BlameTable) Name() string {
+	return doltdb.DoltBlameTablePrefix + bt.name
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (bt *BlameTable) String() string {
+	return doltdb.DoltBlameTablePrefix + bt.name
+}
+
+// Schema is a sql.Table interface function that returns the primary key columns of the blamed table followed by the
+// columns describing the commit which last modified each row
+func (bt *BlameTable) Schema() sql.Schema {
+	return bt.sqlSch
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (bt *BlameTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (bt *BlameTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	return newBlameRowItr(ctx, bt)
+}
+
+// blameRowItr is a sql.RowIter which blames the rows of a table one commit at a time.
+type blameRowItr struct {
+	ctx   context.Context
+	bt    *BlameTable
+	cmItr doltdb.CommitItr
+
+	// unblamed holds the keys of the rows whose blame has not yet been found
+	unblamed map[hash.Hash]types.Value
+	// blamed holds the rows blamed on the last commit visited, which have not yet been returned
+	blamed []sql.Row
+}
+
+func newBlameRowItr(ctx *sql.Context, bt *BlameTable) (*blameRowItr, error) {
+	h, err := bt.head.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cmItr, err := commitwalk.GetTopologicalOrderIterator(ctx, bt.ddb, h)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := bt.tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nbf := bt.tbl.Format()
+	unblamed := make(map[hash.Hash]types.Value, m.Len())
+	err = m.IterAll(ctx, func(key, _ types.Value) error {
+		kh, err := key.Hash(nbf)
+
+		if err != nil {
+			return err
+		}
+
+		unblamed[kh] = key
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &blameRowItr{ctx: ctx, bt: bt, cmItr: cmItr, unblamed: unblamed}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *blameRowItr) Next() (sql.Row, error) {
+	for len(itr.blamed) == 0 {
+		if len(itr.unblamed) == 0 {
+			return nil, io.EOF
+		}
+
+		_, cm, err := itr.cmItr.Next(itr.ctx)
+
+		if err == io.EOF {
+			return nil, fmt.Errorf("couldn't find blame for %d rows of table %s", len(itr.unblamed), itr.bt.name)
+		} else if err != nil {
+			return nil, err
+		}
+
+		err = itr.blameCommit(cm)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r := itr.blamed[0]
+	itr.blamed = itr.blamed[1:]
+	return r, nil
+}
+
+// blameCommit blames the unblamed rows which changed between |cm| and its first parent on |cm|.
+func (itr *blameRowItr) blameCommit(cm *doltdb.Commit) error {
+	changed, all, err := itr.changedKeys(cm)
+
+	if err != nil {
+		return err
+	}
+
+	var keys []types.Value
+	if all {
+		for _, key := range itr.unblamed {
+			keys = append(keys, key)
+		}
+	} else {
+		nbf := itr.bt.tbl.Format()
+		for _, key := range changed {
+			kh, err := key.Hash(nbf)
+
+			if err != nil {
+				return err
+			}
+
+			if _, ok := itr.unblamed[kh]; ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return err
+	}
+
+	nbf := itr.bt.tbl.Format()
+	for _, key := range keys {
+		r, err := row.FromNoms(itr.bt.pkSch, key.(types.Tuple), types.EmptyTuple(nbf))
+
+		if err != nil {
+			return err
+		}
+
+		sqlRow, err := sqlutil.DoltRowToSqlRow(r, itr.bt.pkSch)
+
+		if err != nil {
+			return err
+		}
+
+		sqlRow = append(sqlRow, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description)
+		itr.blamed = append(itr.blamed, sqlRow)
+
+		kh, err := key.Hash(nbf)
+
+		if err != nil {
+			return err
+		}
+
+		delete(itr.unblamed, kh)
+	}
+
+	return nil
+}
+
+// changedKeys returns the keys of the rows of the table which changed between |cm| and its first parent. If every row
+// is considered changed, because the table or its schema changed, or |cm| has no parent, |all| is true.
+func (itr *blameRowItr) changedKeys(cm *doltdb.Commit) (keys []types.Value, all bool, err error) {
+	numParents, err := cm.NumParents()
+
+	if err != nil {
+		return nil, false, err
+	} else if numParents == 0 {
+		return nil, true, nil
+	}
+
+	parent, err := itr.bt.ddb.ResolveParent(itr.ctx, cm, 0)
+
+	if err == doltdb.ErrShallowBoundary {
+		// the history of a shallow clone ends here, so its remaining rows are blamed on this commit
+		return nil, true, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	tbl, sch, err := tableAndSchemaAtCommit(itr.ctx, cm, itr.bt.name)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	parentTbl, parentSch, err := tableAndSchemaAtCommit(itr.ctx, parent, itr.bt.name)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if tbl == nil {
+		return nil, false, nil
+	} else if parentTbl == nil || !schema.SchemasAreEqual(sch, parentSch) {
+		return nil, true, nil
+	}
+
+	m, err := tbl.GetRowData(itr.ctx)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	parentM, err := parentTbl.GetRowData(itr.ctx)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	ad := diff.NewAsyncDiffer(1024)
+	ad.Start(itr.ctx, parentM, m)
+	defer func() {
+		if cerr := ad.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for {
+		diffs, more, err := ad.GetDiffsWithoutTimeout(1024)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, d := range diffs {
+			keys = append(keys, d.KeyValue)
+		}
+
+		if !more {
+			return keys, false, nil
+		}
+	}
+}
+
+// tableAndSchemaAtCommit returns the table named |tblName| at |cm| and its schema, or nils if it does not exist.
+func tableAndSchemaAtCommit(ctx context.Context, cm *doltdb.Commit, tblName string) (*doltdb.Table, schema.Schema, error) {
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tbl, ok, err := root.GetTable(ctx, tblName)
+
+	if err != nil || !ok {
+		return nil, nil, err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tbl, sch, nil
+}
+
+// Close closes the iterator.
+func (itr *blameRowItr) Close(*sql.Context) error {
+	return nil
+}
@@ -16,11 +16,14 @@ package dtables
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/types"
@@ -32,14 +35,19 @@ var _ sql.DeletableTable = (*BranchesTable)(nil)
 var _ sql.InsertableTable = (*BranchesTable)(nil)
 var _ sql.ReplaceableTable = (*BranchesTable)(nil)
 
-// BranchesTable is a sql.Table implementation that implements a system table which shows the dolt branches
+// BranchesTable is a sql.Table implementation that implements a system table which shows the dolt branches. Inserting,
+// updating and deleting rows creates, moves, renames and deletes branches. Branch refs are not part of the working
+// root, so these changes are written when the statement ends rather than when the transaction is committed, and they
+// are not undone by ROLLBACK.
 type BranchesTable struct {
 	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
+	rsw env.RepoStateWriter
 }
 
 // NewBranchesTable creates a BranchesTable
-func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
-	return &BranchesTable{ddb}
+func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter) sql.Table {
+	return &BranchesTable{ddb, rsr, rsw}
 }
 
 // Name is a sql.Table interface function which returns the name of the table which is defined by the constant
@@ -143,34 +151,48 @@ func (itr *BranchItr) Close(*sql.Context) error {
 // Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
 // for each row, followed by a call to Close() when all rows have been processed.
 func (bt *BranchesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
-	return branchWriter{bt}
+	return &branchWriter{bt: bt, replace: true}
 }
 
 // Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
 // updated, followed by a call to Close() when all rows have been processed.
 func (bt *BranchesTable) Updater(ctx *sql.Context) sql.RowUpdater {
-	return branchWriter{bt}
+	return &branchWriter{bt: bt}
 }
 
 // Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
 // inserted, and will end with a call to Close() to finalize the insert operation.
 func (bt *BranchesTable) Inserter(*sql.Context) sql.RowInserter {
-	return branchWriter{bt}
+	return &branchWriter{bt: bt}
 }
 
 // Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
 // and will end with a call to Close() to finalize the delete operation.
 func (bt *BranchesTable) Deleter(*sql.Context) sql.RowDeleter {
-	return branchWriter{bt}
+	return &branchWriter{bt: bt}
 }
 
-var _ sql.RowReplacer = branchWriter{nil}
-var _ sql.RowUpdater = branchWriter{nil}
-var _ sql.RowInserter = branchWriter{nil}
-var _ sql.RowDeleter = branchWriter{nil}
+var _ sql.RowReplacer = (*branchWriter)(nil)
+var _ sql.RowUpdater = (*branchWriter)(nil)
+var _ sql.RowInserter = (*branchWriter)(nil)
+var _ sql.RowDeleter = (*branchWriter)(nil)
 
+// branchWriter validates the branch changes of a statement as each row is processed, and applies them when the
+// statement is closed. If any row fails, none of the changes are applied, and if applying a change fails the branches
+// already changed are restored. Renaming a branch also moves its config, such as its upstream, to the new name.
 type branchWriter struct {
-	bt *BranchesTable
+	bt      *BranchesTable
+	replace bool
+	edits   []branchEdit
+	renames [][2]string
+	newHead ref.DoltRef
+	err     error
+}
+
+// branchEdit moves the branch |dref| to the commit |cm|, or deletes it if |cm| is nil.
+type branchEdit struct {
+	dref ref.BranchRef
+	cm   *doltdb.Commit
 }
 
 func branchAndHashFromRow(r sql.Row) (string, string, error) {
@@ -191,63 +213,231 @@ func branchAndHashFromRow(r sql.Row) (string, string, error) {
 	return branchName, commitHash, nil
 }
 
+func (bWr *branchWriter) resolve(ctx *sql.Context, commitHash string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(commitHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bWr.bt.ddb.Resolve(ctx, cs, nil)
+}
+
+// exists returns whether the branch |dref| exists once the edits of the statement so far are applied.
+func (bWr *branchWriter) exists(ctx *sql.Context, dref ref.DoltRef) (bool, error) {
+	for i := len(bWr.edits) - 1; i >= 0; i-- {
+		if ref.Equals(bWr.edits[i].dref, dref) {
+			return bWr.edits[i].cm != nil, nil
+		}
+	}
+
+	return bWr.bt.ddb.HasRef(ctx, dref)
+}
+
+// fail records that a row could not be processed, so that the changes of the statement are discarded on Close.
+func (bWr *branchWriter) fail(err error) error {
+	bWr.err = err
+	return err
+}
+
 // Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
 // for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
-// is called.
-func (bWr branchWriter) Insert(ctx *sql.Context, r sql.Row) error {
+// is called. Inserting a branch which already exists is an error, but replacing it moves it to the given commit.
+func (bWr *branchWriter) Insert(ctx *sql.Context, r sql.Row) error {
 	branchName, commitHash, err := branchAndHashFromRow(r)
 
 	if err != nil {
-		return err
+		return bWr.fail(err)
 	}
 
-	cs, err := doltdb.NewCommitSpec(commitHash)
+	brRef := ref.NewBranchRef(branchName)
+	if !bWr.replace {
+		exists, err := bWr.exists(ctx, brRef)
 
-	if err != nil {
-		return err
+		if err != nil {
+			return bWr.fail(err)
+		} else if exists {
+			return bWr.fail(fmt.Errorf("a branch named '%s' already exists", branchName))
+		}
 	}
 
-	ddb := bWr.bt.ddb
-	cm, err := ddb.Resolve(ctx, cs, nil)
+	cm, err := bWr.resolve(ctx, commitHash)
 
 	if err != nil {
-		return err
+		return bWr.fail(err)
 	}
 
-	branchRef := ref.NewBranchRef(branchName)
-	return ddb.NewBranchAtCommit(ctx, branchRef, cm)
+	bWr.edits = append(bWr.edits, branchEdit{brRef, cm})
+	return nil
 }
 
-// Update the given row. Provides both the old and new rows.
-func (bWr branchWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
-	return bWr.Insert(ctx, new)
+// Update the given row. Provides both the old and new rows. Changing the name of a branch renames it, and changing
+// its hash moves it to the given commit.
+func (bWr *branchWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	oldName, oldHash, err := branchAndHashFromRow(old)
+
+	if err != nil {
+		return bWr.fail(err)
+	}
+
+	newName, newHash, err := branchAndHashFromRow(new)
+
+	if err != nil {
+		return bWr.fail(err)
+	}
+
+	if oldName == newName && oldHash == newHash {
+		return nil
+	}
+
+	cm, err := bWr.resolve(ctx, newHash)
+
+	if err != nil {
+		return bWr.fail(err)
+	}
+
+	newRef := ref.NewBranchRef(newName)
+	if oldName != newName {
+		exists, err := bWr.exists(ctx, newRef)
+
+		if err != nil {
+			return bWr.fail(err)
+		} else if exists {
+			return bWr.fail(fmt.Errorf("a branch named '%s' already exists", newName))
+		}
+
+		oldRef := ref.NewBranchRef(oldName)
+		bWr.edits = append(bWr.edits, branchEdit{oldRef, nil})
+		bWr.renames = append(bWr.renames, [2]string{oldName, newName})
+
+		if ref.Equals(bWr.bt.rsr.CWBHeadRef(), oldRef) {
+			bWr.newHead = newRef
+		}
+	}
+
+	bWr.edits = append(bWr.edits, branchEdit{newRef, cm})
+	return nil
 }
 
 // Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
 // each row to process for the delete operation, which may involve many rows. After all rows have been processed,
-// Close is called.
-func (bWr branchWriter) Delete(ctx *sql.Context, r sql.Row) error {
+// Close is called. As with dolt branch -d, the checked out branch and branches which are not fully merged into master
+// cannot be deleted.
+func (bWr *branchWriter) Delete(ctx *sql.Context, r sql.Row) error {
 	branchName, _, err := branchAndHashFromRow(r)
 
 	if err != nil {
-		return err
+		return bWr.fail(err)
 	}
 
 	brRef := ref.NewBranchRef(branchName)
-	exists, err := bWr.bt.ddb.HasRef(ctx, brRef)
+	exists, err := bWr.exists(ctx, brRef)
 
 	if err != nil {
-		return err
+		return bWr.fail(err)
 	}
 
 	if !exists {
 		return sql.ErrDeleteRowNotFound.New()
 	}
 
-	return bWr.bt.ddb.DeleteBranch(ctx, brRef)
+	// a replace deletes the existing row before inserting the new one, which moves the branch
+	if bWr.replace {
+		return nil
+	}
+
+	if ref.Equals(bWr.bt.rsr.CWBHeadRef(), brRef) {
+		return bWr.fail(actions.ErrCOBranchDelete)
+	}
+
+	err = actions.CheckBranchIsMerged(ctx, bWr.bt.ddb, brRef)
+
+	if err != nil {
+		return bWr.fail(err)
+	}
+
+	bWr.edits = append(bWr.edits, branchEdit{brRef, nil})
+	return nil
 }
 
-// Close finalizes the delete operation, persisting the result.
-func (bWr branchWriter) Close(*sql.Context) error {
+// Close applies the changes of the statement, unless one of its rows failed. If a change can not be applied, the
+// branches changed before it are restored to the commits they were at. The changes are written to the database
+// directly, outside of the session's transaction.
+func (bWr *branchWriter) Close(ctx *sql.Context) error {
+	if bWr.err != nil || len(bWr.edits) == 0 {
+		return nil
+	}
+
+	ddb := bWr.bt.ddb
+	var restore []branchEdit
+	seen := make(map[string]bool)
+	for _, edit := range bWr.edits {
+		if seen[edit.dref.String()] {
+			continue
+		}
+		seen[edit.dref.String()] = true
+
+		exists, err := ddb.HasRef(ctx, edit.dref)
+
+		if err != nil {
+			return err
+		}
+
+		prev := branchEdit{dref: edit.dref}
+		if exists {
+			prev.cm, err = ddb.ResolveRef(ctx, edit.dref)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		restore = append(restore, prev)
+	}
+
+	for _, edit := range bWr.edits {
+		if err := applyBranchEdit(ctx, ddb, edit); err != nil {
+			return restoreBranches(ctx, ddb, restore, err)
+		}
+	}
+
+	for _, rename := range bWr.renames {
+		if err := bWr.bt.rsw.RenameBranchConfig(rename[0], rename[1]); err != nil {
+			return restoreBranches(ctx, ddb, restore, err)
+		}
+	}
+
+	if bWr.newHead != nil {
+		if err := bWr.bt.rsw.SetCWBHeadRef(ctx, ref.MarshalableRef{Ref: bWr.newHead}); err != nil {
+			return restoreBranches(ctx, ddb, restore, err)
+		}
+	}
+
 	return nil
 }
+
+func applyBranchEdit(ctx *sql.Context, ddb *doltdb.DoltDB, edit branchEdit) error {
+	if edit.cm != nil {
+		return ddb.NewBranchAtCommit(ctx, edit.dref, edit.cm)
+	}
+
+	err := ddb.DeleteBranch(ctx, edit.dref)
+
+	if err == doltdb.ErrBranchNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// restoreBranches puts the branches of |restore| back to the commits they were at before a statement, after applying
+// its changes failed with |err|.
+func restoreBranches(ctx *sql.Context, ddb *doltdb.DoltDB, restore []branchEdit, err error) error {
+	for _, prev := range restore {
+		if rErr := applyBranchEdit(ctx, ddb, prev); rErr != nil {
+			return fmt.Errorf("%w; restoring branch '%s' also failed: %v", err, prev.dref.GetPath(), rErr)
+		}
+	}
+
+	return err
+}
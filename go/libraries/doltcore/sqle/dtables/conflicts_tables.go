@@ -15,15 +15,29 @@ package dtables
 // limitations under the License.
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+var ErrConflictsKeylessUpdate = errors.New("conflicts of tables without a primary key cannot be updated")
+
+const ourColPrefix = "our_"
+
 var _ sql.Table = ConflictsTable{}
+var _ sql.DeletableTable = ConflictsTable{}
+var _ sql.UpdatableTable = ConflictsTable{}
 
 // ConflictsTable is a sql.Table implementation that provides access to the conflicts that exist for a user table
 type ConflictsTable struct {
@@ -97,11 +111,18 @@ func (ct ConflictsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sq
 }
 
 // Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
-// and will end with a call to Close() to finalize the delete operation.
+// and will end with a call to Close() to finalize the delete operation. Deleting a conflict resolves it, writing our
+// values to the table if they were edited.
 func (ct ConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
 	return &conflictDeleter{ct: ct, rs: ct.rs}
 }
 
+// Updater returns a RowUpdater for this table. Only the our_ columns which are not part of the primary key can be
+// updated. The edited values are kept with the conflict, and written to the table when the conflict is deleted.
+func (ct ConflictsTable) Updater(*sql.Context) sql.RowUpdater {
+	return &conflictUpdater{ct: ct, rs: ct.rs}
+}
+
 type conflictRowIter struct {
 	ctx *sql.Context
 	rd  *merge.ConflictReader
@@ -156,13 +177,25 @@ func (cd *conflictDeleter) Delete(ctx *sql.Context, r sql.Row) error {
 
 // Close finalizes the delete operation, persisting the result.
 func (cd *conflictDeleter) Close(ctx *sql.Context) error {
-	_, _, updatedTbl, err := cd.ct.tbl.ResolveConflicts(ctx, cd.pks)
+	root, err := cd.writeEditedConflicts(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tbl, _, err := root.GetTable(ctx, cd.ct.tblName)
+
+	if err != nil {
+		return err
+	}
+
+	_, _, updatedTbl, err := tbl.ResolveConflicts(ctx, cd.pks)
 
 	if err != nil {
 		return err
 	}
 
-	updatedRoot, err := cd.ct.root.PutTable(ctx, cd.ct.tblName, updatedTbl)
+	updatedRoot, err := root.PutTable(ctx, cd.ct.tblName, updatedTbl)
 
 	if err != nil {
 		return err
@@ -170,3 +203,222 @@ func (cd *conflictDeleter) Close(ctx *sql.Context) error {
 
 	return cd.rs.SetRoot(ctx, updatedRoot)
 }
+
+// writeEditedConflicts writes our edited values of the deleted conflicts to the table, and returns the updated root.
+func (cd *conflictDeleter) writeEditedConflicts(ctx context.Context) (*doltdb.RootValue, error) {
+	_, confData, err := cd.ct.tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sch, err := cd.ct.tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowData, err := cd.ct.tbl.GetRowData(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sess := editor.CreateTableEditSession(cd.ct.root, editor.TableEditSessionProps{})
+	var tblEditor editor.TableEditor
+	for _, pk := range cd.pks {
+		val, ok, err := confData.MaybeGet(ctx, pk)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		cnf, err := doltdb.ConflictFromTuple(val.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		} else if !cnf.IsEdited() {
+			continue
+		}
+
+		if tblEditor == nil {
+			tblEditor, err = sess.GetTableEditor(ctx, cd.ct.tblName, sch)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		editedRow, err := row.FromNoms(sch, pk.(types.Tuple), cnf.EditedValue.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		}
+
+		currVal, ok, err := rowData.MaybeGet(ctx, pk)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			currRow, err := row.FromNoms(sch, pk.(types.Tuple), currVal.(types.Tuple))
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = tblEditor.UpdateRow(ctx, currRow, editedRow)
+		} else {
+			err = tblEditor.InsertRow(ctx, editedRow)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tblEditor == nil {
+		return cd.ct.root, nil
+	}
+
+	return sess.Flush(ctx)
+}
+
+var _ sql.RowUpdater = &conflictUpdater{}
+
+type conflictUpdater struct {
+	ct     ConflictsTable
+	rs     RootSetter
+	keys   []types.Value
+	values []types.Value
+}
+
+// Update sets our value of the conflict in |old| to the our_ columns of |new|. It is an error to change any other
+// column.
+func (cu *conflictUpdater) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	cnfSch := cu.ct.rd.GetSchema()
+	tblSch, err := cu.ct.tbl.GetSchema(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if schema.IsKeyless(tblSch) {
+		return ErrConflictsKeylessUpdate
+	}
+
+	for i, col := range cu.ct.sqlSch {
+		if isEditableConflictCol(tblSch, col.Name) {
+			continue
+		}
+
+		cmp, err := col.Type.Compare(old[i], new[i])
+
+		if err != nil {
+			return err
+		} else if cmp != 0 {
+			return fmt.Errorf("cannot update column '%s' of %s: only %s columns which are not part of the primary key can be updated", col.Name, cu.ct.Name(), ourColPrefix)
+		}
+	}
+
+	oldRow, err := sqlutil.SqlRowToDoltRow(ctx, cu.ct.tbl.ValueReadWriter(), old, cnfSch)
+
+	if err != nil {
+		return err
+	}
+
+	newRow, err := sqlutil.SqlRowToDoltRow(ctx, cu.ct.tbl.ValueReadWriter(), new, cnfSch)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := cu.ct.rd.GetKeyForConflict(ctx, oldRow)
+
+	if err != nil {
+		return err
+	}
+
+	ourVal, err := cu.ct.rd.GetOurValueForConflict(ctx, key, newRow)
+
+	if err != nil {
+		return err
+	}
+
+	cu.keys = append(cu.keys, key)
+	cu.values = append(cu.values, ourVal)
+	return nil
+}
+
+// isEditableConflictCol returns whether |colName| is an our_ column of a conflicts table which is not part of the
+// primary key of |sch|, the schema of the conflicted table.
+func isEditableConflictCol(sch schema.Schema, colName string) bool {
+	if !strings.HasPrefix(colName, ourColPrefix) {
+		return false
+	}
+
+	_, isPk := sch.GetPKCols().GetByNameCaseInsensitive(strings.TrimPrefix(colName, ourColPrefix))
+	return !isPk
+}
+
+// Close finalizes the update operation, persisting the edited conflicts.
+func (cu *conflictUpdater) Close(ctx *sql.Context) error {
+	if len(cu.keys) == 0 {
+		return nil
+	}
+
+	schemas, confData, err := cu.ct.tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	confEdit := confData.Edit()
+	for i, key := range cu.keys {
+		val, ok, err := confData.MaybeGet(ctx, key)
+
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		cnf, err := doltdb.ConflictFromTuple(val.(types.Tuple))
+
+		if err != nil {
+			return err
+		}
+
+		cnf.EditedValue = cu.values[i]
+		cnfTpl, err := cnf.ToNomsList(cu.ct.tbl.ValueReadWriter())
+
+		if err != nil {
+			return err
+		}
+
+		confEdit.Set(key, cnfTpl)
+	}
+
+	confData, err = confEdit.Map(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	updatedTbl, err := cu.ct.tbl.SetConflicts(ctx, schemas, confData)
+
+	if err != nil {
+		return err
+	}
+
+	updatedRoot, err := cu.ct.root.PutTable(ctx, cu.ct.tblName, updatedTbl)
+
+	if err != nil {
+		return err
+	}
+
+	return cu.rs.SetRoot(ctx, updatedRoot)
+}
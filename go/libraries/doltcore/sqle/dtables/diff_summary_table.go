@@ -0,0 +1,204 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrDiffSummaryOneToCommit = errors.New("dolt_diff_summary must be filtered to a single 'to_commit'")
+var ErrDiffSummaryOneFromCommit = errors.New("dolt_diff_summary must be filtered to a single 'from_commit'")
+
+var _ sql.Table = (*DiffSummaryTable)(nil)
+var _ sql.FilteredTable = (*DiffSummaryTable)(nil)
+
+// DiffSummaryTable is a sql.Table implementation of a system table which summarizes the row changes to each table
+// between two revisions, as dolt diff --summary does. The revisions are selected by filtering the 'from_commit' and
+// 'to_commit' columns, which may be commit specs, WORKING or STAGED.
+type DiffSummaryTable struct {
+	revs       revisionResolver
+	revFilters revisionFilters
+}
+
+// NewDiffSummaryTable creates a DiffSummaryTable
+func NewDiffSummaryTable(_ *sql.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, head *doltdb.Commit, rsr env.RepoStateReader) sql.Table {
+	return &DiffSummaryTable{
+		revs:       revisionResolver{ddb: ddb, working: root, head: head, rsr: rsr},
+		revFilters: revisionFilters{errOneFrom: ErrDiffSummaryOneFromCommit, errOneTo: ErrDiffSummaryOneToCommit},
+	}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffSummaryTableName
+func (dt *DiffSummaryTable) Name() string {
+	return doltdb.DiffSummaryTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffSummaryTableName
+func (dt *DiffSummaryTable) String() string {
+	return doltdb.DiffSummaryTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the diff summary system table. The counts of
+// unmodified and modified rows and cells are NULL for keyless tables, which only track rows added and deleted.
+func (dt *DiffSummaryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true, Nullable: false},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_name", Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true, Nullable: false},
+		{Name: "rows_unmodified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: true},
+		{Name: "rows_added", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "rows_deleted", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: false},
+		{Name: "rows_modified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: true},
+		{Name: "cells_modified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: true},
+		{Name: "old_row_count", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: true},
+		{Name: "new_row_count", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (dt *DiffSummaryTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *DiffSummaryTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	from, to, err := dt.revFilters.revisions(ctx, dt.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromRoot, toRoot, err := dt.revs.rootsForRevisions(ctx, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := sortedTableDeltas(ctx, fromRoot, toRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, td := range deltas {
+		if td.CurName() == doltdb.DocTableName {
+			continue
+		}
+
+		row, err := diffSummaryRow(ctx, from, to, td)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if row != nil {
+			rows = append(rows, row)
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// HandledFilters returns the list of filters that will be handled by the table itself
+func (dt *DiffSummaryTable) HandledFilters(filters []sql.Expression) []sql.Expression {
+	return dt.revFilters.handledFilters(filters)
+}
+
+// Filters returns the list of filters that are applied to this table.
+func (dt *DiffSummaryTable) Filters() []sql.Expression {
+	return dt.revFilters.filters()
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (dt *DiffSummaryTable) WithFilters(filters []sql.Expression) sql.Table {
+	return dt
+}
+
+// sortedTableDeltas returns the table deltas between |fromRoot| and |toRoot|, ordered by table name.
+func sortedTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) ([]diff.TableDelta, error) {
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	return deltas, nil
+}
+
+// diffSummaryRow returns the row of the diff summary table for |td|, or nil if the rows of the table did not change.
+func diffSummaryRow(ctx context.Context, from, to string, td diff.TableDelta) (sql.Row, error) {
+	keyless, err := td.IsKeyless(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := summarizeTableDelta(ctx, td)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.Adds+acc.Removes+acc.Changes == 0 {
+		return nil, nil
+	}
+
+	if keyless {
+		return sql.NewRow(from, to, td.CurName(), nil, acc.Adds, acc.Removes, nil, nil, nil, nil), nil
+	}
+
+	unmodified := acc.OldSize - acc.Changes - acc.Removes
+	return sql.NewRow(from, to, td.CurName(), unmodified, acc.Adds, acc.Removes, acc.Changes, acc.CellChanges, acc.OldSize, acc.NewSize), nil
+}
+
+// summarizeTableDelta accumulates the progress reported by diff.SummaryForTableDelta.
+func summarizeTableDelta(ctx context.Context, td diff.TableDelta) (diff.DiffSummaryProgress, error) {
+	ch := make(chan diff.DiffSummaryProgress)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(ch)
+		return diff.SummaryForTableDelta(egCtx, ch, td)
+	})
+
+	acc := diff.DiffSummaryProgress{}
+	for p := range ch {
+		acc.Adds += p.Adds
+		acc.Removes += p.Removes
+		acc.Changes += p.Changes
+		acc.CellChanges += p.CellChanges
+		acc.NewSize += p.NewSize
+		acc.OldSize += p.OldSize
+	}
+
+	return acc, eg.Wait()
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*MergeStatusTable)(nil)
+
+// MergeStatusTable is a sql.Table implementation of a system table with a single row which shows whether a merge is in
+// progress, and if so, the commit being merged, the branch it is being merged into and the tables with unresolved
+// conflicts. It reads the merge state persisted in the repo state.
+type MergeStatusTable struct {
+	rsr  env.RepoStateReader
+	root *doltdb.RootValue
+}
+
+// NewMergeStatusTable creates a MergeStatusTable. Unmerged tables are those with conflicts in |root|.
+func NewMergeStatusTable(_ *sql.Context, rsr env.RepoStateReader, root *doltdb.RootValue) sql.Table {
+	return &MergeStatusTable{rsr: rsr, root: root}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// MergeStatusTableName
+func (mt *MergeStatusTable) Name() string {
+	return doltdb.MergeStatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// MergeStatusTableName
+func (mt *MergeStatusTable) String() string {
+	return doltdb.MergeStatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the merge status system table. All columns but
+// is_merging are NULL when no merge is in progress.
+func (mt *MergeStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "is_merging", Type: sql.Boolean, Source: doltdb.MergeStatusTableName, PrimaryKey: false, Nullable: false},
+		{Name: "source", Type: sql.Text, Source: doltdb.MergeStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "source_commit", Type: sql.Text, Source: doltdb.MergeStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "target", Type: sql.Text, Source: doltdb.MergeStatusTableName, PrimaryKey: false, Nullable: true},
+		{Name: "unmerged_tables", Type: sql.Text, Source: doltdb.MergeStatusTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (mt *MergeStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (mt *MergeStatusTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	if !mt.rsr.IsMergeActive() {
+		return sql.RowsToRowIter(sql.NewRow(false, nil, nil, nil, nil)), nil
+	}
+
+	tblNames, err := mt.root.TablesInConflict(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var unmerged interface{}
+	if len(tblNames) > 0 {
+		sort.Strings(tblNames)
+		unmerged = strings.Join(tblNames, ", ")
+	}
+
+	target := mt.rsr.CWBHeadRef().GetPath()
+	return sql.RowsToRowIter(sql.NewRow(true, mt.rsr.GetMergeCommitSpec(), mt.rsr.GetMergeCommit(), target, unmerged)), nil
+}
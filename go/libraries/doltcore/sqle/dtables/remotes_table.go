@@ -0,0 +1,305 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*RemotesTable)(nil)
+var _ sql.InsertableTable = (*RemotesTable)(nil)
+var _ sql.DeletableTable = (*RemotesTable)(nil)
+
+// RemotesTable is a sql.Table implementation that implements a system table which shows the dolt remotes
+type RemotesTable struct {
+	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
+	rsw env.RepoStateWriter
+}
+
+// NewRemotesTable creates a RemotesTable
+func NewRemotesTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, rsw env.RepoStateWriter) sql.Table {
+	return &RemotesTable{ddb: ddb, rsr: rsr, rsw: rsw}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemotesTableName
+func (rt *RemotesTable) Name() string {
+	return doltdb.RemotesTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// RemotesTableName
+func (rt *RemotesTable) String() string {
+	return doltdb.RemotesTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the remotes system table
+func (rt *RemotesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: sql.Text, Source: doltdb.RemotesTableName, PrimaryKey: true, Nullable: false},
+		{Name: "url", Type: sql.Text, Source: doltdb.RemotesTableName, PrimaryKey: false, Nullable: false},
+		{Name: "fetch_specs", Type: sql.JSON, Source: doltdb.RemotesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "params", Type: sql.JSON, Source: doltdb.RemotesTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (rt *RemotesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (rt *RemotesTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	return NewRemoteItr(sqlCtx, rt.rsr)
+}
+
+// RemoteItr is a sql.RowItr implementation which iterates over each remote as if it's a row in the table.
+type RemoteItr struct {
+	remotes []env.Remote
+	idx     int
+}
+
+// NewRemoteItr creates a RemoteItr from the remotes in the repo state.
+func NewRemoteItr(_ *sql.Context, rsr env.RepoStateReader) (*RemoteItr, error) {
+	remoteMap, err := rsr.GetRemotes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]env.Remote, 0, len(remoteMap))
+	for _, r := range remoteMap {
+		remotes = append(remotes, r)
+	}
+
+	sort.Slice(remotes, func(i, j int) bool {
+		return remotes[i].Name < remotes[j].Name
+	})
+
+	return &RemoteItr{remotes, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *RemoteItr) Next() (sql.Row, error) {
+	if itr.idx >= len(itr.remotes) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	r := itr.remotes[itr.idx]
+	fetchSpecs, err := json.Marshal(r.FetchSpecs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := r.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	paramsJSON, err := json.Marshal(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.NewRow(r.Name, r.Url, fetchSpecs, paramsJSON), nil
+}
+
+// Close closes the iterator.
+func (itr *RemoteItr) Close(*sql.Context) error {
+	return nil
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (rt *RemotesTable) Inserter(*sql.Context) sql.RowInserter {
+	return remoteWriter{rt}
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (rt *RemotesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return remoteWriter{rt}
+}
+
+var _ sql.RowInserter = remoteWriter{nil}
+var _ sql.RowDeleter = remoteWriter{nil}
+
+type remoteWriter struct {
+	rt *RemotesTable
+}
+
+func remoteNameFromRow(r sql.Row) (string, error) {
+	name, ok := r[0].(string)
+
+	if !ok {
+		return "", errors.New("invalid value type for name")
+	} else if !env.IsValidRemoteName(name) {
+		return "", fmt.Errorf("invalid remote name: %s", name)
+	}
+
+	return name, nil
+}
+
+// unmarshalJSONColumn decodes the value of the JSON column |col| into |dest|. NULL values leave |dest| unchanged.
+func unmarshalJSONColumn(v interface{}, col string, dest interface{}) error {
+	var data []byte
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("invalid value type for %s", col)
+	}
+
+	err := json.Unmarshal(data, dest)
+
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %v", col, err)
+	}
+
+	return nil
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (rWr remoteWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	name, err := remoteNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	url, ok := r[1].(string)
+
+	if !ok {
+		return errors.New("invalid value type for url")
+	}
+
+	u, err := earl.Parse(url)
+
+	if err != nil {
+		return err
+	} else if u.Scheme == "" {
+		return fmt.Errorf("remote url '%s' must include a scheme", url)
+	}
+
+	params := map[string]string{}
+	err = unmarshalJSONColumn(r[3], "params", &params)
+
+	if err != nil {
+		return err
+	}
+
+	remote := env.NewRemote(name, url, params)
+	err = unmarshalJSONColumn(r[2], "fetch_specs", &remote.FetchSpecs)
+
+	if err != nil {
+		return err
+	}
+
+	for _, fs := range remote.FetchSpecs {
+		rs, err := ref.ParseRefSpecForRemote(name, fs)
+
+		if err != nil {
+			return err
+		}
+
+		if _, ok := rs.(ref.RemoteRefSpec); !ok {
+			return fmt.Errorf("fetch spec '%s' is not a remote ref spec", fs)
+		}
+	}
+
+	remotes, err := rWr.rt.rsr.GetRemotes()
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := remotes[name]; ok {
+		return sql.ErrPrimaryKeyViolation.New(name)
+	}
+
+	return rWr.rt.rsw.AddRemote(remote)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (rWr remoteWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	name, err := remoteNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	remotes, err := rWr.rt.rsr.GetRemotes()
+
+	if err != nil {
+		return err
+	}
+
+	if _, ok := remotes[name]; !ok {
+		return sql.ErrDeleteRowNotFound.New()
+	}
+
+	ddb := rWr.rt.ddb
+	refs, err := ddb.GetRefsOfType(ctx, map[ref.RefType]struct{}{ref.RemoteRefType: {}})
+
+	if err != nil {
+		return err
+	}
+
+	for _, dref := range refs {
+		if rr := dref.(ref.RemoteRef); rr.GetRemote() == name {
+			err = ddb.DeleteBranch(ctx, rr)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return rWr.rt.rsw.RemoveRemote(name)
+}
+
+// Close finalizes the operation, persisting the result.
+func (rWr remoteWriter) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,344 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	diffTableNameCol = "table_name"
+	toRowCol         = "to_row"
+	fromRowCol       = "from_row"
+)
+
+var ErrDiffOneToCommit = errors.New("dolt_diff must be filtered to a single 'to_commit'")
+var ErrDiffOneFromCommit = errors.New("dolt_diff must be filtered to a single 'from_commit'")
+var ErrDiffOneTableName = errors.New("dolt_diff must be filtered to a single 'table_name'")
+
+var _ sql.Table = (*RevisionDiffTable)(nil)
+var _ sql.FilteredTable = (*RevisionDiffTable)(nil)
+
+// RevisionDiffTable is a sql.Table implementation of a system table which diffs the rows of a table between two
+// revisions. The table and revisions are selected by filtering the 'table_name', 'from_commit' and 'to_commit' columns.
+// Revisions may be commit specs, WORKING or STAGED. Each row is rendered as a JSON object in the schema the table had
+// at its revision, so the diff works when the schema changed between the two revisions.
+type RevisionDiffTable struct {
+	ddb            *doltdb.DoltDB
+	revs           revisionResolver
+	revFilters     revisionFilters
+	tblNameFilter  *expression.Equals
+	requiredFilter error
+}
+
+// NewRevisionDiffTable creates a RevisionDiffTable
+func NewRevisionDiffTable(_ *sql.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, head *doltdb.Commit, rsr env.RepoStateReader) sql.Table {
+	return &RevisionDiffTable{
+		ddb:        ddb,
+		revs:       revisionResolver{ddb: ddb, working: root, head: head, rsr: rsr},
+		revFilters: revisionFilters{errOneFrom: ErrDiffOneFromCommit, errOneTo: ErrDiffOneToCommit},
+	}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffTableName
+func (dt *RevisionDiffTable) Name() string {
+	return doltdb.DiffTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffTableName
+func (dt *RevisionDiffTable) String() string {
+	return doltdb.DiffTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the diff system table. The row on a side of the
+// diff where the row does not exist is NULL, as are the commit dates of the WORKING and STAGED revisions.
+func (dt *RevisionDiffTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: diffTableNameCol, Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: toRowCol, Type: sql.JSON, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: toCommitDate, Type: sql.Datetime, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: fromRowCol, Type: sql.JSON, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: fromCommitDate, Type: sql.Datetime, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: diffTypeColName, Type: sql.Text, Source: doltdb.DiffTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (dt *RevisionDiffTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *RevisionDiffTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	tblName, err := dt.tableName(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := dt.revFilters.revisions(ctx, dt.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromRoot, fromDate, err := dt.revs.rootForRevision(ctx, from)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toRoot, toDate, err := dt.revs.rootForRevision(ctx, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromTbl, fromExists, err := fromRoot.GetTable(ctx, tblName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toTbl, toExists, err := toRoot.GetTable(ctx, tblName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !fromExists && !toExists {
+		return nil, sql.ErrTableNotFound.New(tblName)
+	}
+
+	fromData, fromSch, err := tableData(ctx, fromTbl, dt.ddb)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toData, toSch, err := tableData(ctx, toTbl, dt.ddb)
+
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := rowconv.NewJoiner(
+		[]rowconv.NamedSchema{{Name: diff.To, Sch: toSch}, {Name: diff.From, Sch: fromSch}},
+		map[string]rowconv.ColNamingFunc{
+			diff.To:   toNamer,
+			diff.From: fromNamer,
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	rd := diff.NewRowDiffer(ctx, fromSch, toSch, 1024)
+	rd.Start(ctx, fromData, toData)
+
+	return &revisionDiffRowItr{
+		ad:       rd,
+		diffSrc:  diff.NewRowDiffSource(rd, j),
+		joiner:   j,
+		tblName:  tblName,
+		toName:   to,
+		fromName: from,
+		toDate:   timeOrNil(toDate),
+		fromDate: timeOrNil(fromDate),
+	}, nil
+}
+
+// HandledFilters returns the list of filters that will be handled by the table itself
+func (dt *RevisionDiffTable) HandledFilters(filters []sql.Expression) []sql.Expression {
+	handled := dt.revFilters.handledFilters(filters)
+
+	for _, filter := range filters {
+		if eqFilter, isEquality := filter.(*expression.Equals); isEquality {
+			for _, e := range []sql.Expression{eqFilter.Left(), eqFilter.Right()} {
+				if val, ok := e.(*expression.GetField); ok && strings.ToLower(val.Name()) == diffTableNameCol {
+					if dt.tblNameFilter != nil {
+						dt.requiredFilter = ErrDiffOneTableName
+					}
+
+					dt.tblNameFilter = eqFilter
+					handled = append(handled, filter)
+				}
+			}
+		}
+	}
+
+	return handled
+}
+
+// Filters returns the list of filters that are applied to this table.
+func (dt *RevisionDiffTable) Filters() []sql.Expression {
+	revFilters := dt.revFilters.filters()
+	if revFilters == nil || dt.tblNameFilter == nil {
+		return nil
+	}
+
+	return append(revFilters, dt.tblNameFilter)
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (dt *RevisionDiffTable) WithFilters(filters []sql.Expression) sql.Table {
+	return dt
+}
+
+// tableName evaluates the 'table_name' filter, returning the name of the table to diff.
+func (dt *RevisionDiffTable) tableName(ctx *sql.Context) (string, error) {
+	if dt.requiredFilter != nil {
+		return "", fmt.Errorf("error querying table %s: %w", dt.Name(), dt.requiredFilter)
+	} else if dt.tblNameFilter == nil {
+		return "", fmt.Errorf("error querying table %s: %w", dt.Name(), ErrDiffOneTableName)
+	}
+
+	tblName, err := revisionForFilter(ctx, dt.tblNameFilter)
+
+	if err != nil {
+		return "", err
+	}
+
+	return tblName, nil
+}
+
+var _ sql.RowIter = (*revisionDiffRowItr)(nil)
+
+type revisionDiffRowItr struct {
+	ad       diff.RowDiffer
+	diffSrc  *diff.RowDiffSource
+	joiner   *rowconv.Joiner
+	tblName  string
+	toName   string
+	fromName string
+	toDate   interface{}
+	fromDate interface{}
+}
+
+// Next returns the next row
+func (itr *revisionDiffRowItr) Next() (sql.Row, error) {
+	r, _, err := itr.diffSrc.NextDiff()
+
+	if err != nil {
+		return nil, err
+	}
+
+	toAndFromRows, err := itr.joiner.Split(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toRow, err := rowToJSON(toAndFromRows[diff.To], itr.joiner.SchemaForName(diff.To))
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromRow, err := rowToJSON(toAndFromRows[diff.From], itr.joiner.SchemaForName(diff.From))
+
+	if err != nil {
+		return nil, err
+	}
+
+	diffType := diffTypeModified
+	if toRow == nil {
+		diffType = diffTypeRemoved
+	} else if fromRow == nil {
+		diffType = diffTypeAdded
+	}
+
+	return sql.NewRow(itr.tblName, toRow, itr.toName, itr.toDate, fromRow, itr.fromName, itr.fromDate, diffType), nil
+}
+
+// Close closes the iterator
+func (itr *revisionDiffRowItr) Close(*sql.Context) (err error) {
+	defer itr.ad.Close()
+	defer func() {
+		closeErr := itr.diffSrc.Close()
+
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	return nil
+}
+
+// rowToJSON encodes |r| as a JSON object keyed by the column names of |sch|. JSON columns are embedded as documents
+// rather than strings. Returns nil if |r| is nil.
+func rowToJSON(r row.Row, sch schema.Schema) (interface{}, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	vals := make(map[string]interface{}, sch.GetAllCols().Size())
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		v, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(v) {
+			vals[col.Name] = nil
+			return false, nil
+		}
+
+		val, err := col.TypeInfo.ConvertNomsValueToValue(v)
+
+		if err != nil {
+			return true, err
+		}
+
+		if col.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier {
+			val = json.RawMessage(val.([]byte))
+		}
+
+		vals[col.Name] = val
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(vals)
+}
+
+// timeOrNil returns the time of |ts|, or nil if |ts| is nil.
+func timeOrNil(ts *types.Timestamp) interface{} {
+	if ts == nil {
+		return nil
+	}
+
+	return time.Time(*ts)
+}
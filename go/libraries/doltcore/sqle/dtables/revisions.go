@@ -0,0 +1,209 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// revisionResolver resolves the revisions compared by dolt_diff, dolt_diff_summary and dolt_schema_diff. A revision is
+// WORKING, STAGED or a commit spec. Commit specs relative to HEAD are resolved from the head commit of the session.
+type revisionResolver struct {
+	ddb     *doltdb.DoltDB
+	working *doltdb.RootValue
+	head    *doltdb.Commit
+	rsr     env.RepoStateReader
+}
+
+// rootForRevision returns the root of the revision |rev|, and the time of its commit, which is nil for the WORKING and
+// STAGED revisions.
+func (rr revisionResolver) rootForRevision(ctx *sql.Context, rev string) (*doltdb.RootValue, *types.Timestamp, error) {
+	switch strings.ToLower(rev) {
+	case "working":
+		return rr.working, nil, nil
+	case "staged":
+		root, err := env.StagedRoot(ctx, rr.ddb, rr.rsr)
+		return root, nil, err
+	}
+
+	cm, err := rr.resolveCommit(ctx, rev)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t := meta.Time()
+	return root, (*types.Timestamp)(&t), nil
+}
+
+// rootsForRevisions returns the roots of the revisions |from| and |to|.
+func (rr revisionResolver) rootsForRevisions(ctx *sql.Context, from, to string) (*doltdb.RootValue, *doltdb.RootValue, error) {
+	fromRoot, _, err := rr.rootForRevision(ctx, from)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toRoot, _, err := rr.rootForRevision(ctx, to)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fromRoot, toRoot, nil
+}
+
+// resolveCommit resolves the commit spec |cSpecStr|. HEAD refers to the head commit of the session.
+func (rr revisionResolver) resolveCommit(ctx *sql.Context, cSpecStr string) (*doltdb.Commit, error) {
+	name, as, err := doltdb.SplitAncestorSpec(cSpecStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(name) == "head" && rr.head != nil {
+		return rr.head.GetAncestor(ctx, as)
+	}
+
+	cs, err := doltdb.NewCommitSpec(cSpecStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rr.ddb.Resolve(ctx, cs, rr.rsr.CWBHeadRef())
+}
+
+// revisionFilters holds the equality filters on the 'from_commit' and 'to_commit' columns of a table, which select the
+// two revisions the table compares. Each column must be filtered to exactly one revision.
+type revisionFilters struct {
+	from, to       *expression.Equals
+	errOneFrom     error
+	errOneTo       error
+	requiredFilter error
+}
+
+// handledFilters records the 'from_commit' and 'to_commit' filters in |filters|, and returns them.
+func (rf *revisionFilters) handledFilters(filters []sql.Expression) []sql.Expression {
+	var commitFilters []sql.Expression
+	for _, filter := range filters {
+		isCommitFilter := false
+
+		if eqFilter, isEquality := filter.(*expression.Equals); isEquality {
+			for _, e := range []sql.Expression{eqFilter.Left(), eqFilter.Right()} {
+				if val, ok := e.(*expression.GetField); ok {
+					switch strings.ToLower(val.Name()) {
+					case toCommit:
+						if rf.to != nil {
+							rf.requiredFilter = rf.errOneTo
+						}
+
+						isCommitFilter = true
+						rf.to = eqFilter
+					case fromCommit:
+						if rf.from != nil {
+							rf.requiredFilter = rf.errOneFrom
+						}
+
+						isCommitFilter = true
+						rf.from = eqFilter
+					}
+				}
+			}
+		}
+
+		if isCommitFilter {
+			commitFilters = append(commitFilters, filter)
+		}
+	}
+
+	return commitFilters
+}
+
+// filters returns the recorded filters, or nil if either is missing.
+func (rf *revisionFilters) filters() []sql.Expression {
+	if rf.to == nil || rf.from == nil {
+		return nil
+	}
+
+	return []sql.Expression{rf.to, rf.from}
+}
+
+// revisions evaluates the recorded filters, returning the from and to revisions. |tblName| is used in errors.
+func (rf *revisionFilters) revisions(ctx *sql.Context, tblName string) (string, string, error) {
+	if rf.requiredFilter != nil {
+		return "", "", fmt.Errorf("error querying table %s: %w", tblName, rf.requiredFilter)
+	} else if rf.to == nil {
+		return "", "", fmt.Errorf("error querying table %s: %w", tblName, rf.errOneTo)
+	} else if rf.from == nil {
+		return "", "", fmt.Errorf("error querying table %s: %w", tblName, rf.errOneFrom)
+	}
+
+	from, err := revisionForFilter(ctx, rf.from)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	to, err := revisionForFilter(ctx, rf.to)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return from, to, nil
+}
+
+// revisionForFilter evaluates the value that the commit column of |eqFilter| is compared to.
+func revisionForFilter(ctx *sql.Context, eqFilter *expression.Equals) (string, error) {
+	gf, nonGF := eqFilter.Left(), eqFilter.Right()
+	if _, ok := gf.(*expression.GetField); !ok {
+		nonGF, gf = eqFilter.Left(), eqFilter.Right()
+	}
+
+	val, err := nonGF.Eval(ctx, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	rev, ok := val.(string)
+
+	if !ok {
+		return "", fmt.Errorf("received '%v' when expecting commit hash string", val)
+	}
+
+	return rev, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	schemaConflictColumn = "column"
+	schemaConflictIndex  = "index"
+)
+
+var _ sql.Table = (*SchemaConflictsTable)(nil)
+var _ sql.DeletableTable = (*SchemaConflictsTable)(nil)
+
+// SchemaConflictsTable is a sql.Table implementation of a system table which lists the conflicting columns and indexes
+// of the tables whose schemas could not be merged. Deleting a schema conflict resolves it by keeping our definition.
+type SchemaConflictsTable struct {
+	root *doltdb.RootValue
+	rs   RootSetter
+}
+
+// NewSchemaConflictsTable creates a SchemaConflictsTable for the schema conflicts in |root|.
+func NewSchemaConflictsTable(_ *sql.Context, root *doltdb.RootValue, rs RootSetter) sql.Table {
+	return &SchemaConflictsTable{root: root, rs: rs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (st *SchemaConflictsTable) Name() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (st *SchemaConflictsTable) String() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema conflicts system table.
+func (st *SchemaConflictsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: true},
+		{Name: "object_type", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: true},
+		{Name: "our_name", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: true},
+		{Name: "their_name", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "our_definition", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "their_definition", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "description", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (st *SchemaConflictsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *SchemaConflictsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	tblNames, err := st.root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(tblNames)
+
+	var rows []sql.Row
+	for _, tblName := range tblNames {
+		tbl, _, err := st.root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		sc, err := merge.GetSchemaConflicts(ctx, tblName, tbl)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range sc.ColConflicts {
+			rows = append(rows, sql.NewRow(tblName, schemaConflictColumn, c.Ours.Name, c.Theirs.Name,
+				sqlfmt.FmtCol(0, 0, 0, c.Ours), sqlfmt.FmtCol(0, 0, 0, c.Theirs), c.String()))
+		}
+
+		for _, c := range sc.IdxConflicts {
+			rows = append(rows, sql.NewRow(tblName, schemaConflictIndex, c.Ours.Name(), c.Theirs.Name(),
+				sqlfmt.FmtIndex(c.Ours), sqlfmt.FmtIndex(c.Theirs), c.String()))
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Deleter returns a RowDeleter for this table. Deleting a schema conflict resolves it by keeping our definition of the
+// column or index. Once all the schema conflicts of a table are resolved its data is merged.
+func (st *SchemaConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return &schemaConflictDeleter{st: st, toResolve: make(map[string][]string)}
+}
+
+var _ sql.RowDeleter = (*schemaConflictDeleter)(nil)
+
+type schemaConflictDeleter struct {
+	st        *SchemaConflictsTable
+	toResolve map[string][]string
+}
+
+// Delete records the schema conflict in |r| to be resolved when the deleter is closed.
+func (sd *schemaConflictDeleter) Delete(_ *sql.Context, r sql.Row) error {
+	tblName := r[0].(string)
+	sd.toResolve[tblName] = append(sd.toResolve[tblName], r[2].(string))
+	return nil
+}
+
+// Close resolves the deleted schema conflicts with our definitions and updates the root.
+func (sd *schemaConflictDeleter) Close(ctx *sql.Context) error {
+	if len(sd.toResolve) == 0 {
+		return nil
+	}
+
+	tblNames := make([]string, 0, len(sd.toResolve))
+	for tblName := range sd.toResolve {
+		tblNames = append(tblNames, tblName)
+	}
+	sort.Strings(tblNames)
+
+	root := sd.st.root
+	for _, tblName := range tblNames {
+		var err error
+		root, err = merge.ResolveSchemaConflicts(ctx, root, tblName, true, sd.toResolve[tblName])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return sd.st.rs.SetRoot(ctx, root)
+}
@@ -0,0 +1,249 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrSchemaDiffOneToCommit = errors.New("dolt_schema_diff must be filtered to a single 'to_commit'")
+var ErrSchemaDiffOneFromCommit = errors.New("dolt_schema_diff must be filtered to a single 'from_commit'")
+
+const (
+	schemaObjectTable      = "table"
+	schemaObjectColumn     = "column"
+	schemaObjectIndex      = "index"
+	schemaObjectForeignKey = "foreign key"
+
+	schemaDiffAdded    = "added"
+	schemaDiffRemoved  = "removed"
+	schemaDiffModified = "modified"
+	schemaDiffRenamed  = "renamed"
+)
+
+var _ sql.Table = (*SchemaDiffTable)(nil)
+var _ sql.FilteredTable = (*SchemaDiffTable)(nil)
+
+// SchemaDiffTable is a sql.Table implementation of a system table which lists the changes to the tables, columns,
+// indexes and foreign keys of a database between two revisions, as dolt diff --schema does. The revisions are selected
+// by filtering the 'from_commit' and 'to_commit' columns, which may be commit specs, WORKING or STAGED.
+type SchemaDiffTable struct {
+	revs       revisionResolver
+	revFilters revisionFilters
+}
+
+// NewSchemaDiffTable creates a SchemaDiffTable
+func NewSchemaDiffTable(_ *sql.Context, ddb *doltdb.DoltDB, root *doltdb.RootValue, head *doltdb.Commit, rsr env.RepoStateReader) sql.Table {
+	return &SchemaDiffTable{
+		revs:       revisionResolver{ddb: ddb, working: root, head: head, rsr: rsr},
+		revFilters: revisionFilters{errOneFrom: ErrSchemaDiffOneFromCommit, errOneTo: ErrSchemaDiffOneToCommit},
+	}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaDiffTableName
+func (dt *SchemaDiffTable) Name() string {
+	return doltdb.SchemaDiffTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaDiffTableName
+func (dt *SchemaDiffTable) String() string {
+	return doltdb.SchemaDiffTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema diff system table. The definitions
+// are NULL on the side of the diff where the object does not exist.
+func (dt *SchemaDiffTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "object_type", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "object_name", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true, Nullable: false},
+		{Name: "diff_type", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: false, Nullable: false},
+		{Name: "from_definition", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: false, Nullable: true},
+		{Name: "to_definition", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (dt *SchemaDiffTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (dt *SchemaDiffTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	from, to, err := dt.revFilters.revisions(ctx, dt.Name())
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromRoot, toRoot, err := dt.revs.rootsForRevisions(ctx, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := sortedTableDeltas(ctx, fromRoot, toRoot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromSchemas, err := fromRoot.GetAllSchemas(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toSchemas, err := toRoot.GetAllSchemas(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sdb := schemaDiffBuilder{from: from, to: to, fromSchemas: fromSchemas, toSchemas: toSchemas}
+	for _, td := range deltas {
+		if td.CurName() == doltdb.DocTableName {
+			continue
+		}
+
+		err = sdb.addTableDelta(ctx, td)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.RowsToRowIter(sdb.rows...), nil
+}
+
+// HandledFilters returns the list of filters that will be handled by the table itself
+func (dt *SchemaDiffTable) HandledFilters(filters []sql.Expression) []sql.Expression {
+	return dt.revFilters.handledFilters(filters)
+}
+
+// Filters returns the list of filters that are applied to this table.
+func (dt *SchemaDiffTable) Filters() []sql.Expression {
+	return dt.revFilters.filters()
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (dt *SchemaDiffTable) WithFilters(filters []sql.Expression) sql.Table {
+	return dt
+}
+
+// schemaDiffBuilder accumulates the rows of the schema diff table.
+type schemaDiffBuilder struct {
+	from, to    string
+	fromSchemas map[string]schema.Schema
+	toSchemas   map[string]schema.Schema
+	rows        []sql.Row
+}
+
+func (sdb *schemaDiffBuilder) addRow(tblName, objType, objName, diffType string, fromDef, toDef interface{}) {
+	sdb.rows = append(sdb.rows, sql.NewRow(sdb.from, sdb.to, tblName, objType, objName, diffType, fromDef, toDef))
+}
+
+// addTableDelta adds the rows for the changes to the table of |td|. Added and dropped tables are reported along with
+// each of their columns, indexes and foreign keys.
+func (sdb *schemaDiffBuilder) addTableDelta(ctx *sql.Context, td diff.TableDelta) error {
+	tblName := td.CurName()
+
+	switch {
+	case td.IsAdd():
+		sdb.addRow(tblName, schemaObjectTable, tblName, schemaDiffAdded, nil, td.ToName)
+	case td.IsDrop():
+		sdb.addRow(tblName, schemaObjectTable, tblName, schemaDiffRemoved, td.FromName, nil)
+	case td.IsRename():
+		sdb.addRow(tblName, schemaObjectTable, tblName, schemaDiffRenamed, td.FromName, td.ToName)
+	}
+
+	fromSch, toSch, err := td.GetSchemas(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	colDiffs, unionTags := diff.DiffSchColumns(fromSch, toSch)
+	for _, tag := range unionTags {
+		cd := colDiffs[tag]
+
+		switch cd.DiffType {
+		case diff.SchDiffAdded:
+			sdb.addRow(tblName, schemaObjectColumn, cd.New.Name, schemaDiffAdded, nil, sqlfmt.FmtCol(0, 0, 0, *cd.New))
+		case diff.SchDiffRemoved:
+			sdb.addRow(tblName, schemaObjectColumn, cd.Old.Name, schemaDiffRemoved, sqlfmt.FmtCol(0, 0, 0, *cd.Old), nil)
+		case diff.SchDiffModified:
+			diffType := schemaDiffModified
+			if cd.Old.Name != cd.New.Name {
+				diffType = schemaDiffRenamed
+			}
+
+			sdb.addRow(tblName, schemaObjectColumn, cd.New.Name, diffType, sqlfmt.FmtCol(0, 0, 0, *cd.Old), sqlfmt.FmtCol(0, 0, 0, *cd.New))
+		}
+	}
+
+	for _, idxDiff := range diff.DiffSchIndexes(fromSch, toSch) {
+		switch idxDiff.DiffType {
+		case diff.SchDiffAdded:
+			sdb.addRow(tblName, schemaObjectIndex, idxDiff.To.Name(), schemaDiffAdded, nil, sqlfmt.FmtIndex(idxDiff.To))
+		case diff.SchDiffRemoved:
+			sdb.addRow(tblName, schemaObjectIndex, idxDiff.From.Name(), schemaDiffRemoved, sqlfmt.FmtIndex(idxDiff.From), nil)
+		case diff.SchDiffModified:
+			sdb.addRow(tblName, schemaObjectIndex, idxDiff.To.Name(), schemaDiffModified, sqlfmt.FmtIndex(idxDiff.From), sqlfmt.FmtIndex(idxDiff.To))
+		}
+	}
+
+	for _, fkDiff := range diff.DiffForeignKeys(td.FromFks, td.ToFks) {
+		switch fkDiff.DiffType {
+		case diff.SchDiffAdded:
+			toDef := sdb.fmtForeignKey(fkDiff.To, toSch, sdb.toSchemas)
+			sdb.addRow(tblName, schemaObjectForeignKey, fkDiff.To.Name, schemaDiffAdded, nil, toDef)
+		case diff.SchDiffRemoved:
+			fromDef := sdb.fmtForeignKey(fkDiff.From, fromSch, sdb.fromSchemas)
+			sdb.addRow(tblName, schemaObjectForeignKey, fkDiff.From.Name, schemaDiffRemoved, fromDef, nil)
+		case diff.SchDiffModified:
+			fromDef := sdb.fmtForeignKey(fkDiff.From, fromSch, sdb.fromSchemas)
+			toDef := sdb.fmtForeignKey(fkDiff.To, toSch, sdb.toSchemas)
+			sdb.addRow(tblName, schemaObjectForeignKey, fkDiff.To.Name, schemaDiffModified, fromDef, toDef)
+		}
+	}
+
+	return nil
+}
+
+// fmtForeignKey formats |fk| using the schema of its referenced table from |schemas|.
+func (sdb *schemaDiffBuilder) fmtForeignKey(fk doltdb.ForeignKey, sch schema.Schema, schemas map[string]schema.Schema) string {
+	parentSch, ok := schemas[fk.ReferencedTableName]
+
+	if !ok {
+		parentSch = schema.EmptySchema
+	}
+
+	return sqlfmt.FmtForeignKey(fk, sch, parentSch)
+}
@@ -123,6 +123,13 @@ func (dt *TableOfTablesInConflict) Partitions(ctx *sql.Context) (sql.PartitionIt
 		if err != nil {
 			return nil, err
 		} else if ok {
+			// tables with only schema conflicts are listed in dolt_schema_conflicts
+			if has, err := tbl.HasConflicts(); err != nil {
+				return nil, err
+			} else if !has {
+				continue
+			}
+
 			schemas, m, err := tbl.GetConflicts(ctx)
 
 			if err != nil {
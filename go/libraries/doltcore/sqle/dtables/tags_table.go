@@ -0,0 +1,276 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*TagsTable)(nil)
+var _ sql.InsertableTable = (*TagsTable)(nil)
+var _ sql.DeletableTable = (*TagsTable)(nil)
+
+// TagsTable is a sql.Table implementation that implements a system table which shows the dolt tags
+type TagsTable struct {
+	ddb         *doltdb.DoltDB
+	rsr         env.RepoStateReader
+	taggerName  string
+	taggerEmail string
+}
+
+// NewTagsTable creates a TagsTable. Tags inserted without a tagger or email are attributed to |taggerName| and
+// |taggerEmail|.
+func NewTagsTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, taggerName, taggerEmail string) sql.Table {
+	return &TagsTable{ddb: ddb, rsr: rsr, taggerName: taggerName, taggerEmail: taggerEmail}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// TagsTableName
+func (tt *TagsTable) Name() string {
+	return doltdb.TagsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// TagsTableName
+func (tt *TagsTable) String() string {
+	return doltdb.TagsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the tags system table
+func (tt *TagsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "tag_name", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: true, Nullable: false},
+		{Name: "tag_hash", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "tagger", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "email", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "date", Type: sql.Datetime, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "message", Type: sql.Text, Source: doltdb.TagsTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (tt *TagsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (tt *TagsTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	return NewTagItr(sqlCtx, tt.ddb)
+}
+
+// TagItr is a sql.RowItr implementation which iterates over each tag as if it's a row in the table.
+type TagItr struct {
+	tags []*doltdb.Tag
+	idx  int
+}
+
+// NewTagItr creates a TagItr from the tags in |ddb|.
+func NewTagItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*TagItr, error) {
+	tagRefs, err := ddb.GetTags(sqlCtx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*doltdb.Tag, len(tagRefs))
+	for i, r := range tagRefs {
+		tr, ok := r.(ref.TagRef)
+
+		if !ok {
+			return nil, fmt.Errorf("DoltDB.GetTags() returned non-tag DoltRef")
+		}
+
+		tags[i], err = ddb.ResolveTag(sqlCtx, tr)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TagItr{tags, 0}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *TagItr) Next() (sql.Row, error) {
+	if itr.idx >= len(itr.tags) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	tag := itr.tags[itr.idx]
+	h, err := tag.Commit.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	meta := tag.Meta
+	return sql.NewRow(tag.Name, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description), nil
+}
+
+// Close closes the iterator.
+func (itr *TagItr) Close(*sql.Context) error {
+	return nil
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (tt *TagsTable) Inserter(*sql.Context) sql.RowInserter {
+	return tagWriter{tt}
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (tt *TagsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return tagWriter{tt}
+}
+
+var _ sql.RowInserter = tagWriter{nil}
+var _ sql.RowDeleter = tagWriter{nil}
+
+type tagWriter struct {
+	tt *TagsTable
+}
+
+func tagNameFromRow(r sql.Row) (string, error) {
+	tagName, ok := r[0].(string)
+
+	if !ok {
+		return "", errors.New("invalid value type for tag_name")
+	} else if !doltdb.IsValidTagRef(ref.NewTagRef(tagName)) {
+		return "", doltdb.ErrInvTagName
+	}
+
+	return tagName, nil
+}
+
+// optionalString returns the string value of |v|, or |def| if |v| is NULL.
+func optionalString(v interface{}, col, def string) (string, error) {
+	if v == nil {
+		return def, nil
+	}
+
+	s, ok := v.(string)
+
+	if !ok {
+		return "", fmt.Errorf("invalid value type for %s", col)
+	}
+
+	return s, nil
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (tWr tagWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	tagName, err := tagNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	startPoint, ok := r[1].(string)
+
+	if !ok {
+		return errors.New("invalid value type for tag_hash")
+	}
+
+	tagger, err := optionalString(r[2], "tagger", tWr.tt.taggerName)
+
+	if err != nil {
+		return err
+	}
+
+	email, err := optionalString(r[3], "email", tWr.tt.taggerEmail)
+
+	if err != nil {
+		return err
+	}
+
+	msg, err := optionalString(r[5], "message", "")
+
+	if err != nil {
+		return err
+	}
+
+	ddb := tWr.tt.ddb
+	tagRef := ref.NewTagRef(tagName)
+	exists, err := ddb.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return sql.ErrPrimaryKeyViolation.New(tagName)
+	}
+
+	cs, err := doltdb.NewCommitSpec(startPoint)
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := ddb.Resolve(ctx, cs, tWr.tt.rsr.CWBHeadRef())
+
+	if err != nil {
+		return err
+	}
+
+	return ddb.NewTagAtCommit(ctx, tagRef, cm, doltdb.NewTagMeta(tagger, email, msg))
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (tWr tagWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	tagName, err := tagNameFromRow(r)
+
+	if err != nil {
+		return err
+	}
+
+	tagRef := ref.NewTagRef(tagName)
+	exists, err := tWr.tt.ddb.HasRef(ctx, tagRef)
+
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return sql.ErrDeleteRowNotFound.New()
+	}
+
+	return tWr.tt.ddb.DeleteTag(ctx, tagRef)
+}
+
+// Close finalizes the operation, persisting the result.
+func (tWr tagWriter) Close(*sql.Context) error {
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// The fixed dolt schema for the `dolt_merge_policies` table.
+func MergePoliciesTableSchema() schema.Schema {
+	colColl := schema.NewColCollection(
+		schema.NewColumn(doltdb.MergePoliciesTableNameCol, schema.DoltMergePoliciesTableNameTag, types.StringKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn(doltdb.MergePoliciesColumnNameCol, schema.DoltMergePoliciesColumnNameTag, types.StringKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn(doltdb.MergePoliciesPolicyCol, schema.DoltMergePoliciesPolicyTag, types.StringKind, false, schema.NotNullConstraint{}),
+		schema.NewColumn(doltdb.MergePoliciesPolicyColumnCol, schema.DoltMergePoliciesPolicyColumnTag, types.StringKind, false),
+	)
+	return schema.MustSchemaFromCols(colColl)
+}
+
+// GetOrCreateMergePoliciesTable returns the `dolt_merge_policies` table in `db`, creating it if it does not already
+// exist.
+func GetOrCreateMergePoliciesTable(ctx *sql.Context, db Database) (*WritableDoltTable, error) {
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, found, err := db.getTable(ctx, root, doltdb.MergePoliciesTableName)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return tbl.(*WritableDoltTable), nil
+	}
+
+	err = db.createDoltTable(ctx, doltdb.MergePoliciesTableName, root, MergePoliciesTableSchema())
+	if err != nil {
+		return nil, err
+	}
+	root, err = db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, found, err = db.getTable(ctx, root, doltdb.MergePoliciesTableName)
+	if err != nil {
+		return nil, err
+	}
+	// Verify it was created successfully
+	if !found {
+		return nil, sql.ErrTableNotFound.New(doltdb.MergePoliciesTableName)
+	}
+	return tbl.(*WritableDoltTable), nil
+}
+
+var _ sql.InsertableTable = (*emptyMergePoliciesTable)(nil)
+var _ sql.ReplaceableTable = (*emptyMergePoliciesTable)(nil)
+
+// emptyMergePoliciesTable stands in for the `dolt_merge_policies` table until it exists. Like `dolt_procedures`, the
+// table is only created once it is written to, so inserting into it creates it.
+type emptyMergePoliciesTable struct {
+	db     Database
+	sqlSch sql.Schema
+}
+
+func newEmptyMergePoliciesTable(db Database) (*emptyMergePoliciesTable, error) {
+	sqlSch, err := sqlutil.FromDoltSchema(doltdb.MergePoliciesTableName, MergePoliciesTableSchema())
+	if err != nil {
+		return nil, err
+	}
+	return &emptyMergePoliciesTable{db: db, sqlSch: sqlSch}, nil
+}
+
+// Name implements sql.Table
+func (t *emptyMergePoliciesTable) Name() string {
+	return doltdb.MergePoliciesTableName
+}
+
+// String implements sql.Table
+func (t *emptyMergePoliciesTable) String() string {
+	return doltdb.MergePoliciesTableName
+}
+
+// Schema implements sql.Table
+func (t *emptyMergePoliciesTable) Schema() sql.Schema {
+	return t.sqlSch
+}
+
+// Partitions implements sql.Table
+func (t *emptyMergePoliciesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows implements sql.Table
+func (t *emptyMergePoliciesTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// Inserter implements sql.InsertableTable, creating the `dolt_merge_policies` table.
+func (t *emptyMergePoliciesTable) Inserter(ctx *sql.Context) sql.RowInserter {
+	tbl, err := t.createTable(ctx)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return tbl.Inserter(ctx)
+}
+
+// Replacer implements sql.ReplaceableTable, creating the `dolt_merge_policies` table.
+func (t *emptyMergePoliciesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	tbl, err := t.createTable(ctx)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return tbl.Replacer(ctx)
+}
+
+func (t *emptyMergePoliciesTable) createTable(ctx *sql.Context) (*WritableDoltTable, error) {
+	// batched edits to other tables must be in the root the table is created in, or they would be lost
+	err := t.db.Flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return GetOrCreateMergePoliciesTable(ctx, t.db)
+}
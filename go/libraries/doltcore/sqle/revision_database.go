@@ -0,0 +1,400 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// RevisionDelimiter separates the name of a database from a revision of it in the name of a revision database, such
+// as `mydb/feature-x`.
+const RevisionDelimiter = "/"
+
+var ErrRevisionDatabaseReadOnly = errors.NewKind("database %s is read-only, it is revision '%s' of database %s")
+
+// SplitRevisionDbName splits |name| into the name of a database and a revision of it. The returned bool is false if
+// |name| is not qualified with a revision, in which case |name| is returned as the database name.
+func SplitRevisionDbName(name string) (string, string, bool) {
+	idx := strings.Index(name, RevisionDelimiter)
+
+	if idx <= 0 || idx == len(name)-len(RevisionDelimiter) {
+		return name, "", false
+	}
+
+	return name[:idx], name[idx+len(RevisionDelimiter):], true
+}
+
+// RevisionDatabase is a read-only sql.Database for a branch, tag or commit of a dolt database. Its name is the name of
+// the database qualified with the revision, as in `mydb/feature-x`. It is added to a session the first time the
+// session uses it, and a session sees the commit its revision resolved to then until the database is refreshed.
+// Its DoltDB refuses to move refs and its repo state refuses to change its roots, so no statement or function can
+// change the revision.
+type RevisionDatabase struct {
+	db       Database
+	baseName string
+	revision string
+}
+
+var _ SqlDatabase = RevisionDatabase{}
+var _ sql.VersionedDatabase = RevisionDatabase{}
+
+// NewRevisionDatabase returns the RevisionDatabase for |revision| of |db|. The revision may be a branch, a tag, or any
+// commit spec, which is resolved to a commit once.
+func NewRevisionDatabase(ctx context.Context, db Database, revision string) (RevisionDatabase, error) {
+	name := db.Name() + RevisionDelimiter + revision
+	rs, err := newRevisionRepoState(ctx, db, revision)
+
+	if err != nil {
+		return RevisionDatabase{}, sql.ErrDatabaseNotFound.New(name)
+	}
+
+	revDb := Database{
+		name:      name,
+		ddb:       doltdb.ReadOnlyDoltDB(db.ddb, rs.readOnlyErr()),
+		rsr:       rs,
+		rsw:       rs,
+		drw:       db.drw,
+		batchMode: single,
+	}
+
+	return RevisionDatabase{db: revDb, baseName: db.Name(), revision: revision}, nil
+}
+
+// Name returns the name of the database qualified with the revision.
+func (db RevisionDatabase) Name() string {
+	return db.db.Name()
+}
+
+// BaseName returns the name of the database this is a revision of.
+func (db RevisionDatabase) BaseName() string {
+	return db.baseName
+}
+
+// Revision returns the revision of the database.
+func (db RevisionDatabase) Revision() string {
+	return db.revision
+}
+
+// GetTableInsensitive implements sql.Database. The tables returned can not be written.
+func (db RevisionDatabase) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Table, bool, error) {
+	if err := db.addToSession(ctx); err != nil {
+		return nil, false, err
+	}
+
+	tbl, found, err := db.db.GetTableInsensitive(ctx, tblName)
+
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	return readOnlyTable(tbl), true, nil
+}
+
+// GetTableNames implements sql.Database.
+func (db RevisionDatabase) GetTableNames(ctx *sql.Context) ([]string, error) {
+	if err := db.addToSession(ctx); err != nil {
+		return nil, err
+	}
+
+	return db.db.GetTableNames(ctx)
+}
+
+// GetTableInsensitiveAsOf implements sql.VersionedDatabase.
+func (db RevisionDatabase) GetTableInsensitiveAsOf(ctx *sql.Context, tblName string, asOf interface{}) (sql.Table, bool, error) {
+	if err := db.addToSession(ctx); err != nil {
+		return nil, false, err
+	}
+
+	return db.db.GetTableInsensitiveAsOf(ctx, tblName, asOf)
+}
+
+// GetTableNamesAsOf implements sql.VersionedDatabase.
+func (db RevisionDatabase) GetTableNamesAsOf(ctx *sql.Context, asOf interface{}) ([]string, error) {
+	return db.db.GetTableNamesAsOf(ctx, asOf)
+}
+
+// GetRoot returns the root of the commit the session sees.
+func (db RevisionDatabase) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
+	if err := db.addToSession(ctx); err != nil {
+		return nil, err
+	}
+
+	return db.db.GetRoot(ctx)
+}
+
+// Refresh moves the session of |ctx| to the commit the revision resolves to now, if the session has the database.
+// A session on a branch then sees the commits made to the branch since.
+func (db RevisionDatabase) Refresh(ctx *sql.Context) error {
+	dSess := DSessFromSess(ctx.Session)
+
+	if _, ok := dSess.dbDatas[db.Name()]; !ok {
+		return nil
+	}
+
+	h, err := db.db.rsr.CWBHeadHash(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if _, val := dSess.Session.Get(db.db.HeadKey()); val == h.String() {
+		return nil
+	}
+
+	return dSess.setHead(ctx, db.db.HeadKey(), sql.Text, db.Name(), h.String())
+}
+
+// addToSession adds the database to the session of |ctx| if the session does not have it yet.
+func (db RevisionDatabase) addToSession(ctx *sql.Context) error {
+	dSess := DSessFromSess(ctx.Session)
+
+	if _, ok := dSess.dbDatas[db.Name()]; ok {
+		return nil
+	}
+
+	if err := dSess.AddDB(ctx, db.db); err != nil {
+		return err
+	}
+
+	root, err := db.db.GetRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return RegisterSchemaFragments(ctx, db.db, root)
+}
+
+// readOnlyTable returns a version of |tbl| which can not be written.
+func readOnlyTable(tbl sql.Table) sql.Table {
+	switch t := tbl.(type) {
+	case *AlterableDoltTable:
+		return &t.DoltTable
+	case *WritableDoltTable:
+		return &t.DoltTable
+	}
+
+	switch tbl.(type) {
+	case sql.InsertableTable, sql.UpdatableTable, sql.DeletableTable, sql.ReplaceableTable, sql.TruncateableTable:
+		return readOnlySystemTable{tbl}
+	}
+
+	return tbl
+}
+
+// readOnlySystemTable hides the write methods of a system table.
+type readOnlySystemTable struct {
+	sql.Table
+}
+
+// revisionRepoState is the env.RepoStateReader and env.RepoStateWriter of a RevisionDatabase. Its head is the
+// revision, and it refuses every change.
+type revisionRepoState struct {
+	dbName   string
+	revision string
+	ddb      *doltdb.DoltDB
+	base     env.RepoStateReader
+	headRef  ref.DoltRef
+	headSpec *doltdb.CommitSpec
+}
+
+var _ env.RepoStateReader = (*revisionRepoState)(nil)
+var _ env.RepoStateWriter = (*revisionRepoState)(nil)
+
+// newRevisionRepoState returns the repo state of |revision| of |db|. Branches and tags are resolved whenever the head
+// is, and other commit specs are resolved to a commit once.
+func newRevisionRepoState(ctx context.Context, db Database, revision string) (*revisionRepoState, error) {
+	rs := &revisionRepoState{dbName: db.Name(), revision: revision, ddb: db.ddb, base: db.rsr}
+
+	for _, r := range []ref.DoltRef{ref.NewBranchRef(revision), ref.NewTagRef(revision)} {
+		ok, err := db.ddb.HasRef(ctx, r)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			cs, err := doltdb.NewCommitSpec(r.String())
+
+			if err != nil {
+				return nil, err
+			}
+
+			rs.headRef, rs.headSpec = r, cs
+			return rs, nil
+		}
+	}
+
+	cs, err := doltdb.NewCommitSpec(revision)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := db.ddb.Resolve(ctx, cs, db.rsr.CWBHeadRef())
+
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rs.headSpec, err = doltdb.NewCommitSpec(h.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	rs.headRef = ref.NewInternalRef(h.String())
+	return rs, nil
+}
+
+func (rs *revisionRepoState) readOnlyErr() error {
+	return ErrRevisionDatabaseReadOnly.New(rs.dbName+RevisionDelimiter+rs.revision, rs.revision, rs.dbName)
+}
+
+func (rs *revisionRepoState) CWBHeadRef() ref.DoltRef {
+	return rs.headRef
+}
+
+func (rs *revisionRepoState) CWBHeadSpec() *doltdb.CommitSpec {
+	return rs.headSpec
+}
+
+func (rs *revisionRepoState) CWBHeadHash(ctx context.Context) (hash.Hash, error) {
+	cm, err := rs.ddb.Resolve(ctx, rs.headSpec, rs.headRef)
+
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	return cm.HashOf()
+}
+
+// WorkingHash returns the hash of the root of the head, or an empty hash if the head can not be resolved.
+func (rs *revisionRepoState) WorkingHash() hash.Hash {
+	ctx := context.Background()
+	cm, err := rs.ddb.Resolve(ctx, rs.headSpec, rs.headRef)
+
+	if err != nil {
+		return hash.Hash{}
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return hash.Hash{}
+	}
+
+	h, err := root.HashOf()
+
+	if err != nil {
+		return hash.Hash{}
+	}
+
+	return h
+}
+
+func (rs *revisionRepoState) StagedHash() hash.Hash {
+	return rs.WorkingHash()
+}
+
+func (rs *revisionRepoState) IsMergeActive() bool {
+	return false
+}
+
+func (rs *revisionRepoState) GetMergeCommit() string {
+	return ""
+}
+
+func (rs *revisionRepoState) GetMergeCommitSpec() string {
+	return ""
+}
+
+func (rs *revisionRepoState) GetPreMergeWorking() string {
+	return ""
+}
+
+func (rs *revisionRepoState) IsRebaseActive() bool {
+	return false
+}
+
+func (rs *revisionRepoState) GetRebaseOrigHead() string {
+	return ""
+}
+
+func (rs *revisionRepoState) GetRemotes() (map[string]env.Remote, error) {
+	return rs.base.GetRemotes()
+}
+
+// SetStagedHash refuses to change the staged root, unless it is set to the root of the head.
+func (rs *revisionRepoState) SetStagedHash(ctx context.Context, h hash.Hash) error {
+	if h == rs.WorkingHash() {
+		return nil
+	}
+
+	return rs.readOnlyErr()
+}
+
+// SetWorkingHash refuses to change the working root, unless it is set to the root of the head, which is what
+// committing the transaction of a statement which did not change anything does.
+func (rs *revisionRepoState) SetWorkingHash(ctx context.Context, h hash.Hash) error {
+	if h == rs.WorkingHash() {
+		return nil
+	}
+
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) SetCWBHeadRef(context.Context, ref.MarshalableRef) error {
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) AbortMerge() error {
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) ClearMerge() error {
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) StartMerge(commitStr, commitSpecStr string) error {
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) AddRemote(r env.Remote) error {
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) RemoveRemote(name string) error {
+	return rs.readOnlyErr()
+}
+
+func (rs *revisionRepoState) RenameBranchConfig(oldName, newName string) error {
+	return rs.readOnlyErr()
+}
@@ -0,0 +1,46 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitRevisionDbName(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseName string
+		revision string
+		ok       bool
+	}{
+		{"mydb", "mydb", "", false},
+		{"mydb/master", "mydb", "master", true},
+		{"mydb/feature/x", "mydb", "feature/x", true},
+		{"mydb/HEAD~2", "mydb", "HEAD~2", true},
+		{"/master", "/master", "", false},
+		{"mydb/", "mydb/", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			baseName, revision, ok := SplitRevisionDbName(test.name)
+			assert.Equal(t, test.baseName, baseName)
+			assert.Equal(t, test.revision, revision)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package sqlfmt
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -198,6 +199,9 @@ func TestRowAsUpdateStmt(t *testing.T) {
 
 func TestValueAsSqlString(t *testing.T) {
 	tu, _ := uuid.Parse("00000000-0000-0000-0000-000000000000")
+	doc := `{"a": "it's"}`
+	tj, err := typeinfo.JSONType.ParseValue(context.Background(), types.NewMemoryValueStore(), &doc)
+	require.NoError(t, err)
 
 	tests := []struct {
 		name string
@@ -236,6 +240,12 @@ func TestValueAsSqlString(t *testing.T) {
 			ti:   typeinfo.StringDefaultType,
 			exp:  "'\\0\\'\\\"\\b\\n\\r\\t\\Z\\\\'",
 		},
+		{
+			name: "json",
+			val:  tj,
+			ti:   typeinfo.JSONType,
+			exp:  `'{\"a\":\"it\'s\"}'`,
+		},
 	}
 
 	for _, test := range tests {
@@ -49,6 +49,15 @@ type TableReadCloser interface {
 	TableCloser
 }
 
+// TypedTableReader is a TableReadCloser for a file format that stores the type of each column, such as parquet. The
+// column types of its schema are read from the file, and don't need to be inferred from the rows.
+type TypedTableReader interface {
+	TableReadCloser
+
+	// ColumnTypesStored is a marker method for readers whose schema has the column types stored in the file.
+	ColumnTypesStored()
+}
+
 // SqlTableReader is a  TableReader that can read rows as sql.Row.
 type SqlTableReader interface {
 	// GetSchema gets the schema of the rows that this reader will return
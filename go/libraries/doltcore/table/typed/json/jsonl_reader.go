@@ -0,0 +1,153 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// JSONLReader reads newline delimited JSON, where each line holds one row as a JSON object. Rows are read one line at
+// a time, so the input is never buffered as a whole.
+type JSONLReader struct {
+	vrw    types.ValueReadWriter
+	closer io.Closer
+	bRd    *bufio.Reader
+	sch    schema.Schema
+	line   int
+}
+
+func OpenJSONLReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONLReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLReader(vrw, r, sch)
+}
+
+func NewJSONLReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema) (*JSONLReader, error) {
+	if sch == nil {
+		return nil, errors.New("schema must be provided to JSONLReader")
+	}
+
+	return &JSONLReader{vrw: vrw, closer: r, bRd: bufio.NewReaderSize(r, ReadBufSize), sch: sch}, nil
+}
+
+// Close should release resources being held
+func (r *JSONLReader) Close(ctx context.Context) error {
+	if r.closer != nil {
+		err := r.closer.Close()
+		r.closer = nil
+
+		return err
+	}
+	return errors.New("already closed")
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *JSONLReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// VerifySchema checks that the incoming schema matches the schema from the existing table
+func (r *JSONLReader) VerifySchema(sch schema.Schema) (bool, error) {
+	return true, nil
+}
+
+// ReadRow reads the next row. Blank lines are skipped. A line that isn't a valid JSON object is returned as a bad
+// row.
+func (r *JSONLReader) ReadRow(ctx context.Context) (row.Row, error) {
+	for {
+		line, err := r.bRd.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		r.line++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		rowMap, jsonErr := decodeJSONLRow(r.sch, line)
+		if jsonErr != nil || rowMap == nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("line %d is not a JSON object: %s", r.line, string(line)))
+		}
+
+		rowRes, convErr := rowFromMap(ctx, r.vrw, r.sch, rowMap)
+		if convErr != nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("line %d: %s", r.line, convErr.Error()))
+		}
+
+		return rowRes, nil
+	}
+}
+
+// decodeJSONLRow decodes |line|, a JSON object holding a row of |sch|. Numbers are decoded as json.Numbers so that they
+// keep the precision they are written with, and the values of JSON columns are kept as the raw text of their documents.
+func decodeJSONLRow(sch schema.Schema, line []byte) (map[string]interface{}, error) {
+	var rawMap map[string]json.RawMessage
+	if err := decodeJSON(line, &rawMap); err != nil || rawMap == nil {
+		return nil, err
+	}
+
+	rowMap := make(map[string]interface{}, len(rawMap))
+	for k, raw := range rawMap {
+		if col, ok := sch.GetAllCols().GetByName(k); ok && col.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier {
+			rowMap[k] = raw
+			continue
+		}
+
+		var v interface{}
+		if err := decodeJSON(raw, &v); err != nil {
+			return nil, err
+		}
+		rowMap[k] = v
+	}
+
+	return rowMap, nil
+}
+
+// decodeJSON decodes the single JSON value in |data| into |v|, with numbers decoded as json.Numbers.
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if dec.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func jsonlTestSchema(t *testing.T) schema.Schema {
+	colColl := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("first name", 1, types.StringKind, false),
+		schema.NewColumn("last name", 2, types.StringKind, false),
+	)
+
+	sch, err := schema.SchemaFromCols(colColl)
+	require.NoError(t, err)
+	return sch
+}
+
+func TestJSONLReader(t *testing.T) {
+	testJSONL := `{"id": 0, "first name": "tim", "last name": "sehn"}
+
+{"id": 1, "first name": "brian", "last name": "hendriks"}
+{"id": 2, "first name": "aaron", bad}
+{"id": 3, "first name": "zach"}`
+
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("file.jsonl", []byte(testJSONL)))
+
+	sch := jsonlTestSchema(t)
+	vrw := types.NewMemoryValueStore()
+	reader, err := OpenJSONLReader(vrw, "file.jsonl", fs, sch)
+	require.NoError(t, err)
+	defer reader.Close(context.Background())
+
+	var rows []row.Row
+	var badRows int
+	for {
+		r, err := reader.ReadRow(context.Background())
+		if err == io.EOF {
+			break
+		} else if table.IsBadRow(err) {
+			badRows++
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+
+	zach, err := row.New(types.Format_Default, sch, row.TaggedValues{0: types.Int(3), 1: types.String("zach")})
+	require.NoError(t, err)
+	expectedRows := []row.Row{
+		newRow(sch, 0, "tim", "sehn"),
+		newRow(sch, 1, "brian", "hendriks"),
+		zach,
+	}
+
+	assert.Equal(t, 1, badRows)
+	assert.Equal(t, expectedRows, rows)
+}
+
+func TestJSONLReaderNumbers(t *testing.T) {
+	ctx := context.Background()
+	colColl := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("big", 1, types.UintKind, false),
+		schema.Column{Name: "doc", Tag: 2, Kind: types.JSONKind, TypeInfo: typeinfo.JSONType},
+	)
+	sch, err := schema.SchemaFromCols(colColl)
+	require.NoError(t, err)
+
+	testJSONL := `{"id": 9007199254740993, "big": 18446744073709551615, "doc": {"n": 12345678901234567890.123456789}}
+{"id": 1} trailing`
+
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("file.jsonl", []byte(testJSONL)))
+
+	vrw := types.NewMemoryValueStore()
+	reader, err := OpenJSONLReader(vrw, "file.jsonl", fs, sch)
+	require.NoError(t, err)
+	defer reader.Close(ctx)
+
+	r, err := reader.ReadRow(ctx)
+	require.NoError(t, err)
+
+	id, _ := r.GetColVal(0)
+	assert.Equal(t, types.Int(9007199254740993), id)
+	big, _ := r.GetColVal(1)
+	assert.Equal(t, types.Uint(18446744073709551615), big)
+
+	docVal, _ := r.GetColVal(2)
+	doc, err := typeinfo.JSONType.FormatValue(docVal)
+	require.NoError(t, err)
+	assert.Equal(t, `{"n":12345678901234567890.123456789}`, *doc)
+
+	_, err = reader.ReadRow(ctx)
+	assert.True(t, table.IsBadRow(err))
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	colColl := schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("name", 1, types.StringKind, false),
+		schema.Column{Name: "doc", Tag: 2, Kind: types.JSONKind, TypeInfo: typeinfo.JSONType},
+	)
+	sch, err := schema.SchemaFromCols(colColl)
+	require.NoError(t, err)
+
+	vrw := types.NewMemoryValueStore()
+	doc := `{"a": [1, 2]}`
+	docVal, err := typeinfo.JSONType.ParseValue(ctx, vrw, &doc)
+	require.NoError(t, err)
+
+	var rows []row.Row
+	for i, name := range []string{"line\nbreak", `quote"d`, ""} {
+		vals := row.TaggedValues{0: types.Int(i), 1: types.String(name)}
+		if i == 0 {
+			vals[2] = docVal
+		}
+		r, err := row.New(types.Format_Default, sch, vals)
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+
+	fs := filesys.EmptyInMemFS("/")
+	wr, err := OpenJSONLWriter("/file.jsonl", fs, sch)
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, wr.WriteRow(ctx, r))
+	}
+	require.NoError(t, wr.Close(ctx))
+
+	data, err := fs.ReadFile("/file.jsonl")
+	require.NoError(t, err)
+	expected := `{"doc":{"a":[1,2]},"id":0,"name":"line\nbreak"}
+{"id":1,"name":"quote\"d"}
+{"id":2,"name":""}
+`
+	assert.Equal(t, expected, string(data))
+
+	reader, err := OpenJSONLReader(vrw, "/file.jsonl", fs, sch)
+	require.NoError(t, err)
+	defer reader.Close(ctx)
+
+	for _, expectedRow := range rows {
+		r, err := reader.ReadRow(ctx)
+		require.NoError(t, err)
+		for tag := uint64(0); tag < 2; tag++ {
+			expectedVal, _ := expectedRow.GetColVal(tag)
+			actualVal, _ := r.GetColVal(tag)
+			assert.True(t, expectedVal.Equals(actualVal), "tag %d: expected %v, got %v", tag, expectedVal, actualVal)
+		}
+	}
+	_, err = reader.ReadRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
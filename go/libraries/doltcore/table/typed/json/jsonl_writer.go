@@ -0,0 +1,91 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+// JSONLWriter writes newline delimited JSON, one JSON object per row.
+type JSONLWriter struct {
+	closer io.Closer
+	bWr    *bufio.Writer
+	sch    schema.Schema
+}
+
+func OpenJSONLWriter(path string, fs filesys.WritableFS, outSch schema.Schema) (*JSONLWriter, error) {
+	err := fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := fs.OpenForWrite(path, os.ModePerm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLWriter(wr, outSch)
+}
+
+func NewJSONLWriter(wr io.WriteCloser, outSch schema.Schema) (*JSONLWriter, error) {
+	return &JSONLWriter{closer: wr, bWr: bufio.NewWriterSize(wr, WriteBufSize), sch: outSch}, nil
+}
+
+func (jsonlw *JSONLWriter) GetSchema() schema.Schema {
+	return jsonlw.sch
+}
+
+// WriteRow will write a row to a table
+func (jsonlw *JSONLWriter) WriteRow(ctx context.Context, r row.Row) error {
+	colValMap, err := rowToMap(jsonlw.sch, r)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalToJson(colValMap)
+	if err != nil {
+		return err
+	}
+
+	return iohelp.WriteAll(jsonlw.bWr, append(data, '\n'))
+}
+
+// Close should flush all writes, release resources being held
+func (jsonlw *JSONLWriter) Close(ctx context.Context) error {
+	if jsonlw.closer != nil {
+		errFl := jsonlw.bWr.Flush()
+		errCl := jsonlw.closer.Close()
+		jsonlw.closer = nil
+
+		if errCl != nil {
+			return errCl
+		}
+
+		return errFl
+	}
+	return errors.New("already closed")
+}
@@ -16,6 +16,7 @@ package json
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -111,7 +113,12 @@ func (r *JSONReader) ReadRow(ctx context.Context) (row.Row, error) {
 }
 
 func (r *JSONReader) convToRow(ctx context.Context, rowMap map[string]interface{}) (row.Row, error) {
-	allCols := r.sch.GetAllCols()
+	return rowFromMap(ctx, r.vrw, r.sch, rowMap)
+}
+
+// rowFromMap converts a decoded JSON object to a row of |sch|, with the keys of the object matching column names.
+func rowFromMap(ctx context.Context, vrw types.ValueReadWriter, sch schema.Schema, rowMap map[string]interface{}) (row.Row, error) {
+	allCols := sch.GetAllCols()
 
 	taggedVals := make(row.TaggedValues, allCols.Size())
 
@@ -121,15 +128,37 @@ func (r *JSONReader) convToRow(ctx context.Context, rowMap map[string]interface{
 			return nil, fmt.Errorf("column %s not found in schema", k)
 		}
 
-		switch v.(type) {
+		if col.TypeInfo.GetTypeIdentifier() == typeinfo.JSONTypeIdentifier {
+			// JSON documents are nested in the row as JSON values rather than strings
+			doc, ok := v.(json.RawMessage)
+			if !ok {
+				var err error
+				doc, err = json.Marshal(v)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			var err error
+			taggedVals[col.Tag], err = col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, []byte(doc))
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch val := v.(type) {
 		case int, string, bool, float64:
-			taggedVals[col.Tag], _ = col.TypeInfo.ConvertValueToNomsValue(ctx, r.vrw, v)
+			taggedVals[col.Tag], _ = col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, v)
+		case json.Number:
+			// numbers decoded with UseNumber are converted from their text, which keeps their precision
+			taggedVals[col.Tag], _ = col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, val.String())
 		}
 
 	}
 
 	// todo: move null value checks to pipeline
-	err := r.sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+	err := sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		if val, ok := taggedVals.Get(tag); !col.IsNullable() && (!ok || types.IsNull(val)) {
 			return true, fmt.Errorf("column `%s` does not allow null values", col.Name)
 		}
@@ -139,5 +168,5 @@ func (r *JSONReader) convToRow(ctx context.Context, rowMap map[string]interface{
 		return nil, err
 	}
 
-	return row.New(r.vrw.Format(), r.sch, taggedVals)
+	return row.New(vrw.Format(), sch, taggedVals)
 }
@@ -75,44 +75,10 @@ func (jsonw *JSONWriter) GetSchema() schema.Schema {
 
 // WriteRow will write a row to a table
 func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
-	allCols := jsonw.sch.GetAllCols()
-	colValMap := make(map[string]interface{}, allCols.Size())
-	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		val, ok := r.GetColVal(tag)
-		if !ok || types.IsNull(val) {
-			return false, nil
-		}
-
-		switch col.TypeInfo.GetTypeIdentifier() {
-		case typeinfo.DatetimeTypeIdentifier,
-			typeinfo.DecimalTypeIdentifier,
-			typeinfo.EnumTypeIdentifier,
-			typeinfo.InlineBlobTypeIdentifier,
-			typeinfo.SetTypeIdentifier,
-			typeinfo.TimeTypeIdentifier,
-			typeinfo.TupleTypeIdentifier,
-			typeinfo.UuidTypeIdentifier,
-			typeinfo.VarBinaryTypeIdentifier,
-			typeinfo.YearTypeIdentifier:
-			v, err := col.TypeInfo.FormatValue(val)
-			if err != nil {
-				return true, err
-			}
-			val = types.String(*v)
-
-		case typeinfo.BitTypeIdentifier,
-			typeinfo.BoolTypeIdentifier,
-			typeinfo.VarStringTypeIdentifier,
-			typeinfo.UintTypeIdentifier,
-			typeinfo.IntTypeIdentifier,
-			typeinfo.FloatTypeIdentifier:
-			// use primitive type
-		}
-
-		colValMap[col.Name] = val
-
-		return false, nil
-	})
+	colValMap, err := rowToMap(jsonw.sch, r)
+	if err != nil {
+		return err
+	}
 
 	data, err := marshalToJson(colValMap)
 	if err != nil {
@@ -169,3 +135,60 @@ func marshalToJson(valMap interface{}) ([]byte, error) {
 	}
 	return jsonBytes, nil
 }
+
+// rowToMap returns the values of |r| keyed by column name, in the form they are marshalled to JSON. Null values are
+// left out.
+func rowToMap(sch schema.Schema, r row.Row) (map[string]interface{}, error) {
+	allCols := sch.GetAllCols()
+	colValMap := make(map[string]interface{}, allCols.Size())
+	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			return false, nil
+		}
+
+		switch col.TypeInfo.GetTypeIdentifier() {
+		case typeinfo.DatetimeTypeIdentifier,
+			typeinfo.DecimalTypeIdentifier,
+			typeinfo.EnumTypeIdentifier,
+			typeinfo.InlineBlobTypeIdentifier,
+			typeinfo.SetTypeIdentifier,
+			typeinfo.TimeTypeIdentifier,
+			typeinfo.TupleTypeIdentifier,
+			typeinfo.UuidTypeIdentifier,
+			typeinfo.VarBinaryTypeIdentifier,
+			typeinfo.YearTypeIdentifier:
+			v, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+			val = types.String(*v)
+
+		case typeinfo.JSONTypeIdentifier:
+			v, err := col.TypeInfo.FormatValue(val)
+			if err != nil {
+				return true, err
+			}
+			colValMap[col.Name] = json.RawMessage(*v)
+			return false, nil
+
+		case typeinfo.BitTypeIdentifier,
+			typeinfo.BoolTypeIdentifier,
+			typeinfo.VarStringTypeIdentifier,
+			typeinfo.UintTypeIdentifier,
+			typeinfo.IntTypeIdentifier,
+			typeinfo.FloatTypeIdentifier:
+			// use primitive type
+		}
+
+		colValMap[col.Name] = val
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return colValMap, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func mustTypeInfo(t *testing.T, sqlType sql.Type) typeinfo.TypeInfo {
+	ti, err := typeinfo.FromSqlType(sqlType)
+	require.NoError(t, err)
+	return ti
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	varcharType := mustTypeInfo(t, sql.MustCreateStringWithDefaults(sqltypes.VarChar, 20))
+	decimalType := mustTypeInfo(t, sql.MustCreateDecimalType(10, 2))
+	tests := []struct {
+		name     string
+		ti       typeinfo.TypeInfo
+		readType typeinfo.TypeInfo
+		vals     []string
+	}{
+		{"id", typeinfo.Int64Type, typeinfo.Int64Type, []string{"1", "-2", "3"}},
+		{"i8", typeinfo.Int8Type, typeinfo.Int8Type, []string{"-128", "127", ""}},
+		{"i32", typeinfo.Int32Type, typeinfo.Int32Type, []string{"-2147483648", "0", "2147483647"}},
+		{"u16", typeinfo.Uint16Type, typeinfo.Uint16Type, []string{"65535", "0", ""}},
+		{"u32", typeinfo.Uint32Type, typeinfo.Uint32Type, []string{"4294967295", "1", "2"}},
+		{"u64", typeinfo.Uint64Type, typeinfo.Uint64Type, []string{"18446744073709551615", "0", ""}},
+		{"f32", typeinfo.Float32Type, typeinfo.Float32Type, []string{"1.5", "-0.25", ""}},
+		{"f64", typeinfo.Float64Type, typeinfo.Float64Type, []string{"3.141592653589793", "0", "-1e100"}},
+		{"bool", typeinfo.BoolType, typeinfo.BoolType, []string{"1", "0", ""}},
+		{"dec", decimalType, decimalType, []string{"12345678.90", "-0.01", "-128.00"}},
+		{"str", varcharType, typeinfo.StringDefaultType, []string{"abc", "", "héllo"}},
+		{"date", typeinfo.DateType, typeinfo.DateType, []string{"2021-03-04", "1000-01-01", "1969-12-31"}},
+		{"dt", typeinfo.DatetimeType, typeinfo.DatetimeType, []string{"2021-03-04 05:06:07.123456", "1000-01-01 00:00:00", ""}},
+		{"time", typeinfo.TimeType, typeinfo.TimeType, []string{"12:34:56.789", "-838:59:59", ""}},
+		{"year", typeinfo.YearType, typeinfo.Int16Type, []string{"2021", "1901", ""}},
+		{"json", typeinfo.JSONType, typeinfo.JSONType, []string{`{"a": [1, 2]}`, `"str"`, ""}},
+		{"uuid", typeinfo.UuidType, typeinfo.UuidType, []string{"e9f4c1b6-1d1c-4c0e-9f2a-6d3a1b2c3d4e", "", ""}},
+		{"blob", mustTypeInfo(t, sql.LongBlob), mustTypeInfo(t, sql.LongBlob), []string{"\x00\x01\xff", "", ""}},
+	}
+
+	var cols []schema.Column
+	for i, test := range tests {
+		var constraints []schema.ColConstraint
+		if i == 0 {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+		col, err := schema.NewColumnWithTypeInfo(test.name, uint64(i), test.ti, i == 0, "", false, "", constraints...)
+		require.NoError(t, err)
+		cols = append(cols, col)
+	}
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	require.NoError(t, err)
+
+	numRows := len(tests[0].vals)
+	var rows []row.Row
+	for i := 0; i < numRows; i++ {
+		taggedVals := make(row.TaggedValues)
+		for tag, test := range tests {
+			if test.vals[i] == "" && test.name != "str" && test.name != "blob" {
+				continue
+			}
+			taggedVals[uint64(tag)], err = test.ti.ParseValue(ctx, vrw, &test.vals[i])
+			require.NoError(t, err, test.name)
+		}
+		r, err := row.New(types.Format_Default, sch, taggedVals)
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+
+	fs := filesys.EmptyInMemFS("/")
+	wr, err := OpenParquetWriter("/data/file.parquet", fs, sch)
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, wr.WriteRow(ctx, r))
+	}
+	require.NoError(t, wr.Close(ctx))
+
+	rd, err := OpenParquetReader(vrw, "/data/file.parquet", fs)
+	require.NoError(t, err)
+	defer rd.Close(ctx)
+
+	readCols := rd.GetSchema().GetAllCols()
+	require.Equal(t, len(tests), readCols.Size())
+	for tag, test := range tests {
+		col, ok := readCols.GetByTag(uint64(tag))
+		require.True(t, ok)
+		assert.Equal(t, test.name, col.Name)
+		assert.True(t, test.readType.Equals(col.TypeInfo), "%s: expected %s, got %s", test.name, test.readType, col.TypeInfo)
+		assert.Equal(t, tag != 0, col.IsNullable(), test.name)
+	}
+
+	for i := 0; i < numRows; i++ {
+		r, err := rd.ReadRow(ctx)
+		require.NoError(t, err)
+
+		for tag, test := range tests {
+			expected, _ := rows[i].GetColVal(uint64(tag))
+			actual, _ := r.GetColVal(uint64(tag))
+			if types.IsNull(expected) {
+				assert.True(t, types.IsNull(actual), "%s row %d: expected null, got %v", test.name, i, actual)
+				continue
+			}
+
+			expectedStr, err := test.ti.FormatValue(expected)
+			require.NoError(t, err)
+			col, _ := readCols.GetByTag(uint64(tag))
+			actualStr, err := col.TypeInfo.FormatValue(actual)
+			require.NoError(t, err)
+			assert.Equal(t, *expectedStr, *actualStr, "%s row %d", test.name, i)
+		}
+	}
+
+	_, err = rd.ReadRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecimalBytes(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected []byte
+	}{
+		{"0", []byte{0x00}},
+		{"1.27", []byte{0x7f}},
+		{"1.28", []byte{0x00, 0x80}},
+		{"-1.28", []byte{0x80}},
+		{"-1.29", []byte{0xff, 0x7f}},
+		{"-0.01", []byte{0xff}},
+		{"655.36", []byte{0x01, 0x00, 0x00}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.val, func(t *testing.T) {
+			d := decimal.RequireFromString(test.val)
+			b := decimalToBytes(d, 2)
+			assert.Equal(t, test.expected, b)
+			assert.True(t, d.Equal(decimalFromBytes(b, 2)))
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ReadBatchSize is the number of values read from each column of the file at a time
+var ReadBatchSize = 4 * 1024
+
+// ParquetReader reads the rows of a parquet file. The types of the columns of its schema come from the types stored
+// in the file.
+type ParquetReader struct {
+	vrw      types.ValueReadWriter
+	file     source.ParquetFile
+	pr       *reader.ParquetReader
+	sch      schema.Schema
+	elements []*parquet.SchemaElement
+	rowsLeft int64
+	batch    [][]interface{}
+	batchPos int
+}
+
+// OpenParquetReader opens the parquet file at |path| for reading. The file is read as its rows are, rather than loaded
+// into memory, so |fs| must open files which can seek.
+func OpenParquetReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS) (*ParquetReader, error) {
+	file, err := openFSFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewParquetReader(vrw, file)
+}
+
+// NewParquetReader creates a ParquetReader for |file|, which the reader will close when it is closed.
+func NewParquetReader(vrw types.ValueReadWriter, file source.ParquetFile) (*ParquetReader, error) {
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	// the reader renames the schema elements to go identifiers, the names from the file are kept in the infos
+	names := make([]string, len(pr.SchemaHandler.Infos))
+	for i, info := range pr.SchemaHandler.Infos {
+		names[i] = info.ExName
+	}
+
+	elements, sch, err := schemaFromParquet(pr.SchemaHandler.SchemaElements, names)
+	if err != nil {
+		pr.ReadStop()
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &ParquetReader{
+		vrw:      vrw,
+		file:     file,
+		pr:       pr,
+		sch:      sch,
+		elements: elements,
+		rowsLeft: pr.GetNumRows(),
+	}, nil
+}
+
+// schemaFromParquet returns the columns of a parquet file, with the names from |names|, and the schema of the rows read
+// from it. Only flat files are supported, where every column is a direct child of the root of the parquet schema.
+func schemaFromParquet(elements []*parquet.SchemaElement, names []string) ([]*parquet.SchemaElement, schema.Schema, error) {
+	if len(elements) < 2 {
+		return nil, nil, errors.New("parquet file has no columns")
+	}
+
+	colElements := make([]*parquet.SchemaElement, 0, len(elements)-1)
+	cols := make([]schema.Column, 0, len(elements)-1)
+	for i := range elements[1:] {
+		el := *elements[i+1]
+		el.Name = names[i+1]
+		name := el.Name
+		if el.GetNumChildren() > 0 {
+			return nil, nil, fmt.Errorf("parquet column '%s' is a nested column, which is not supported", name)
+		}
+		if el.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+			return nil, nil, fmt.Errorf("parquet column '%s' is a repeated column, which is not supported", name)
+		}
+
+		ti, err := typeInfoFromSchemaElement(&el)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var constraints []schema.ColConstraint
+		if el.GetRepetitionType() == parquet.FieldRepetitionType_REQUIRED {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+
+		// We need at least one primary key col, so choose the first one
+		col, err := schema.NewColumnWithTypeInfo(name, uint64(i), ti, i == 0, "", false, "", constraints...)
+		if err != nil {
+			return nil, nil, err
+		}
+		colElements = append(colElements, &el)
+		cols = append(cols, col)
+	}
+
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return colElements, sch, nil
+}
+
+// ColumnTypesStored implements table.TypedTableReader.
+func (r *ParquetReader) ColumnTypesStored() {}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *ParquetReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// VerifySchema checks that the incoming schema matches the schema from the existing table
+func (r *ParquetReader) VerifySchema(outSch schema.Schema) (bool, error) {
+	return schema.VerifyInSchema(r.sch, outSch)
+}
+
+// ReadRow reads a row from a table.
+func (r *ParquetReader) ReadRow(ctx context.Context) (row.Row, error) {
+	if r.pr == nil {
+		return nil, errors.New("reader is closed")
+	}
+
+	if r.batch == nil || r.batchPos >= len(r.batch[0]) {
+		if r.rowsLeft <= 0 {
+			return nil, io.EOF
+		}
+
+		err := r.readBatch()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	taggedVals := make(row.TaggedValues, len(r.elements))
+	for i, el := range r.elements {
+		tag := uint64(i)
+		col, _ := r.sch.GetAllCols().GetByTag(tag)
+
+		val, err := valueFromParquet(el, r.batch[i][r.batchPos])
+		if err != nil {
+			return nil, err
+		}
+
+		taggedVals[tag], err = col.TypeInfo.ConvertValueToNomsValue(ctx, r.vrw, val)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r.batchPos++
+
+	return row.New(r.vrw.Format(), r.sch, taggedVals)
+}
+
+func (r *ParquetReader) readBatch() error {
+	n := r.rowsLeft
+	if n > int64(ReadBatchSize) {
+		n = int64(ReadBatchSize)
+	}
+
+	batch := make([][]interface{}, len(r.elements))
+	for i := range r.elements {
+		vals, _, _, err := r.pr.ReadColumnByIndex(int64(i), n)
+		if err != nil {
+			return err
+		}
+		if int64(len(vals)) != n {
+			return fmt.Errorf("expected %d values in parquet column '%s', found %d", n, r.elements[i].GetName(), len(vals))
+		}
+		batch[i] = vals
+	}
+
+	r.batch = batch
+	r.batchPos = 0
+	r.rowsLeft -= n
+
+	return nil
+}
+
+// Close should release resources being held
+func (r *ParquetReader) Close(ctx context.Context) error {
+	if r.pr != nil {
+		r.pr.ReadStop()
+		r.pr = nil
+
+		return r.file.Close()
+	}
+	return errors.New("already closed")
+}
+
+// fsFile is a source.ParquetFile which reads a file of a filesys.ReadableFS. The parquet reader opens the file again
+// for each column it reads.
+type fsFile struct {
+	fs   filesys.ReadableFS
+	path string
+	rd   io.ReadSeeker
+	cl   io.Closer
+}
+
+var _ source.ParquetFile = (*fsFile)(nil)
+
+func openFSFile(fs filesys.ReadableFS, path string) (*fsFile, error) {
+	rc, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rd, ok := rc.(io.ReadSeeker)
+	if !ok {
+		_ = rc.Close()
+		return nil, fmt.Errorf("parquet file '%s' can not be read because it was not opened for seeking", path)
+	}
+
+	return &fsFile{fs: fs, path: path, rd: rd, cl: rc}, nil
+}
+
+// Open opens the file at |name|, or this file again if |name| is empty.
+func (f *fsFile) Open(name string) (source.ParquetFile, error) {
+	if name == "" {
+		name = f.path
+	}
+	return openFSFile(f.fs, name)
+}
+
+func (f *fsFile) Create(name string) (source.ParquetFile, error) {
+	return nil, errors.New("parquet files are not written through a reader")
+}
+
+func (f *fsFile) Seek(offset int64, whence int) (int64, error) {
+	return f.rd.Seek(offset, whence)
+}
+
+// Read reads len(b) bytes unless the end of the file is reached first, as the parquet reader expects.
+func (f *fsFile) Read(b []byte) (int, error) {
+	n, err := io.ReadFull(f.rd, b)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (f *fsFile) Write(b []byte) (int, error) {
+	return 0, errors.New("parquet files are not written through a reader")
+}
+
+func (f *fsFile) Close() error {
+	return f.cl.Close()
+}
@@ -0,0 +1,446 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go/parquet"
+	parquettypes "github.com/xitongsys/parquet-go/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+	uuidLength    = 16
+)
+
+// convertedType returns the converted type annotation of the parquet column |el|. Files written with only the newer
+// logical type annotation have it derived from that. Logical types without a converted type equivalent, such as
+// UUID, return false.
+func convertedType(el *parquet.SchemaElement) (parquet.ConvertedType, bool) {
+	if el.ConvertedType != nil {
+		return *el.ConvertedType, true
+	}
+
+	lt := el.LogicalType
+	if lt == nil {
+		return 0, false
+	}
+
+	switch {
+	case lt.STRING != nil:
+		return parquet.ConvertedType_UTF8, true
+	case lt.ENUM != nil:
+		return parquet.ConvertedType_ENUM, true
+	case lt.JSON != nil:
+		return parquet.ConvertedType_JSON, true
+	case lt.BSON != nil:
+		return parquet.ConvertedType_BSON, true
+	case lt.DECIMAL != nil:
+		return parquet.ConvertedType_DECIMAL, true
+	case lt.DATE != nil:
+		return parquet.ConvertedType_DATE, true
+	case lt.TIME != nil && lt.TIME.Unit != nil && lt.TIME.Unit.MILLIS != nil:
+		return parquet.ConvertedType_TIME_MILLIS, true
+	case lt.TIME != nil:
+		return parquet.ConvertedType_TIME_MICROS, true
+	case lt.TIMESTAMP != nil && lt.TIMESTAMP.Unit != nil && lt.TIMESTAMP.Unit.MILLIS != nil:
+		return parquet.ConvertedType_TIMESTAMP_MILLIS, true
+	case lt.TIMESTAMP != nil:
+		return parquet.ConvertedType_TIMESTAMP_MICROS, true
+	case lt.INTEGER != nil:
+		signed := map[int8]parquet.ConvertedType{
+			8: parquet.ConvertedType_INT_8, 16: parquet.ConvertedType_INT_16,
+			32: parquet.ConvertedType_INT_32, 64: parquet.ConvertedType_INT_64,
+		}
+		unsigned := map[int8]parquet.ConvertedType{
+			8: parquet.ConvertedType_UINT_8, 16: parquet.ConvertedType_UINT_16,
+			32: parquet.ConvertedType_UINT_32, 64: parquet.ConvertedType_UINT_64,
+		}
+		if lt.INTEGER.IsSigned {
+			ct, ok := signed[lt.INTEGER.BitWidth]
+			return ct, ok
+		}
+		ct, ok := unsigned[lt.INTEGER.BitWidth]
+		return ct, ok
+	}
+
+	return 0, false
+}
+
+// isNanos returns whether the parquet TIME or TIMESTAMP column |el| stores nanoseconds, which has no converted type
+// equivalent.
+func isNanos(el *parquet.SchemaElement) bool {
+	lt := el.LogicalType
+	if lt == nil || el.ConvertedType != nil {
+		return false
+	}
+	if lt.TIME != nil {
+		return lt.TIME.Unit != nil && lt.TIME.Unit.NANOS != nil
+	}
+	if lt.TIMESTAMP != nil {
+		return lt.TIMESTAMP.Unit != nil && lt.TIMESTAMP.Unit.NANOS != nil
+	}
+	return false
+}
+
+func isUUID(el *parquet.SchemaElement) bool {
+	return el.LogicalType != nil && el.LogicalType.UUID != nil &&
+		el.GetType() == parquet.Type_FIXED_LEN_BYTE_ARRAY && el.GetTypeLength() == uuidLength
+}
+
+// decimalParams returns the precision and scale of the parquet DECIMAL column |el|.
+func decimalParams(el *parquet.SchemaElement) (precision, scale int32) {
+	if el.Precision != nil {
+		return el.GetPrecision(), el.GetScale()
+	}
+	if el.LogicalType != nil && el.LogicalType.DECIMAL != nil {
+		return el.LogicalType.DECIMAL.Precision, el.LogicalType.DECIMAL.Scale
+	}
+	return 0, 0
+}
+
+// typeInfoFromSchemaElement returns the TypeInfo of the column imported from the parquet column |el|.
+func typeInfoFromSchemaElement(el *parquet.SchemaElement) (typeinfo.TypeInfo, error) {
+	ct, hasCT := convertedType(el)
+
+	if hasCT && ct == parquet.ConvertedType_DECIMAL {
+		precision, scale := decimalParams(el)
+		if precision > sql.DecimalTypeMaxPrecision || scale > sql.DecimalTypeMaxScale {
+			// too wide for a sql decimal, so the exact value is kept as text
+			return typeinfo.StringDefaultType, nil
+		}
+		return typeinfo.FromSqlType(sql.MustCreateDecimalType(uint8(precision), uint8(scale)))
+	}
+
+	switch el.GetType() {
+	case parquet.Type_BOOLEAN:
+		return typeinfo.BoolType, nil
+
+	case parquet.Type_INT32:
+		if !hasCT {
+			return typeinfo.Int32Type, nil
+		}
+		switch ct {
+		case parquet.ConvertedType_INT_8:
+			return typeinfo.Int8Type, nil
+		case parquet.ConvertedType_INT_16:
+			return typeinfo.Int16Type, nil
+		case parquet.ConvertedType_INT_32:
+			return typeinfo.Int32Type, nil
+		case parquet.ConvertedType_UINT_8:
+			return typeinfo.Uint8Type, nil
+		case parquet.ConvertedType_UINT_16:
+			return typeinfo.Uint16Type, nil
+		case parquet.ConvertedType_UINT_32:
+			return typeinfo.Uint32Type, nil
+		case parquet.ConvertedType_DATE:
+			return typeinfo.DateType, nil
+		case parquet.ConvertedType_TIME_MILLIS:
+			return typeinfo.TimeType, nil
+		}
+
+	case parquet.Type_INT64:
+		if !hasCT {
+			return typeinfo.Int64Type, nil
+		}
+		switch ct {
+		case parquet.ConvertedType_INT_64:
+			return typeinfo.Int64Type, nil
+		case parquet.ConvertedType_UINT_64:
+			return typeinfo.Uint64Type, nil
+		case parquet.ConvertedType_TIME_MICROS:
+			return typeinfo.TimeType, nil
+		case parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS:
+			return typeinfo.DatetimeType, nil
+		}
+
+	case parquet.Type_INT96:
+		return typeinfo.DatetimeType, nil
+
+	case parquet.Type_FLOAT:
+		return typeinfo.Float32Type, nil
+
+	case parquet.Type_DOUBLE:
+		return typeinfo.Float64Type, nil
+
+	case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		if isUUID(el) {
+			return typeinfo.UuidType, nil
+		}
+		if !hasCT {
+			return typeinfo.FromSqlType(sql.LongBlob)
+		}
+		switch ct {
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM:
+			return typeinfo.StringDefaultType, nil
+		case parquet.ConvertedType_JSON:
+			return typeinfo.JSONType, nil
+		case parquet.ConvertedType_BSON:
+			return typeinfo.FromSqlType(sql.LongBlob)
+		}
+	}
+
+	return nil, fmt.Errorf("parquet column '%s' has unsupported type %s %s", el.GetName(), el.GetType(), el.GetConvertedType())
+}
+
+// valueFromParquet converts the physical value |v| of the parquet column |el| to a go value that can be passed to the
+// ConvertValueToNomsValue method of the column's TypeInfo.
+func valueFromParquet(el *parquet.SchemaElement, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	ct, hasCT := convertedType(el)
+
+	if hasCT && ct == parquet.ConvertedType_DECIMAL {
+		_, scale := decimalParams(el)
+		switch val := v.(type) {
+		case int32:
+			return decimal.New(int64(val), -scale).StringFixed(scale), nil
+		case int64:
+			return decimal.New(val, -scale).StringFixed(scale), nil
+		case string:
+			return decimalFromBytes([]byte(val), scale).StringFixed(scale), nil
+		}
+		return nil, fmt.Errorf("unexpected decimal value %v in parquet column '%s'", v, el.GetName())
+	}
+
+	switch val := v.(type) {
+	case int32:
+		switch {
+		case !hasCT:
+			return int64(val), nil
+		case ct == parquet.ConvertedType_UINT_8 || ct == parquet.ConvertedType_UINT_16 || ct == parquet.ConvertedType_UINT_32:
+			return uint64(uint32(val)), nil
+		case ct == parquet.ConvertedType_DATE:
+			return time.Unix(int64(val)*secondsPerDay, 0).UTC(), nil
+		case ct == parquet.ConvertedType_TIME_MILLIS:
+			return sql.Time.Unmarshal(int64(val) * 1000), nil
+		}
+		return int64(val), nil
+
+	case int64:
+		switch {
+		case !hasCT:
+			return val, nil
+		case ct == parquet.ConvertedType_UINT_64:
+			return uint64(val), nil
+		case ct == parquet.ConvertedType_TIME_MICROS && isNanos(el):
+			return sql.Time.Unmarshal(val / 1000), nil
+		case ct == parquet.ConvertedType_TIME_MICROS:
+			return sql.Time.Unmarshal(val), nil
+		case ct == parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return time.Unix(val/1e3, (val%1e3)*1e6).UTC(), nil
+		case ct == parquet.ConvertedType_TIMESTAMP_MICROS && isNanos(el):
+			return time.Unix(0, val).UTC(), nil
+		case ct == parquet.ConvertedType_TIMESTAMP_MICROS:
+			return time.Unix(val/1e6, (val%1e6)*1e3).UTC(), nil
+		}
+		return val, nil
+
+	case string:
+		if el.GetType() == parquet.Type_INT96 {
+			return parquettypes.INT96ToTime(val).UTC(), nil
+		}
+		if isUUID(el) {
+			id, err := uuid.FromBytes([]byte(val))
+			if err != nil {
+				return nil, err
+			}
+			return id.String(), nil
+		}
+		return val, nil
+	}
+
+	return v, nil
+}
+
+// schemaElementFromColumn returns the parquet column that |col| is exported as.
+func schemaElementFromColumn(col schema.Column) (*parquet.SchemaElement, error) {
+	el := parquet.NewSchemaElement()
+	el.Name = col.Name
+	el.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_OPTIONAL)
+	if !col.IsNullable() {
+		el.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+	}
+
+	setType := func(t parquet.Type) {
+		el.Type = parquet.TypePtr(t)
+	}
+	setConvertedType := func(t parquet.Type, ct parquet.ConvertedType) {
+		setType(t)
+		el.ConvertedType = parquet.ConvertedTypePtr(ct)
+	}
+
+	sqlType := col.TypeInfo.ToSqlType()
+	switch col.TypeInfo.GetTypeIdentifier() {
+	case typeinfo.BitTypeIdentifier:
+		setConvertedType(parquet.Type_INT64, parquet.ConvertedType_UINT_64)
+	case typeinfo.BoolTypeIdentifier:
+		setType(parquet.Type_BOOLEAN)
+	case typeinfo.DatetimeTypeIdentifier:
+		if sqlType.Type() == sqltypes.Date {
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_DATE)
+		} else {
+			setConvertedType(parquet.Type_INT64, parquet.ConvertedType_TIMESTAMP_MICROS)
+		}
+	case typeinfo.DecimalTypeIdentifier:
+		decType := sqlType.(sql.DecimalType)
+		setConvertedType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_DECIMAL)
+		el.Precision = int32Ptr(int32(decType.Precision()))
+		el.Scale = int32Ptr(int32(decType.Scale()))
+	case typeinfo.EnumTypeIdentifier, typeinfo.SetTypeIdentifier, typeinfo.VarStringTypeIdentifier:
+		setConvertedType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_UTF8)
+	case typeinfo.FloatTypeIdentifier:
+		if sqlType.Type() == sqltypes.Float32 {
+			setType(parquet.Type_FLOAT)
+		} else {
+			setType(parquet.Type_DOUBLE)
+		}
+	case typeinfo.InlineBlobTypeIdentifier, typeinfo.VarBinaryTypeIdentifier:
+		setType(parquet.Type_BYTE_ARRAY)
+	case typeinfo.IntTypeIdentifier:
+		switch sqlType.Type() {
+		case sqltypes.Int8:
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_INT_8)
+		case sqltypes.Int16:
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_INT_16)
+		case sqltypes.Int24, sqltypes.Int32:
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_INT_32)
+		default:
+			setConvertedType(parquet.Type_INT64, parquet.ConvertedType_INT_64)
+		}
+	case typeinfo.JSONTypeIdentifier:
+		setConvertedType(parquet.Type_BYTE_ARRAY, parquet.ConvertedType_JSON)
+	case typeinfo.TimeTypeIdentifier:
+		setConvertedType(parquet.Type_INT64, parquet.ConvertedType_TIME_MICROS)
+	case typeinfo.UintTypeIdentifier:
+		switch sqlType.Type() {
+		case sqltypes.Uint8:
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_UINT_8)
+		case sqltypes.Uint16:
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_UINT_16)
+		case sqltypes.Uint24, sqltypes.Uint32:
+			setConvertedType(parquet.Type_INT32, parquet.ConvertedType_UINT_32)
+		default:
+			setConvertedType(parquet.Type_INT64, parquet.ConvertedType_UINT_64)
+		}
+	case typeinfo.UuidTypeIdentifier:
+		setType(parquet.Type_FIXED_LEN_BYTE_ARRAY)
+		el.TypeLength = int32Ptr(uuidLength)
+		el.LogicalType = parquet.NewLogicalType()
+		el.LogicalType.UUID = parquet.NewUUIDType()
+	case typeinfo.YearTypeIdentifier:
+		setConvertedType(parquet.Type_INT32, parquet.ConvertedType_INT_16)
+	default:
+		return nil, fmt.Errorf("column '%s' of type %s cannot be exported to parquet", col.Name, col.TypeInfo.String())
+	}
+
+	return el, nil
+}
+
+// valueToParquet converts |v|, a value of the column |col|, to the physical value of the parquet column created by
+// schemaElementFromColumn.
+func valueToParquet(col schema.Column, v types.Value) (interface{}, error) {
+	if types.IsNull(v) {
+		return nil, nil
+	}
+
+	switch col.TypeInfo.GetTypeIdentifier() {
+	case typeinfo.BitTypeIdentifier:
+		return int64(v.(types.Uint)), nil
+	case typeinfo.BoolTypeIdentifier:
+		return bool(v.(types.Bool)), nil
+	case typeinfo.DatetimeTypeIdentifier:
+		t := time.Time(v.(types.Timestamp)).UTC()
+		if col.TypeInfo.ToSqlType().Type() == sqltypes.Date {
+			days := t.Unix() / secondsPerDay
+			if t.Unix() < 0 && t.Unix()%secondsPerDay != 0 {
+				days--
+			}
+			return int32(days), nil
+		}
+		return t.Unix()*1e6 + int64(t.Nanosecond()/1e3), nil
+	case typeinfo.DecimalTypeIdentifier:
+		scale := int32(col.TypeInfo.ToSqlType().(sql.DecimalType).Scale())
+		return string(decimalToBytes(decimal.Decimal(v.(types.Decimal)), scale)), nil
+	case typeinfo.FloatTypeIdentifier:
+		if col.TypeInfo.ToSqlType().Type() == sqltypes.Float32 {
+			return float32(v.(types.Float)), nil
+		}
+		return float64(v.(types.Float)), nil
+	case typeinfo.InlineBlobTypeIdentifier:
+		return string(v.(types.InlineBlob)), nil
+	case typeinfo.IntTypeIdentifier, typeinfo.YearTypeIdentifier:
+		if col.TypeInfo.ToSqlType().Type() == sqltypes.Int64 {
+			return int64(v.(types.Int)), nil
+		}
+		return int32(v.(types.Int)), nil
+	case typeinfo.TimeTypeIdentifier:
+		return int64(v.(types.Int)), nil
+	case typeinfo.UintTypeIdentifier:
+		if col.TypeInfo.ToSqlType().Type() == sqltypes.Uint64 {
+			return int64(v.(types.Uint)), nil
+		}
+		return int32(v.(types.Uint)), nil
+	case typeinfo.UuidTypeIdentifier:
+		id := v.(types.UUID)
+		return string(id[:]), nil
+	case typeinfo.VarBinaryTypeIdentifier:
+		return col.TypeInfo.ConvertNomsValueToValue(v)
+	}
+
+	str, err := col.TypeInfo.FormatValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return *str, nil
+}
+
+// decimalToBytes returns the unscaled value of |d| as a big-endian two's complement integer, which is how parquet
+// stores decimals in byte arrays.
+func decimalToBytes(d decimal.Decimal, scale int32) []byte {
+	unscaled := d.Shift(scale).BigInt()
+	n := unscaled.BitLen()/8 + 1
+	if unscaled.Sign() < 0 {
+		n = new(big.Int).Not(unscaled).BitLen()/8 + 1
+		unscaled.Add(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(8*n)))
+	}
+	b := unscaled.Bytes()
+	return append(make([]byte, n-len(b)), b...)
+}
+
+func decimalFromBytes(b []byte, scale int32) decimal.Decimal {
+	unscaled := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return decimal.NewFromBigInt(unscaled, -scale)
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/marshal"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const rootName = "schema"
+
+// ParquetWriter writes rows to a parquet file. Each column is written using the parquet type that best matches the
+// column's type.
+type ParquetWriter struct {
+	closer io.Closer
+	pw     *writer.ParquetWriter
+	sch    schema.Schema
+	cols   []schema.Column
+}
+
+// OpenParquetWriter creates the parquet file at |path| and returns a writer for it.
+func OpenParquetWriter(path string, fs filesys.WritableFS, outSch schema.Schema) (*ParquetWriter, error) {
+	err := fs.MkDirs(filepath.Dir(path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	wr, err := fs.OpenForWrite(path, os.ModePerm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pw, err := NewParquetWriter(wr, outSch)
+
+	if err != nil {
+		_ = wr.Close()
+		return nil, err
+	}
+
+	return pw, nil
+}
+
+// NewParquetWriter creates a ParquetWriter that writes to |wr|, which is closed when the writer is closed.
+func NewParquetWriter(wr io.WriteCloser, outSch schema.Schema) (*ParquetWriter, error) {
+	cols := outSch.GetAllCols().GetColumns()
+
+	root := parquet.NewSchemaElement()
+	root.Name = rootName
+	root.NumChildren = int32Ptr(int32(len(cols)))
+	root.RepetitionType = parquet.FieldRepetitionTypePtr(parquet.FieldRepetitionType_REQUIRED)
+
+	elements := []*parquet.SchemaElement{root}
+	for _, col := range cols {
+		el, err := schemaElementFromColumn(col)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, el)
+	}
+
+	pw, err := writer.NewParquetWriter(writerfile.NewWriterFile(wr), elements, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.MarshalFunc = marshal.MarshalCSV
+
+	return &ParquetWriter{closer: wr, pw: pw, sch: outSch, cols: cols}, nil
+}
+
+// GetSchema returns the schema of the rows written by this writer
+func (pqw *ParquetWriter) GetSchema() schema.Schema {
+	return pqw.sch
+}
+
+// WriteRow will write a row to a table
+func (pqw *ParquetWriter) WriteRow(ctx context.Context, r row.Row) error {
+	rec := make([]interface{}, len(pqw.cols))
+	for i, col := range pqw.cols {
+		val, _ := r.GetColVal(col.Tag)
+
+		var err error
+		rec[i], err = valueToParquet(col, val)
+		if err != nil {
+			return err
+		}
+	}
+
+	return pqw.pw.Write(rec)
+}
+
+// Close should flush all writes, release resources being held
+func (pqw *ParquetWriter) Close(ctx context.Context) error {
+	if pqw.closer != nil {
+		errSt := pqw.pw.WriteStop()
+		errCl := pqw.closer.Close()
+		pqw.closer = nil
+
+		if errSt != nil {
+			return errSt
+		}
+
+		return errCl
+	}
+	return errors.New("already closed")
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -116,6 +117,12 @@ func (csvw *CSVWriter) WriteRow(ctx context.Context, r row.Row) error {
 		var v string
 		if val.Kind() == types.StringKind {
 			v = string(val.(types.String))
+		} else if val.Kind() == types.JSONKind {
+			doc, err := typeinfo.JSONType.FormatValue(val)
+			if err != nil {
+				return false, err
+			}
+			v = *doc
 		} else {
 			v, err = types.EncodedValue(ctx, val)
 			if err != nil {
@@ -255,9 +255,17 @@ func (fs *InMemFS) OpenForRead(fp string) (io.ReadCloser, error) {
 	}
 
 	fileObj := fs.objs[fp].(*memFile)
-	buf := bytes.NewBuffer(fileObj.data)
 
-	return ioutil.NopCloser(buf), nil
+	return memFileReader{bytes.NewReader(fileObj.data)}, nil
+}
+
+// memFileReader reads a file of an InMemFS. Like a file opened from the local filesystem it can seek.
+type memFileReader struct {
+	*bytes.Reader
+}
+
+func (r memFileReader) Close() error {
+	return nil
 }
 
 // ReadFile reads the entire contents of a file
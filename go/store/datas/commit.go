@@ -23,6 +23,7 @@ package datas
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 
@@ -45,6 +46,23 @@ const (
 	CommitName       = "Commit"
 )
 
+// ErrCommitNotFound is returned when walking the history of a commit reaches a parent commit which isn't in the
+// database.
+var ErrCommitNotFound = errors.New("commit not found")
+
+// CommitNotFoundError is the ErrCommitNotFound error for a particular commit hash.
+type CommitNotFoundError struct {
+	Hash hash.Hash
+}
+
+func (e CommitNotFoundError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrCommitNotFound, e.Hash)
+}
+
+func (e CommitNotFoundError) Unwrap() error {
+	return ErrCommitNotFound
+}
+
 var commitTemplate = types.MakeStructTemplate(CommitName, []string{CommitMetaField, ParentsField, ParentsListField, ValueField})
 
 var valueCommitType = nomdl.MustParseType(`Struct Commit {
@@ -146,7 +164,7 @@ func parentsToQueue(ctx context.Context, refs types.RefSlice, q *types.RefByHeig
 			return err
 		}
 		if v == nil {
-			return fmt.Errorf("target not found: %v", r.TargetHash())
+			return CommitNotFoundError{r.TargetHash()}
 		}
 
 		c, ok := v.(types.Struct)
@@ -216,7 +216,7 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, newHeadRef types.
 		return err
 	}
 
-	refSt, err := db.WriteValue(ctx, newSt) // will be orphaned if the tryCommitChunks() below fails
+	refSt, err := db.writeHeadValue(ctx, newSt) // will be orphaned if the tryCommitChunks() below fails
 
 	if err != nil {
 		return err
@@ -339,7 +339,7 @@ func (db *database) doCommit(ctx context.Context, datasetID string, commit types
 			return err
 		}
 
-		commitRef, err := db.WriteValue(ctx, commit) // will be orphaned if the tryCommitChunks() below fails
+		commitRef, err := db.writeHeadValue(ctx, commit) // will be orphaned if the tryCommitChunks() below fails
 
 		if err != nil {
 			return err
@@ -589,6 +589,69 @@ func (db *database) GC(ctx context.Context) error {
 	return db.ValueStore.GC(ctx)
 }
 
+// writeHeadValue writes |v|, the new head of a dataset. A commit at the recorded boundary of a shallow clone, which is
+// already stored but whose parents were left out, isn't written again, because writing it would fail the ValueStore's
+// completeness check. Every other head is written as usual.
+func (db *database) writeHeadValue(ctx context.Context, v types.Value) (types.Ref, error) {
+	atBoundary, err := db.isShallowBoundaryCommit(ctx, v)
+
+	if err != nil {
+		return types.Ref{}, err
+	}
+
+	if atBoundary {
+		return types.NewRef(v, db.Format())
+	}
+
+	return db.WriteValue(ctx, v)
+}
+
+// isShallowBoundaryCommit returns whether |v| is stored and the chunks it references which aren't stored are all
+// commits recorded as left out of a shallow clone of this database.
+func (db *database) isShallowBoundaryCommit(ctx context.Context, v types.Value) (bool, error) {
+	h, err := v.Hash(db.Format())
+
+	if err != nil {
+		return false, err
+	}
+
+	has, err := db.chunkStore().Has(ctx, h)
+
+	if err != nil || !has {
+		return false, err
+	}
+
+	refs := hash.NewHashSet()
+	err = v.WalkRefs(db.Format(), func(r types.Ref) error {
+		refs.Insert(r.TargetHash())
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	absent, err := db.chunkStore().HasMany(ctx, refs)
+
+	if err != nil || len(absent) == 0 {
+		return false, err
+	}
+
+	boundary, err := ShallowBoundary(ctx, db)
+
+	if err != nil {
+		return false, err
+	}
+
+	for h := range absent {
+		if !boundary.Has(h) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func (db *database) tryCommitChunks(ctx context.Context, currentDatasets types.Map, currentRootHash hash.Hash) error {
 	newRoot, err := db.WriteValue(ctx, currentDatasets)
 
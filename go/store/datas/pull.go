@@ -141,11 +141,22 @@ func clone(ctx context.Context, srcTS, sinkTS nbs.TableFileStore, eventCh chan<-
 		}
 	}
 
+	// The sink may already hold some of the table files, such as when a backup is synced again. Those files don't need
+	// to be downloaded, and the sink's root is updated from its current value.
+	sinkRoot, sinkFiles, err := sinkTS.Sources(ctx)
+	if err != nil {
+		return err
+	}
+	_, sinkFileIDToTF := mapTableFiles(sinkFiles)
+
 	// Initializes the list of fileIDs we are going to download, and the map of fileIDToTF.  If this clone takes a long
 	// time some of the urls within the nbs.TableFiles will expire and fail to download.  At that point we will retrieve
 	// the sources again, and update the fileIDToTF map with updated info, but not change the files we are downloading.
 	desiredFiles, fileIDToTF := mapTableFiles(tblFiles)
 	completed := make([]bool, len(desiredFiles))
+	for i, fileID := range desiredFiles {
+		_, completed[i] = sinkFileIDToTF[fileID]
+	}
 
 	report(TableFileEvent{Listed, tblFiles})
 
@@ -236,7 +247,7 @@ func clone(ctx context.Context, srcTS, sinkTS nbs.TableFileStore, eventCh chan<-
 		}
 	}
 
-	return sinkTS.SetRootChunk(ctx, root, hash.Hash{})
+	return sinkTS.SetRootChunk(ctx, root, sinkRoot)
 }
 
 // Pull objects that descend from sourceRef from srcDB to sinkDB.
@@ -569,3 +569,44 @@ func TestClone(t *testing.T) {
 		assert.True(t, reflect.DeepEqual(flakeySrc.TestTableFileStore, dest))
 	})
 }
+
+func TestCloneToExistingSink(t *testing.T) {
+	srcRoot := hash.Of([]byte("src root"))
+	src := &TestTableFileStore{
+		root: srcRoot,
+		tableFiles: map[string]*TestTableFile{
+			"file1": {fileID: "file1", numChunks: 1, data: []byte("Call me Ishmael.")},
+			"file2": {fileID: "file2", numChunks: 2, data: []byte("Some years ago")},
+		},
+	}
+
+	// file1 was copied by an earlier clone, and old is a file that is no longer in the source
+	existing := &TestTableFile{fileID: "file1", numChunks: 1, data: []byte("Call me Ishmael.")}
+	old := &TestTableFile{fileID: "old", numChunks: 1, data: []byte("never mind how long precisely")}
+	dest := &lastRootTableFileStore{
+		TestTableFileStore: &TestTableFileStore{
+			root:       hash.Of([]byte("dest root")),
+			tableFiles: map[string]*TestTableFile{"file1": existing, "old": old},
+		},
+	}
+
+	err := clone(context.Background(), src, dest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, srcRoot, dest.root)
+	assert.Equal(t, hash.Of([]byte("dest root")), dest.previous)
+	assert.Len(t, dest.tableFiles, 3)
+	assert.Same(t, existing, dest.tableFiles["file1"])
+	assert.Equal(t, src.tableFiles["file2"], dest.tableFiles["file2"])
+}
+
+// lastRootTableFileStore is a TestTableFileStore which records the previous root passed to SetRootChunk
+type lastRootTableFileStore struct {
+	*TestTableFileStore
+	previous hash.Hash
+}
+
+func (s *lastRootTableFileStore) SetRootChunk(ctx context.Context, root, previous hash.Hash) error {
+	s.previous = previous
+	return s.TestTableFileStore.SetRootChunk(ctx, root, previous)
+}
@@ -75,6 +75,7 @@ type Puller struct {
 	sinkDB        Database
 	rootChunkHash hash.Hash
 	downloaded    hash.HashSet
+	excluded      hash.HashSet
 
 	wr          *nbs.CmpChunkTableWriter
 	tempDir     string
@@ -173,6 +174,7 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 		sinkDB:        sinkDB,
 		rootChunkHash: rootChunkHash,
 		downloaded:    hash.HashSet{},
+		excluded:      hash.HashSet{},
 		tempDir:       tempDir,
 		wr:            wr,
 		chunksPerTF:   chunksPerTF,
@@ -180,6 +182,15 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 	}, nil
 }
 
+// ExcludeChunks keeps the chunks in |hashes| from being pulled. Chunks which are only reachable through the excluded
+// chunks aren't pulled either. This is used to pull the history of a commit only up to a set of shallow boundary
+// commits.
+func (p *Puller) ExcludeChunks(hashes hash.HashSet) {
+	for h := range hashes {
+		p.excluded.Insert(h)
+	}
+}
+
 func (p *Puller) processCompletedTables(ctx context.Context, ae *atomicerr.AtomicError, completedTables <-chan FilledWriters) {
 	type tempTblFile struct {
 		id          string
@@ -282,6 +293,7 @@ func (p *Puller) Pull(ctx context.Context) error {
 
 	for len(absent) > 0 {
 		limitToNewChunks(absent, p.downloaded)
+		limitToNewChunks(absent, p.excluded)
 
 		chunksInLevel := len(absent)
 		twDetails.ChunksInLevel = chunksInLevel
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/util/clienttest"
@@ -353,6 +354,81 @@ func TestPuller(t *testing.T) {
 	}
 }
 
+func TestPullerExcludeChunks(t *testing.T) {
+	ctx := context.Background()
+	db, err := tempDirDB(ctx)
+	require.NoError(t, err)
+
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+
+	var refs []types.Ref
+	for i := 0; i < 3; i++ {
+		parents, err := types.NewList(ctx, db)
+		require.NoError(t, err)
+		if len(refs) > 0 {
+			parents, err = types.NewList(ctx, db, refs[len(refs)-1])
+			require.NoError(t, err)
+		}
+
+		ds, err = db.Commit(ctx, ds, types.Int(i), CommitOptions{ParentsList: parents})
+		require.NoError(t, err)
+
+		r, ok, err := ds.MaybeHeadRef()
+		require.NoError(t, err)
+		require.True(t, ok)
+		refs = append(refs, r)
+	}
+
+	sinkdb, err := tempDirDB(ctx)
+	require.NoError(t, err)
+
+	tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+	err = os.MkdirAll(tmpDir, os.ModePerm)
+	require.NoError(t, err)
+
+	eventCh := make(chan PullerEvent, 128)
+	go func() {
+		for range eventCh {
+		}
+	}()
+
+	plr, err := NewPuller(ctx, tmpDir, 128, db, sinkdb, refs[2].TargetHash(), eventCh)
+	require.NoError(t, err)
+	plr.ExcludeChunks(hash.NewHashSet(refs[0].TargetHash()))
+
+	err = plr.Pull(ctx)
+	close(eventCh)
+	require.NoError(t, err)
+
+	for i, r := range refs {
+		ok, err := sinkdb.chunkStore().Has(ctx, r.TargetHash())
+		require.NoError(t, err)
+		assert.Equal(t, i > 0, ok, "commit %d", i)
+	}
+
+	// a stored commit whose parent was excluded can become the head of a dataset once the excluded parent is recorded
+	// as the shallow boundary
+	boundary, err := types.NewSet(ctx, sinkdb, types.String(refs[0].TargetHash().String()))
+	require.NoError(t, err)
+	boundaryDS, err := sinkdb.GetDataset(ctx, ShallowBoundaryDataset)
+	require.NoError(t, err)
+	_, err = sinkdb.CommitValue(ctx, boundaryDS, boundary)
+	require.NoError(t, err)
+	recorded, err := ShallowBoundary(ctx, sinkdb)
+	require.NoError(t, err)
+	assert.Equal(t, hash.NewHashSet(refs[0].TargetHash()), recorded)
+
+	sinkDS, err := sinkdb.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	sinkDS, err = sinkdb.SetHead(ctx, sinkDS, refs[1])
+	require.NoError(t, err)
+	r, ok, err := sinkDS.MaybeHeadRef()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, refs[1].TargetHash(), r.TargetHash())
+}
+
 func makeABigTable(ctx context.Context, db Database) (types.Map, error) {
 	m, err := types.NewMap(ctx, db)
 
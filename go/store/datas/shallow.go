@@ -0,0 +1,75 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ShallowBoundaryDataset is the dataset whose head has the set of hashes of the commits left out of a shallow clone as
+// its value.
+const ShallowBoundaryDataset = "refs/internal/shallow"
+
+// ShallowBoundary returns the hashes of the commits which were left out of |db| by a shallow clone. The set is empty if
+// the database isn't a shallow clone.
+func ShallowBoundary(ctx context.Context, db Database) (hash.HashSet, error) {
+	boundary := hash.NewHashSet()
+	ds, err := db.GetDataset(ctx, ShallowBoundaryDataset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	st, ok := ds.MaybeHead()
+
+	if !ok {
+		return boundary, nil
+	}
+
+	val, ok, err := st.MaybeGet(ValueField)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("%s has no value", ShallowBoundaryDataset)
+	}
+
+	set, ok := val.(types.Set)
+
+	if !ok {
+		return nil, fmt.Errorf("%s does not point at a set of commit hashes", ShallowBoundaryDataset)
+	}
+
+	err = set.IterAll(ctx, func(v types.Value) error {
+		h, ok := hash.MaybeParse(string(v.(types.String)))
+
+		if !ok {
+			return fmt.Errorf("%s has an invalid commit hash: %s", ShallowBoundaryDataset, v.(types.String))
+		}
+
+		boundary.Insert(h)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return boundary, nil
+}
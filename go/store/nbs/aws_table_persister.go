@@ -26,6 +26,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"sort"
 	"sync"
@@ -146,6 +147,90 @@ func (s3p awsTablePersister) Persist(ctx context.Context, mt *memTable, haver ch
 	return newReaderFromIndexData(s3p.indexCache, data, name, tra, s3BlockSize)
 }
 
+// CopyTableFile implements tableFilePersister. The table file is uploaded as it is read from |r|, one part at a time,
+// unless it is small enough to be written to DynamoDB.
+func (s3p awsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, name addr, chunkCount uint32) error {
+	if s3p.limits.tableMayBeInDynamo(chunkCount) {
+		data, err := ioutil.ReadAll(io.LimitReader(r, int64(s3p.limits.itemMax)))
+
+		if err != nil {
+			return err
+		}
+
+		if len(data) < s3p.limits.itemMax && s3p.limits.tableFitsInDynamo(name, len(data), chunkCount) {
+			return s3p.ddb.Write(ctx, name, data)
+		}
+
+		r = io.MultiReader(bytes.NewReader(data), r)
+	}
+
+	return s3p.multipartUploadFromReader(ctx, r, name.String())
+}
+
+func (s3p awsTablePersister) multipartUploadFromReader(ctx context.Context, r io.Reader, key string) error {
+	uploadID, err := s3p.startMultipartUpload(ctx, key)
+
+	if err != nil {
+		return err
+	}
+
+	multipartUpload, err := s3p.uploadPartsFromReader(ctx, r, key, uploadID)
+	if err != nil {
+		_ = s3p.abortMultipartUpload(ctx, key, uploadID)
+		return err
+	}
+
+	return s3p.completeMultipartUpload(ctx, key, uploadID, multipartUpload)
+}
+
+// uploadPartsFromReader uploads the data read from |r| in parts of the target part size. Only one part is held in
+// memory at a time, so parts are uploaded one after another.
+func (s3p awsTablePersister) uploadPartsFromReader(ctx context.Context, r io.Reader, key, uploadID string) (*s3.CompletedMultipartUpload, error) {
+	multipartUpload := &s3.CompletedMultipartUpload{}
+	buff := make([]byte, s3p.limits.partTarget)
+
+	for partNum := int64(1); ; partNum++ { // Parts are 1-indexed
+		n, err := io.ReadFull(r, buff)
+
+		if err == io.EOF && partNum > 1 {
+			// the previous part ended exactly at the end of the data
+			break
+		}
+
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return nil, err
+		}
+
+		if partNum > maxS3Parts {
+			return nil, errors.New("exceeded maximum parts")
+		}
+
+		if s3p.rl != nil {
+			s3p.rl <- struct{}{}
+		}
+		etag, err := s3p.uploadPart(ctx, buff[:n], key, uploadID, partNum)
+		if s3p.rl != nil {
+			<-s3p.rl
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		multipartUpload.Parts = append(multipartUpload.Parts, &s3.CompletedPart{
+			ETag:       aws.String(etag),
+			PartNumber: aws.Int64(partNum),
+		})
+
+		if last {
+			break
+		}
+	}
+
+	return multipartUpload, nil
+}
+
 func (s3p awsTablePersister) multipartUpload(ctx context.Context, data []byte, key string) error {
 	uploadID, err := s3p.startMultipartUpload(ctx, key)
 
@@ -22,11 +22,13 @@
 package nbs
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"math/rand"
 	"sync"
 	"testing"
+	"testing/iotest"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -288,6 +290,37 @@ func (m *failingFakeS3) UploadPartWithContext(ctx aws.Context, input *s3.UploadP
 	return nil, mockAWSError("MalformedXML")
 }
 
+func TestAWSTablePersisterCopyTableFile(t *testing.T) {
+	tableData, name, err := buildTable(testChunks)
+	require.NoError(t, err)
+
+	testIt := func(t *testing.T, partTarget uint64, r io.Reader) {
+		assert := assert.New(t)
+		s3svc, ddb := makeFakeS3(t), makeFakeDTS(makeFakeDDB(t), nil)
+		limits := awsLimits{partTarget: partTarget}
+		s3p := awsTablePersister{s3: s3svc, bucket: "bucket", ddb: ddb, limits: limits, parseIndex: parseIndexF}
+
+		err := s3p.CopyTableFile(context.Background(), r, name, uint32(len(testChunks)))
+		require.NoError(t, err)
+
+		if r, err := s3svc.readerForTable(name); assert.NotNil(r) && assert.NoError(err) {
+			assertChunksInReader(testChunks, r, assert)
+		}
+	}
+
+	t.Run("InMultipleParts", func(t *testing.T) {
+		testIt(t, uint64(len(tableData)/3), bytes.NewReader(tableData))
+	})
+
+	t.Run("InSinglePart", func(t *testing.T) {
+		testIt(t, uint64(len(tableData)), bytes.NewReader(tableData))
+	})
+
+	t.Run("ShortReads", func(t *testing.T) {
+		testIt(t, uint64(len(tableData)/3), iotest.OneByteReader(bytes.NewReader(tableData)))
+	})
+}
+
 func TestAWSTablePersisterDividePlan(t *testing.T) {
 	assert := assert.New(t)
 	minPartSize, maxPartSize := uint64(16), uint64(32)
@@ -53,6 +53,12 @@ func (bsp *blobstorePersister) Persist(ctx context.Context, mt *memTable, haver
 	return newReaderFromIndexData(bsp.indexCache, data, name, bsTRA, bsp.blockSize)
 }
 
+// CopyTableFile implements tableFilePersister.
+func (bsp *blobstorePersister) CopyTableFile(ctx context.Context, r io.Reader, name addr, chunkCount uint32) error {
+	_, err := bsp.bs.Put(ctx, name.String(), r)
+	return err
+}
+
 // ConjoinAll (Not currently implemented) conjoins all chunks in |sources| into a single,
 // new chunkSource.
 func (bsp *blobstorePersister) ConjoinAll(ctx context.Context, sources chunkSources, stats *Stats) (chunkSource, error) {
@@ -64,6 +64,28 @@ func (ftp *fsTablePersister) Persist(ctx context.Context, mt *memTable, haver ch
 	return ftp.persistTable(ctx, name, data, chunkCount, stats)
 }
 
+// CopyTableFile implements tableFilePersister.
+func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, name addr, chunkCount uint32) (err error) {
+	var f *os.File
+	f, err = os.OpenFile(filepath.Join(ftp.dir, name.String()), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		closeErr := f.Close()
+
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	_, err = io.Copy(f, r)
+
+	return err
+}
+
 func (ftp *fsTablePersister) persistTable(ctx context.Context, name addr, data []byte, chunkCount uint32, stats *Stats) (cs chunkSource, err error) {
 	if chunkCount == 0 {
 		return emptyChunkSource{}, nil
@@ -50,6 +50,16 @@ func (nbsMW *NBSMetricWrapper) Size(ctx context.Context) (uint64, error) {
 	return nbsMW.nbs.Size(ctx)
 }
 
+// ChunkCount returns the number of chunks in the table files of the wrapped block store.
+func (nbsMW *NBSMetricWrapper) ChunkCount() (uint32, error) {
+	return nbsMW.nbs.ChunkCount()
+}
+
+// PhysicalLen returns the number of bytes in the table files of the wrapped block store.
+func (nbsMW *NBSMetricWrapper) PhysicalLen() (uint64, error) {
+	return nbsMW.nbs.PhysicalLen()
+}
+
 // WriteTableFile will read a table file from the provided reader and write it to the TableFileStore
 func (nbsMW *NBSMetricWrapper) WriteTableFile(ctx context.Context, fileId string, numChunks int, rd io.Reader, contentLength uint64, contentHash []byte) error {
 	return nbsMW.nbs.WriteTableFile(ctx, fileId, numChunks, rd, contentLength, contentHash)
@@ -27,7 +27,6 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"reflect"
 	"sort"
 	"sync"
@@ -969,6 +968,20 @@ func (nbs *NomsBlockStore) StatsSummary() string {
 	return fmt.Sprintf("Root: %s; Chunk Count %d; Physical Bytes %s", nbs.upstream.root, cnt, humanize.Bytes(physLen))
 }
 
+// ChunkCount returns the number of chunks in the table files of the store.
+func (nbs *NomsBlockStore) ChunkCount() (uint32, error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	return nbs.tables.count()
+}
+
+// PhysicalLen returns the number of bytes in the table files of the store.
+func (nbs *NomsBlockStore) PhysicalLen() (uint64, error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	return nbs.tables.physicalLen()
+}
+
 // tableFile is our implementation of TableFile.
 type tableFile struct {
 	info TableSpecInfo
@@ -1097,9 +1110,10 @@ func (nbs *NomsBlockStore) chunkSourcesByAddr() (map[addr]chunkSource, error) {
 
 func (nbs *NomsBlockStore) SupportedOperations() TableFileStoreOps {
 	_, ok := nbs.p.(*fsTablePersister)
+	_, canWrite := nbs.p.(tableFilePersister)
 	return TableFileStoreOps{
 		CanRead:  true,
-		CanWrite: ok,
+		CanWrite: canWrite,
 		CanPrune: ok,
 		CanGC:    ok,
 	}
@@ -1107,45 +1121,24 @@ func (nbs *NomsBlockStore) SupportedOperations() TableFileStoreOps {
 
 // WriteTableFile will read a table file from the provided reader and write it to the TableFileStore
 func (nbs *NomsBlockStore) WriteTableFile(ctx context.Context, fileId string, numChunks int, rd io.Reader, contentLength uint64, contentHash []byte) error {
-	fsPersister, ok := nbs.p.(*fsTablePersister)
+	tfp, ok := nbs.p.(tableFilePersister)
 
 	if !ok {
 		return errors.New("Not implemented")
 	}
 
-	path := filepath.Join(fsPersister.dir, fileId)
-
-	err := func() (err error) {
-		var f *os.File
-		f, err = os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
-
-		if err != nil {
-			return err
-		}
-
-		defer func() {
-			closeErr := f.Close()
-
-			if err == nil {
-				err = closeErr
-			}
-		}()
+	fileIdHash, ok := hash.MaybeParse(fileId)
 
-		_, err = io.Copy(f, rd)
+	if !ok {
+		return errors.New("invalid base32 encoded hash: " + fileId)
+	}
 
-		return err
-	}()
+	err := tfp.CopyTableFile(ctx, rd, addr(fileIdHash), uint32(numChunks))
 
 	if err != nil {
 		return err
 	}
 
-	fileIdHash, ok := hash.MaybeParse(fileId)
-
-	if !ok {
-		return errors.New("invalid base32 encoded hash: " + fileId)
-	}
-
 	_, err = nbs.UpdateManifest(ctx, map[hash.Hash]uint32{fileIdHash: uint32(numChunks)})
 
 	return err
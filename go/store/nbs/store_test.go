@@ -31,6 +31,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -107,6 +108,29 @@ func TestNBSAsTableFileStore(t *testing.T) {
 	require.Greater(t, size, uint64(0))
 }
 
+func TestBlobstoreAsTableFileStore(t *testing.T) {
+	ctx := context.Background()
+	st, err := NewBSStore(ctx, types.Format_Default.VersionString(), blobstore.NewInMemoryBlobstore(), defaultMemTableSize)
+	require.NoError(t, err)
+	assert.True(t, st.SupportedOperations().CanWrite)
+
+	numTableFiles := 8
+	fileToData := populateLocalStore(t, st, numTableFiles)
+
+	_, sources, err := st.Sources(ctx)
+	require.NoError(t, err)
+	require.Equal(t, numTableFiles, len(sources))
+
+	for _, src := range sources {
+		rd, err := src.Open(ctx)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(rd)
+		require.NoError(t, err)
+		require.NoError(t, rd.Close())
+		assert.Equal(t, fileToData[src.FileID()], data)
+	}
+}
+
 type tableFileSet map[string]TableFile
 
 func (s tableFileSet) contains(fileName string) (ok bool) {
@@ -28,6 +28,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"sync"
 
@@ -55,6 +56,13 @@ type tablePersister interface {
 	PruneTableFiles(ctx context.Context, contents manifestContents) error
 }
 
+// tableFilePersister is implemented by tablePersisters that can store a complete table file, such as one read from
+// another table file store.
+type tableFilePersister interface {
+	// CopyTableFile makes the table file named |name|, containing |chunkCount| chunks and read from |r|, durable.
+	CopyTableFile(ctx context.Context, r io.Reader, name addr, chunkCount uint32) error
+}
+
 // indexCache provides sized storage for table indices. While getting and/or
 // setting the cache entry for a given table name, the caller MUST hold the
 // lock that for that entry.
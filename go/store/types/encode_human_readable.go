@@ -336,6 +336,22 @@ func (w *hrsWriter) Write(ctx context.Context, v Value) error {
 	case TypeKind:
 		w.writeType(v.(*Type), map[*Type]struct{}{})
 
+	case JSONKind:
+		inner, err := v.(JSON).Inner()
+
+		if err != nil {
+			return err
+		}
+
+		w.write("JSON(")
+		err = w.Write(ctx, inner)
+
+		if err != nil {
+			return err
+		}
+
+		w.write(")")
+
 	case StructKind:
 		err := w.writeStruct(ctx, v.(Struct))
 
@@ -0,0 +1,159 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/store/d"
+)
+
+// JSON is a JSON document. The document is stored as a tree of noms values: objects are stored as a Map keyed by
+// String, arrays as a List, and scalars as String, Decimal, Float, Int, Uint, Bool or Null values. Storing the
+// document this way, rather than as a string, means that equal documents have equal encodings regardless of their
+// whitespace and the order of their keys, and that large documents share structure with the documents they were
+// derived from.
+type JSON struct {
+	valueImpl
+}
+
+// NewJSONDoc wraps |value|, which must be a valid JSON tree of noms values, as a JSON document.
+func NewJSONDoc(nbf *NomsBinFormat, vrw ValueReadWriter, value Value) (JSON, error) {
+	w := newBinaryNomsWriter()
+	err := JSONKind.writeTo(&w, nbf)
+
+	if err != nil {
+		return EmptyJSONDoc(nbf), err
+	}
+
+	err = value.writeTo(&w, nbf)
+
+	if err != nil {
+		return EmptyJSONDoc(nbf), err
+	}
+
+	return JSON{valueImpl{vrw, nbf, w.data(), nil}}, nil
+}
+
+// EmptyJSONDoc returns a JSON document holding the JSON null value.
+func EmptyJSONDoc(nbf *NomsBinFormat) JSON {
+	w := newBinaryNomsWriter()
+	d.PanicIfError(JSONKind.writeTo(&w, nbf))
+	d.PanicIfError(NullValue.writeTo(&w, nbf))
+
+	return JSON{valueImpl{nil, nbf, w.data(), nil}}
+}
+
+// readJSON reads the data provided by a decoder and moves the decoder forward.
+func readJSON(nbf *NomsBinFormat, dec *valueDecoder) (JSON, error) {
+	start := dec.pos()
+
+	if dec.PeekKind() != JSONKind {
+		return JSON{}, errors.New("current value is not a JSON document")
+	}
+
+	err := skipJSON(nbf, dec)
+
+	if err != nil {
+		return JSON{}, err
+	}
+
+	end := dec.pos()
+	return JSON{valueImpl{dec.vrw, nbf, dec.byteSlice(start, end), nil}}, nil
+}
+
+func skipJSON(nbf *NomsBinFormat, dec *valueDecoder) error {
+	dec.skipKind()
+	return dec.SkipValue(nbf)
+}
+
+func walkJSON(nbf *NomsBinFormat, r *refWalker, cb RefCallback) error {
+	r.skipKind()
+	return r.walkValue(nbf, cb)
+}
+
+// Inner returns the noms value at the root of the document.
+func (t JSON) Inner() (Value, error) {
+	dec := t.decoder()
+	dec.skipKind()
+	return dec.readValue(t.format())
+}
+
+// Value interface
+func (t JSON) Value(ctx context.Context) (Value, error) {
+	return t, nil
+}
+
+func (t JSON) WalkValues(ctx context.Context, cb ValueCallback) error {
+	val, err := t.Inner()
+
+	if err != nil {
+		return err
+	}
+
+	return cb(val)
+}
+
+func (t JSON) typeOf() (*Type, error) {
+	return PrimitiveTypeMap[JSONKind], nil
+}
+
+func (t JSON) Kind() NomsKind {
+	return JSONKind
+}
+
+func (t JSON) Less(nbf *NomsBinFormat, other LesserValuable) (bool, error) {
+	otherJSON, ok := other.(JSON)
+	if !ok {
+		return JSONKind < other.Kind(), nil
+	}
+
+	val, err := t.Inner()
+
+	if err != nil {
+		return false, err
+	}
+
+	otherVal, err := otherJSON.Inner()
+
+	if err != nil {
+		return false, err
+	}
+
+	return val.Less(nbf, otherVal)
+}
+
+// The type of a JSON document does not depend on its contents, so JSON is treated as a primitive by the type system.
+// Reading and skipping JSON values requires a valueDecoder, which is special cased in readValue and SkipValue, so
+// readFrom and skip are never called.
+func (t JSON) isPrimitive() bool {
+	return true
+}
+
+func (t JSON) readFrom(nbf *NomsBinFormat, b *binaryNomsReader) (Value, error) {
+	panic("unreachable")
+}
+
+func (t JSON) skip(nbf *NomsBinFormat, b *binaryNomsReader) {
+	panic("unreachable")
+}
+
+func (t JSON) HumanReadableString() string {
+	s, err := EncodedValue(context.Background(), t)
+	d.PanicIfError(err)
+
+	return s
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	vs := newTestValueStore()
+
+	list, err := NewList(ctx, vs, Int(1), Float(2.5), Bool(true), NullValue)
+	require.NoError(t, err)
+	obj, err := NewMap(ctx, vs, String("a"), list, String("b"), String("c"))
+	require.NoError(t, err)
+	doc, err := NewJSONDoc(vs.Format(), vs, obj)
+	require.NoError(t, err)
+
+	assert.Equal(t, JSONKind, doc.Kind())
+	inner, err := doc.Inner()
+	require.NoError(t, err)
+	assert.True(t, obj.Equals(inner))
+
+	tup, err := NewTuple(vs.Format(), Int(1), doc, String("after"))
+	require.NoError(t, err)
+	fields, err := tup.AsSlice()
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+	assert.True(t, doc.Equals(fields[1]))
+	assert.True(t, String("after").Equals(fields[2]))
+
+	typ, err := TypeOf(doc)
+	require.NoError(t, err)
+	assert.Equal(t, PrimitiveTypeMap[JSONKind], typ)
+	assert.Equal(t, "JSON(\"abc\")", mustJSONDoc(t, vs, String("abc")).HumanReadableString())
+	assert.Contains(t, doc.HumanReadableString(), "\"b\": \"c\"")
+}
+
+func mustJSONDoc(t *testing.T, vrw ValueReadWriter, v Value) JSON {
+	doc, err := NewJSONDoc(vrw.Format(), vrw, v)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestJSONLess(t *testing.T) {
+	vs := newTestValueStore()
+
+	a := mustJSONDoc(t, vs, Int(1))
+	b := mustJSONDoc(t, vs, Int(2))
+
+	less, err := a.Less(vs.Format(), b)
+	require.NoError(t, err)
+	assert.True(t, less)
+	less, err = b.Less(vs.Format(), a)
+	require.NoError(t, err)
+	assert.False(t, less)
+	less, err = a.Less(vs.Format(), Int(1))
+	require.NoError(t, err)
+	assert.False(t, less)
+}
+
+func TestJSONWalkRefs(t *testing.T) {
+	ctx := context.Background()
+	vs := newTestValueStore()
+
+	ref, err := vs.WriteValue(ctx, String("referenced"))
+	require.NoError(t, err)
+	doc, err := NewJSONDoc(vs.Format(), vs, ref)
+	require.NoError(t, err)
+
+	var refs []Ref
+	err = doc.WalkRefs(vs.Format(), func(r Ref) error {
+		refs = append(refs, r)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, ref.TargetHash(), refs[0].TargetHash())
+}
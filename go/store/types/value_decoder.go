@@ -47,6 +47,7 @@ type CodecReader interface {
 	ReadTimestamp() (time.Time, error)
 	ReadDecimal() (decimal.Decimal, error)
 	ReadBlob() (Blob, error)
+	ReadJSON() (JSON, error)
 }
 
 var _ CodecReader = (*valueDecoder)(nil)
@@ -79,6 +80,10 @@ func (r *valueDecoder) ReadBlob() (Blob, error) {
 	return newBlob(seq), nil
 }
 
+func (r *valueDecoder) ReadJSON() (JSON, error) {
+	return readJSON(r.vrw.Format(), r)
+}
+
 func (r *valueDecoder) readRef(nbf *NomsBinFormat) (Ref, error) {
 	return readRef(nbf, &(r.typedBinaryNomsReader))
 }
@@ -345,6 +350,8 @@ func (r *valueDecoder) readValue(nbf *NomsBinFormat) (Value, error) {
 		return r.readStruct(nbf)
 	case TupleKind:
 		return r.readTuple(nbf)
+	case JSONKind:
+		return r.readJSON(nbf)
 	case TypeKind:
 		r.skipKind()
 		return r.readType()
@@ -425,6 +432,11 @@ func (r *valueDecoder) SkipValue(nbf *NomsBinFormat) error {
 		if err != nil {
 			return err
 		}
+	case JSONKind:
+		err := r.skipJSON(nbf)
+		if err != nil {
+			return err
+		}
 	case TypeKind:
 		r.skipKind()
 		err := r.skipType()
@@ -478,6 +490,12 @@ func (r *valueDecoder) readTypeOfValue(nbf *NomsBinFormat) (*Type, error) {
 		}
 		d.Chk.True(val != nil)
 		return val.typeOf()
+	case JSONKind:
+		err := r.skipJSON(nbf)
+		if err != nil {
+			return nil, err
+		}
+		return PrimitiveTypeMap[JSONKind], nil
 	case TypeKind:
 		r.skipKind()
 		err := r.skipType()
@@ -564,6 +582,10 @@ func (r *valueDecoder) readTuple(nbf *NomsBinFormat) (Tuple, error) {
 	return readTuple(nbf, r)
 }
 
+func (r *valueDecoder) readJSON(nbf *NomsBinFormat) (JSON, error) {
+	return readJSON(nbf, r)
+}
+
 func (r *valueDecoder) skipStruct(nbf *NomsBinFormat) error {
 	return skipStruct(nbf, r)
 }
@@ -572,6 +594,10 @@ func (r *valueDecoder) skipTuple(nbf *NomsBinFormat) error {
 	return skipTuple(nbf, r)
 }
 
+func (r *valueDecoder) skipJSON(nbf *NomsBinFormat) error {
+	return skipJSON(nbf, r)
+}
+
 func (r *valueDecoder) readOrderedKey(nbf *NomsBinFormat) (orderedKey, error) {
 	switch r.PeekKind() {
 	case hashKind:
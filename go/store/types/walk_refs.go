@@ -188,6 +188,8 @@ func (r *refWalker) walkValue(nbf *NomsBinFormat, cb RefCallback) error {
 		return r.walkStruct(nbf, cb)
 	case TupleKind:
 		return r.walkTuple(nbf, cb)
+	case JSONKind:
+		return r.walkJSON(nbf, cb)
 	case TypeKind:
 		r.skipKind()
 		return r.skipType()
@@ -214,3 +216,7 @@ func (r *refWalker) walkStruct(nbf *NomsBinFormat, cb RefCallback) error {
 func (r *refWalker) walkTuple(nbf *NomsBinFormat, cb RefCallback) error {
 	return walkTuple(nbf, r, cb)
 }
+
+func (r *refWalker) walkJSON(nbf *NomsBinFormat, cb RefCallback) error {
+	return walkJSON(nbf, r, cb)
+}